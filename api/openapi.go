@@ -0,0 +1,80 @@
+// Package api embeds the OpenAPI specification of the HTTP API.
+package api
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed openapi.yaml
+var spec []byte
+
+// Load parses and validates the embedded specification.
+func Load() (*openapi3.T, error) {
+	loader := openapi3.NewLoader()
+
+	doc, err := loader.LoadFromData(spec)
+	if err != nil {
+		return nil, fmt.Errorf("load openapi spec: %w", err)
+	}
+
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("invalid openapi spec: %w", err)
+	}
+
+	return doc, nil
+}
+
+// MissingRoutes returns the registered routes ("METHOD /path") that have no
+// operation in doc. A gin parameter segment matches any templated spec
+// segment, so "/reviewers/:action" is covered by "/reviewers/{user_id}:reassign".
+func MissingRoutes(doc *openapi3.T, routes gin.RoutesInfo) []string {
+	var missing []string
+
+	for _, route := range routes {
+		if !documented(doc, route.Method, route.Path) {
+			missing = append(missing, route.Method+" "+route.Path)
+		}
+	}
+
+	sort.Strings(missing)
+
+	return missing
+}
+
+func documented(doc *openapi3.T, method, ginPath string) bool {
+	for specPath, item := range doc.Paths.Map() {
+		if matchPath(specPath, ginPath) && item.GetOperation(method) != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchPath(specPath, ginPath string) bool {
+	specSegments := strings.Split(specPath, "/")
+	ginSegments := strings.Split(ginPath, "/")
+	if len(specSegments) != len(ginSegments) {
+		return false
+	}
+
+	for i, segment := range ginSegments {
+		isParam := strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*")
+		if isParam != strings.Contains(specSegments[i], "{") {
+			return false
+		}
+
+		if !isParam && segment != specSegments[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,34 @@
+package api_test
+
+import (
+	"testing"
+
+	"github.com/IlyaAGL/avito_autumn_2025/api"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/app/router"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/infrastructure/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// TestSpecCoversRoutes fails when a route of the API, SCIM included, has no
+// operation in the spec.
+func TestSpecCoversRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	doc, err := api.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := router.New(router.Config{
+		Spec:      doc,
+		Metrics:   metrics.New(),
+		SCIMToken: "test-token",
+	}, router.Services{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if missing := api.MissingRoutes(doc, r.Routes()); len(missing) > 0 {
+		t.Errorf("routes missing from the OpenAPI spec: %v", missing)
+	}
+}
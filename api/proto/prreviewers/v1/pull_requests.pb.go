@@ -0,0 +1,777 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v5.29.3
+// source: prreviewers/v1/pull_requests.proto
+
+package prreviewersv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PullRequestStatus int32
+
+const (
+	PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED PullRequestStatus = 0
+	PullRequestStatus_PULL_REQUEST_STATUS_OPEN        PullRequestStatus = 1
+	PullRequestStatus_PULL_REQUEST_STATUS_MERGED      PullRequestStatus = 2
+)
+
+// Enum value maps for PullRequestStatus.
+var (
+	PullRequestStatus_name = map[int32]string{
+		0: "PULL_REQUEST_STATUS_UNSPECIFIED",
+		1: "PULL_REQUEST_STATUS_OPEN",
+		2: "PULL_REQUEST_STATUS_MERGED",
+	}
+	PullRequestStatus_value = map[string]int32{
+		"PULL_REQUEST_STATUS_UNSPECIFIED": 0,
+		"PULL_REQUEST_STATUS_OPEN":        1,
+		"PULL_REQUEST_STATUS_MERGED":      2,
+	}
+)
+
+func (x PullRequestStatus) Enum() *PullRequestStatus {
+	p := new(PullRequestStatus)
+	*p = x
+	return p
+}
+
+func (x PullRequestStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PullRequestStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_prreviewers_v1_pull_requests_proto_enumTypes[0].Descriptor()
+}
+
+func (PullRequestStatus) Type() protoreflect.EnumType {
+	return &file_prreviewers_v1_pull_requests_proto_enumTypes[0]
+}
+
+func (x PullRequestStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PullRequestStatus.Descriptor instead.
+func (PullRequestStatus) EnumDescriptor() ([]byte, []int) {
+	return file_prreviewers_v1_pull_requests_proto_rawDescGZIP(), []int{0}
+}
+
+type PullRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId     string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName   string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId          string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Status            PullRequestStatus      `protobuf:"varint,4,opt,name=status,proto3,enum=prreviewers.v1.PullRequestStatus" json:"status,omitempty"`
+	AssignedReviewers []string               `protobuf:"bytes,5,rep,name=assigned_reviewers,json=assignedReviewers,proto3" json:"assigned_reviewers,omitempty"`
+	MergedAt          *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=merged_at,json=mergedAt,proto3" json:"merged_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PullRequest) Reset() {
+	*x = PullRequest{}
+	mi := &file_prreviewers_v1_pull_requests_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullRequest) ProtoMessage() {}
+
+func (x *PullRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewers_v1_pull_requests_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullRequest.ProtoReflect.Descriptor instead.
+func (*PullRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewers_v1_pull_requests_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PullRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *PullRequest) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *PullRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *PullRequest) GetStatus() PullRequestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+}
+
+func (x *PullRequest) GetAssignedReviewers() []string {
+	if x != nil {
+		return x.AssignedReviewers
+	}
+	return nil
+}
+
+func (x *PullRequest) GetMergedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MergedAt
+	}
+	return nil
+}
+
+type ReviewStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OpenPrs       int32                  `protobuf:"varint,2,opt,name=open_prs,json=openPrs,proto3" json:"open_prs,omitempty"`
+	TotalPrs      int32                  `protobuf:"varint,3,opt,name=total_prs,json=totalPrs,proto3" json:"total_prs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewStats) Reset() {
+	*x = ReviewStats{}
+	mi := &file_prreviewers_v1_pull_requests_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewStats) ProtoMessage() {}
+
+func (x *ReviewStats) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewers_v1_pull_requests_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewStats.ProtoReflect.Descriptor instead.
+func (*ReviewStats) Descriptor() ([]byte, []int) {
+	return file_prreviewers_v1_pull_requests_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ReviewStats) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReviewStats) GetOpenPrs() int32 {
+	if x != nil {
+		return x.OpenPrs
+	}
+	return 0
+}
+
+func (x *ReviewStats) GetTotalPrs() int32 {
+	if x != nil {
+		return x.TotalPrs
+	}
+	return 0
+}
+
+type CreatePullRequestRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId        string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreatePullRequestRequest) Reset() {
+	*x = CreatePullRequestRequest{}
+	mi := &file_prreviewers_v1_pull_requests_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePullRequestRequest) ProtoMessage() {}
+
+func (x *CreatePullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewers_v1_pull_requests_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePullRequestRequest.ProtoReflect.Descriptor instead.
+func (*CreatePullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewers_v1_pull_requests_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreatePullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+type GetPullRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPullRequestRequest) Reset() {
+	*x = GetPullRequestRequest{}
+	mi := &file_prreviewers_v1_pull_requests_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPullRequestRequest) ProtoMessage() {}
+
+func (x *GetPullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewers_v1_pull_requests_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPullRequestRequest.ProtoReflect.Descriptor instead.
+func (*GetPullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewers_v1_pull_requests_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetPullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+type ListPullRequestsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unspecified returns pull requests in any status.
+	Status        PullRequestStatus `protobuf:"varint,1,opt,name=status,proto3,enum=prreviewers.v1.PullRequestStatus" json:"status,omitempty"`
+	AuthorId      string            `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPullRequestsRequest) Reset() {
+	*x = ListPullRequestsRequest{}
+	mi := &file_prreviewers_v1_pull_requests_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPullRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPullRequestsRequest) ProtoMessage() {}
+
+func (x *ListPullRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewers_v1_pull_requests_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPullRequestsRequest.ProtoReflect.Descriptor instead.
+func (*ListPullRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewers_v1_pull_requests_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListPullRequestsRequest) GetStatus() PullRequestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+}
+
+func (x *ListPullRequestsRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+type ListPullRequestsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequests  []*PullRequest         `protobuf:"bytes,1,rep,name=pull_requests,json=pullRequests,proto3" json:"pull_requests,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPullRequestsResponse) Reset() {
+	*x = ListPullRequestsResponse{}
+	mi := &file_prreviewers_v1_pull_requests_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPullRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPullRequestsResponse) ProtoMessage() {}
+
+func (x *ListPullRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewers_v1_pull_requests_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPullRequestsResponse.ProtoReflect.Descriptor instead.
+func (*ListPullRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_prreviewers_v1_pull_requests_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListPullRequestsResponse) GetPullRequests() []*PullRequest {
+	if x != nil {
+		return x.PullRequests
+	}
+	return nil
+}
+
+type MergePullRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergePullRequestRequest) Reset() {
+	*x = MergePullRequestRequest{}
+	mi := &file_prreviewers_v1_pull_requests_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergePullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergePullRequestRequest) ProtoMessage() {}
+
+func (x *MergePullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewers_v1_pull_requests_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergePullRequestRequest.ProtoReflect.Descriptor instead.
+func (*MergePullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewers_v1_pull_requests_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MergePullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+type ReassignReviewerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	OldReviewerId string                 `protobuf:"bytes,2,opt,name=old_reviewer_id,json=oldReviewerId,proto3" json:"old_reviewer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReassignReviewerRequest) Reset() {
+	*x = ReassignReviewerRequest{}
+	mi := &file_prreviewers_v1_pull_requests_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReassignReviewerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReassignReviewerRequest) ProtoMessage() {}
+
+func (x *ReassignReviewerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewers_v1_pull_requests_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReassignReviewerRequest.ProtoReflect.Descriptor instead.
+func (*ReassignReviewerRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewers_v1_pull_requests_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ReassignReviewerRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *ReassignReviewerRequest) GetOldReviewerId() string {
+	if x != nil {
+		return x.OldReviewerId
+	}
+	return ""
+}
+
+type ReassignReviewerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequest   *PullRequest           `protobuf:"bytes,1,opt,name=pull_request,json=pullRequest,proto3" json:"pull_request,omitempty"`
+	ReplacedBy    string                 `protobuf:"bytes,2,opt,name=replaced_by,json=replacedBy,proto3" json:"replaced_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReassignReviewerResponse) Reset() {
+	*x = ReassignReviewerResponse{}
+	mi := &file_prreviewers_v1_pull_requests_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReassignReviewerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReassignReviewerResponse) ProtoMessage() {}
+
+func (x *ReassignReviewerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewers_v1_pull_requests_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReassignReviewerResponse.ProtoReflect.Descriptor instead.
+func (*ReassignReviewerResponse) Descriptor() ([]byte, []int) {
+	return file_prreviewers_v1_pull_requests_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReassignReviewerResponse) GetPullRequest() *PullRequest {
+	if x != nil {
+		return x.PullRequest
+	}
+	return nil
+}
+
+func (x *ReassignReviewerResponse) GetReplacedBy() string {
+	if x != nil {
+		return x.ReplacedBy
+	}
+	return ""
+}
+
+type GetReviewStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReviewStatsRequest) Reset() {
+	*x = GetReviewStatsRequest{}
+	mi := &file_prreviewers_v1_pull_requests_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReviewStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReviewStatsRequest) ProtoMessage() {}
+
+func (x *GetReviewStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewers_v1_pull_requests_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReviewStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetReviewStatsRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewers_v1_pull_requests_proto_rawDescGZIP(), []int{9}
+}
+
+type GetReviewStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserStats     []*ReviewStats         `protobuf:"bytes,1,rep,name=user_stats,json=userStats,proto3" json:"user_stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReviewStatsResponse) Reset() {
+	*x = GetReviewStatsResponse{}
+	mi := &file_prreviewers_v1_pull_requests_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReviewStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReviewStatsResponse) ProtoMessage() {}
+
+func (x *GetReviewStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewers_v1_pull_requests_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReviewStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetReviewStatsResponse) Descriptor() ([]byte, []int) {
+	return file_prreviewers_v1_pull_requests_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetReviewStatsResponse) GetUserStats() []*ReviewStats {
+	if x != nil {
+		return x.UserStats
+	}
+	return nil
+}
+
+var File_prreviewers_v1_pull_requests_proto protoreflect.FileDescriptor
+
+const file_prreviewers_v1_pull_requests_proto_rawDesc = "" +
+	"\n" +
+	"\"prreviewers/v1/pull_requests.proto\x12\x0eprreviewers.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa1\x02\n" +
+	"\vPullRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x129\n" +
+	"\x06status\x18\x04 \x01(\x0e2!.prreviewers.v1.PullRequestStatusR\x06status\x12-\n" +
+	"\x12assigned_reviewers\x18\x05 \x03(\tR\x11assignedReviewers\x127\n" +
+	"\tmerged_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\bmergedAt\"^\n" +
+	"\vReviewStats\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\bopen_prs\x18\x02 \x01(\x05R\aopenPrs\x12\x1b\n" +
+	"\ttotal_prs\x18\x03 \x01(\x05R\btotalPrs\"\x8b\x01\n" +
+	"\x18CreatePullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\"?\n" +
+	"\x15GetPullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\"q\n" +
+	"\x17ListPullRequestsRequest\x129\n" +
+	"\x06status\x18\x01 \x01(\x0e2!.prreviewers.v1.PullRequestStatusR\x06status\x12\x1b\n" +
+	"\tauthor_id\x18\x02 \x01(\tR\bauthorId\"\\\n" +
+	"\x18ListPullRequestsResponse\x12@\n" +
+	"\rpull_requests\x18\x01 \x03(\v2\x1b.prreviewers.v1.PullRequestR\fpullRequests\"A\n" +
+	"\x17MergePullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\"i\n" +
+	"\x17ReassignReviewerRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12&\n" +
+	"\x0fold_reviewer_id\x18\x02 \x01(\tR\roldReviewerId\"{\n" +
+	"\x18ReassignReviewerResponse\x12>\n" +
+	"\fpull_request\x18\x01 \x01(\v2\x1b.prreviewers.v1.PullRequestR\vpullRequest\x12\x1f\n" +
+	"\vreplaced_by\x18\x02 \x01(\tR\n" +
+	"replacedBy\"\x17\n" +
+	"\x15GetReviewStatsRequest\"T\n" +
+	"\x16GetReviewStatsResponse\x12:\n" +
+	"\n" +
+	"user_stats\x18\x01 \x03(\v2\x1b.prreviewers.v1.ReviewStatsR\tuserStats*v\n" +
+	"\x11PullRequestStatus\x12#\n" +
+	"\x1fPULL_REQUEST_STATUS_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18PULL_REQUEST_STATUS_OPEN\x10\x01\x12\x1e\n" +
+	"\x1aPULL_REQUEST_STATUS_MERGED\x10\x022\xcf\x04\n" +
+	"\x12PullRequestService\x12Z\n" +
+	"\x11CreatePullRequest\x12(.prreviewers.v1.CreatePullRequestRequest\x1a\x1b.prreviewers.v1.PullRequest\x12T\n" +
+	"\x0eGetPullRequest\x12%.prreviewers.v1.GetPullRequestRequest\x1a\x1b.prreviewers.v1.PullRequest\x12e\n" +
+	"\x10ListPullRequests\x12'.prreviewers.v1.ListPullRequestsRequest\x1a(.prreviewers.v1.ListPullRequestsResponse\x12X\n" +
+	"\x10MergePullRequest\x12'.prreviewers.v1.MergePullRequestRequest\x1a\x1b.prreviewers.v1.PullRequest\x12e\n" +
+	"\x10ReassignReviewer\x12'.prreviewers.v1.ReassignReviewerRequest\x1a(.prreviewers.v1.ReassignReviewerResponse\x12_\n" +
+	"\x0eGetReviewStats\x12%.prreviewers.v1.GetReviewStatsRequest\x1a&.prreviewers.v1.GetReviewStatsResponseBMZKgithub.com/IlyaAGL/avito_autumn_2025/api/proto/prreviewers/v1;prreviewersv1b\x06proto3"
+
+var (
+	file_prreviewers_v1_pull_requests_proto_rawDescOnce sync.Once
+	file_prreviewers_v1_pull_requests_proto_rawDescData []byte
+)
+
+func file_prreviewers_v1_pull_requests_proto_rawDescGZIP() []byte {
+	file_prreviewers_v1_pull_requests_proto_rawDescOnce.Do(func() {
+		file_prreviewers_v1_pull_requests_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_prreviewers_v1_pull_requests_proto_rawDesc), len(file_prreviewers_v1_pull_requests_proto_rawDesc)))
+	})
+	return file_prreviewers_v1_pull_requests_proto_rawDescData
+}
+
+var file_prreviewers_v1_pull_requests_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_prreviewers_v1_pull_requests_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_prreviewers_v1_pull_requests_proto_goTypes = []any{
+	(PullRequestStatus)(0),           // 0: prreviewers.v1.PullRequestStatus
+	(*PullRequest)(nil),              // 1: prreviewers.v1.PullRequest
+	(*ReviewStats)(nil),              // 2: prreviewers.v1.ReviewStats
+	(*CreatePullRequestRequest)(nil), // 3: prreviewers.v1.CreatePullRequestRequest
+	(*GetPullRequestRequest)(nil),    // 4: prreviewers.v1.GetPullRequestRequest
+	(*ListPullRequestsRequest)(nil),  // 5: prreviewers.v1.ListPullRequestsRequest
+	(*ListPullRequestsResponse)(nil), // 6: prreviewers.v1.ListPullRequestsResponse
+	(*MergePullRequestRequest)(nil),  // 7: prreviewers.v1.MergePullRequestRequest
+	(*ReassignReviewerRequest)(nil),  // 8: prreviewers.v1.ReassignReviewerRequest
+	(*ReassignReviewerResponse)(nil), // 9: prreviewers.v1.ReassignReviewerResponse
+	(*GetReviewStatsRequest)(nil),    // 10: prreviewers.v1.GetReviewStatsRequest
+	(*GetReviewStatsResponse)(nil),   // 11: prreviewers.v1.GetReviewStatsResponse
+	(*timestamppb.Timestamp)(nil),    // 12: google.protobuf.Timestamp
+}
+var file_prreviewers_v1_pull_requests_proto_depIdxs = []int32{
+	0,  // 0: prreviewers.v1.PullRequest.status:type_name -> prreviewers.v1.PullRequestStatus
+	12, // 1: prreviewers.v1.PullRequest.merged_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: prreviewers.v1.ListPullRequestsRequest.status:type_name -> prreviewers.v1.PullRequestStatus
+	1,  // 3: prreviewers.v1.ListPullRequestsResponse.pull_requests:type_name -> prreviewers.v1.PullRequest
+	1,  // 4: prreviewers.v1.ReassignReviewerResponse.pull_request:type_name -> prreviewers.v1.PullRequest
+	2,  // 5: prreviewers.v1.GetReviewStatsResponse.user_stats:type_name -> prreviewers.v1.ReviewStats
+	3,  // 6: prreviewers.v1.PullRequestService.CreatePullRequest:input_type -> prreviewers.v1.CreatePullRequestRequest
+	4,  // 7: prreviewers.v1.PullRequestService.GetPullRequest:input_type -> prreviewers.v1.GetPullRequestRequest
+	5,  // 8: prreviewers.v1.PullRequestService.ListPullRequests:input_type -> prreviewers.v1.ListPullRequestsRequest
+	7,  // 9: prreviewers.v1.PullRequestService.MergePullRequest:input_type -> prreviewers.v1.MergePullRequestRequest
+	8,  // 10: prreviewers.v1.PullRequestService.ReassignReviewer:input_type -> prreviewers.v1.ReassignReviewerRequest
+	10, // 11: prreviewers.v1.PullRequestService.GetReviewStats:input_type -> prreviewers.v1.GetReviewStatsRequest
+	1,  // 12: prreviewers.v1.PullRequestService.CreatePullRequest:output_type -> prreviewers.v1.PullRequest
+	1,  // 13: prreviewers.v1.PullRequestService.GetPullRequest:output_type -> prreviewers.v1.PullRequest
+	6,  // 14: prreviewers.v1.PullRequestService.ListPullRequests:output_type -> prreviewers.v1.ListPullRequestsResponse
+	1,  // 15: prreviewers.v1.PullRequestService.MergePullRequest:output_type -> prreviewers.v1.PullRequest
+	9,  // 16: prreviewers.v1.PullRequestService.ReassignReviewer:output_type -> prreviewers.v1.ReassignReviewerResponse
+	11, // 17: prreviewers.v1.PullRequestService.GetReviewStats:output_type -> prreviewers.v1.GetReviewStatsResponse
+	12, // [12:18] is the sub-list for method output_type
+	6,  // [6:12] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_prreviewers_v1_pull_requests_proto_init() }
+func file_prreviewers_v1_pull_requests_proto_init() {
+	if File_prreviewers_v1_pull_requests_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_prreviewers_v1_pull_requests_proto_rawDesc), len(file_prreviewers_v1_pull_requests_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_prreviewers_v1_pull_requests_proto_goTypes,
+		DependencyIndexes: file_prreviewers_v1_pull_requests_proto_depIdxs,
+		EnumInfos:         file_prreviewers_v1_pull_requests_proto_enumTypes,
+		MessageInfos:      file_prreviewers_v1_pull_requests_proto_msgTypes,
+	}.Build()
+	File_prreviewers_v1_pull_requests_proto = out.File
+	file_prreviewers_v1_pull_requests_proto_goTypes = nil
+	file_prreviewers_v1_pull_requests_proto_depIdxs = nil
+}
@@ -0,0 +1,1104 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v5.29.3
+// source: prreviewers/v1/teams.proto
+
+package prreviewersv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TeamMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	IsActive      bool                   `protobuf:"varint,3,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TeamMember) Reset() {
+	*x = TeamMember{}
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamMember) ProtoMessage() {}
+
+func (x *TeamMember) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamMember.ProtoReflect.Descriptor instead.
+func (*TeamMember) Descriptor() ([]byte, []int) {
+	return file_prreviewers_v1_teams_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TeamMember) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TeamMember) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *TeamMember) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+type Team struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Members       []*TeamMember          `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Team) Reset() {
+	*x = Team{}
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Team) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Team) ProtoMessage() {}
+
+func (x *Team) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Team.ProtoReflect.Descriptor instead.
+func (*Team) Descriptor() ([]byte, []int) {
+	return file_prreviewers_v1_teams_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Team) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *Team) GetMembers() []*TeamMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type TeamSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Members       int32                  `protobuf:"varint,2,opt,name=members,proto3" json:"members,omitempty"`
+	ActiveMembers int32                  `protobuf:"varint,3,opt,name=active_members,json=activeMembers,proto3" json:"active_members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TeamSummary) Reset() {
+	*x = TeamSummary{}
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamSummary) ProtoMessage() {}
+
+func (x *TeamSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamSummary.ProtoReflect.Descriptor instead.
+func (*TeamSummary) Descriptor() ([]byte, []int) {
+	return file_prreviewers_v1_teams_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TeamSummary) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *TeamSummary) GetMembers() int32 {
+	if x != nil {
+		return x.Members
+	}
+	return 0
+}
+
+func (x *TeamSummary) GetActiveMembers() int32 {
+	if x != nil {
+		return x.ActiveMembers
+	}
+	return 0
+}
+
+type CreateTeamRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TeamName string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Members  []*TeamMember          `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	// Update an existing team instead of failing.
+	Upsert bool `protobuf:"varint,3,opt,name=upsert,proto3" json:"upsert,omitempty"`
+	// Move members out of their current team instead of failing.
+	AllowMove     bool `protobuf:"varint,4,opt,name=allow_move,json=allowMove,proto3" json:"allow_move,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTeamRequest) Reset() {
+	*x = CreateTeamRequest{}
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTeamRequest) ProtoMessage() {}
+
+func (x *CreateTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTeamRequest.ProtoReflect.Descriptor instead.
+func (*CreateTeamRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewers_v1_teams_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateTeamRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *CreateTeamRequest) GetMembers() []*TeamMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *CreateTeamRequest) GetUpsert() bool {
+	if x != nil {
+		return x.Upsert
+	}
+	return false
+}
+
+func (x *CreateTeamRequest) GetAllowMove() bool {
+	if x != nil {
+		return x.AllowMove
+	}
+	return false
+}
+
+type GetTeamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeamRequest) Reset() {
+	*x = GetTeamRequest{}
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeamRequest) ProtoMessage() {}
+
+func (x *GetTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamRequest.ProtoReflect.Descriptor instead.
+func (*GetTeamRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewers_v1_teams_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetTeamRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+type ListTeamsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTeamsRequest) Reset() {
+	*x = ListTeamsRequest{}
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTeamsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTeamsRequest) ProtoMessage() {}
+
+func (x *ListTeamsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTeamsRequest.ProtoReflect.Descriptor instead.
+func (*ListTeamsRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewers_v1_teams_proto_rawDescGZIP(), []int{5}
+}
+
+type ListTeamsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Teams         []*TeamSummary         `protobuf:"bytes,1,rep,name=teams,proto3" json:"teams,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTeamsResponse) Reset() {
+	*x = ListTeamsResponse{}
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTeamsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTeamsResponse) ProtoMessage() {}
+
+func (x *ListTeamsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTeamsResponse.ProtoReflect.Descriptor instead.
+func (*ListTeamsResponse) Descriptor() ([]byte, []int) {
+	return file_prreviewers_v1_teams_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListTeamsResponse) GetTeams() []*TeamSummary {
+	if x != nil {
+		return x.Teams
+	}
+	return nil
+}
+
+type DeactivateTeamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeactivateTeamRequest) Reset() {
+	*x = DeactivateTeamRequest{}
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeactivateTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeactivateTeamRequest) ProtoMessage() {}
+
+func (x *DeactivateTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeactivateTeamRequest.ProtoReflect.Descriptor instead.
+func (*DeactivateTeamRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewers_v1_teams_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeactivateTeamRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+type DeactivateTeamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeactivateTeamResponse) Reset() {
+	*x = DeactivateTeamResponse{}
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeactivateTeamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeactivateTeamResponse) ProtoMessage() {}
+
+func (x *DeactivateTeamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeactivateTeamResponse.ProtoReflect.Descriptor instead.
+func (*DeactivateTeamResponse) Descriptor() ([]byte, []int) {
+	return file_prreviewers_v1_teams_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeactivateTeamResponse) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+type AddMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Members       []*TeamMember          `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddMembersRequest) Reset() {
+	*x = AddMembersRequest{}
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddMembersRequest) ProtoMessage() {}
+
+func (x *AddMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddMembersRequest.ProtoReflect.Descriptor instead.
+func (*AddMembersRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewers_v1_teams_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AddMembersRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *AddMembersRequest) GetMembers() []*TeamMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+// ReviewerChange is an open review handed over because its reviewer left
+// the author's team. An empty new_reviewer_id means it was dropped.
+type ReviewerChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	OldReviewerId string                 `protobuf:"bytes,2,opt,name=old_reviewer_id,json=oldReviewerId,proto3" json:"old_reviewer_id,omitempty"`
+	NewReviewerId string                 `protobuf:"bytes,3,opt,name=new_reviewer_id,json=newReviewerId,proto3" json:"new_reviewer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewerChange) Reset() {
+	*x = ReviewerChange{}
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewerChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewerChange) ProtoMessage() {}
+
+func (x *ReviewerChange) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewerChange.ProtoReflect.Descriptor instead.
+func (*ReviewerChange) Descriptor() ([]byte, []int) {
+	return file_prreviewers_v1_teams_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ReviewerChange) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *ReviewerChange) GetOldReviewerId() string {
+	if x != nil {
+		return x.OldReviewerId
+	}
+	return ""
+}
+
+func (x *ReviewerChange) GetNewReviewerId() string {
+	if x != nil {
+		return x.NewReviewerId
+	}
+	return ""
+}
+
+type RemoveMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	UserIds       []string               `protobuf:"bytes,2,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveMembersRequest) Reset() {
+	*x = RemoveMembersRequest{}
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMembersRequest) ProtoMessage() {}
+
+func (x *RemoveMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveMembersRequest.ProtoReflect.Descriptor instead.
+func (*RemoveMembersRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewers_v1_teams_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RemoveMembersRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *RemoveMembersRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type RemoveMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Team          *Team                  `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	Reassignments []*ReviewerChange      `protobuf:"bytes,2,rep,name=reassignments,proto3" json:"reassignments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveMembersResponse) Reset() {
+	*x = RemoveMembersResponse{}
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMembersResponse) ProtoMessage() {}
+
+func (x *RemoveMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveMembersResponse.ProtoReflect.Descriptor instead.
+func (*RemoveMembersResponse) Descriptor() ([]byte, []int) {
+	return file_prreviewers_v1_teams_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RemoveMembersResponse) GetTeam() *Team {
+	if x != nil {
+		return x.Team
+	}
+	return nil
+}
+
+func (x *RemoveMembersResponse) GetReassignments() []*ReviewerChange {
+	if x != nil {
+		return x.Reassignments
+	}
+	return nil
+}
+
+type MoveUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TeamName      string                 `protobuf:"bytes,2,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveUserRequest) Reset() {
+	*x = MoveUserRequest{}
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveUserRequest) ProtoMessage() {}
+
+func (x *MoveUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveUserRequest.ProtoReflect.Descriptor instead.
+func (*MoveUserRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewers_v1_teams_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MoveUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MoveUserRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+type MoveUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Reassignments []*ReviewerChange      `protobuf:"bytes,2,rep,name=reassignments,proto3" json:"reassignments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveUserResponse) Reset() {
+	*x = MoveUserResponse{}
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveUserResponse) ProtoMessage() {}
+
+func (x *MoveUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveUserResponse.ProtoReflect.Descriptor instead.
+func (*MoveUserResponse) Descriptor() ([]byte, []int) {
+	return file_prreviewers_v1_teams_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *MoveUserResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *MoveUserResponse) GetReassignments() []*ReviewerChange {
+	if x != nil {
+		return x.Reassignments
+	}
+	return nil
+}
+
+type RenameTeamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	NewTeamName   string                 `protobuf:"bytes,2,opt,name=new_team_name,json=newTeamName,proto3" json:"new_team_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameTeamRequest) Reset() {
+	*x = RenameTeamRequest{}
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameTeamRequest) ProtoMessage() {}
+
+func (x *RenameTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameTeamRequest.ProtoReflect.Descriptor instead.
+func (*RenameTeamRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewers_v1_teams_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RenameTeamRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *RenameTeamRequest) GetNewTeamName() string {
+	if x != nil {
+		return x.NewTeamName
+	}
+	return ""
+}
+
+type DeleteTeamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTeamRequest) Reset() {
+	*x = DeleteTeamRequest{}
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTeamRequest) ProtoMessage() {}
+
+func (x *DeleteTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTeamRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTeamRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewers_v1_teams_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DeleteTeamRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+type DeleteTeamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTeamResponse) Reset() {
+	*x = DeleteTeamResponse{}
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTeamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTeamResponse) ProtoMessage() {}
+
+func (x *DeleteTeamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewers_v1_teams_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTeamResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTeamResponse) Descriptor() ([]byte, []int) {
+	return file_prreviewers_v1_teams_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeleteTeamResponse) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+var File_prreviewers_v1_teams_proto protoreflect.FileDescriptor
+
+const file_prreviewers_v1_teams_proto_rawDesc = "" +
+	"\n" +
+	"\x1aprreviewers/v1/teams.proto\x12\x0eprreviewers.v1\x1a\x1aprreviewers/v1/users.proto\"^\n" +
+	"\n" +
+	"TeamMember\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
+	"\tis_active\x18\x03 \x01(\bR\bisActive\"Y\n" +
+	"\x04Team\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x124\n" +
+	"\amembers\x18\x02 \x03(\v2\x1a.prreviewers.v1.TeamMemberR\amembers\"k\n" +
+	"\vTeamSummary\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12\x18\n" +
+	"\amembers\x18\x02 \x01(\x05R\amembers\x12%\n" +
+	"\x0eactive_members\x18\x03 \x01(\x05R\ractiveMembers\"\x9d\x01\n" +
+	"\x11CreateTeamRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x124\n" +
+	"\amembers\x18\x02 \x03(\v2\x1a.prreviewers.v1.TeamMemberR\amembers\x12\x16\n" +
+	"\x06upsert\x18\x03 \x01(\bR\x06upsert\x12\x1d\n" +
+	"\n" +
+	"allow_move\x18\x04 \x01(\bR\tallowMove\"-\n" +
+	"\x0eGetTeamRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\"\x12\n" +
+	"\x10ListTeamsRequest\"F\n" +
+	"\x11ListTeamsResponse\x121\n" +
+	"\x05teams\x18\x01 \x03(\v2\x1b.prreviewers.v1.TeamSummaryR\x05teams\"4\n" +
+	"\x15DeactivateTeamRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\"5\n" +
+	"\x16DeactivateTeamResponse\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\"f\n" +
+	"\x11AddMembersRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x124\n" +
+	"\amembers\x18\x02 \x03(\v2\x1a.prreviewers.v1.TeamMemberR\amembers\"\x88\x01\n" +
+	"\x0eReviewerChange\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12&\n" +
+	"\x0fold_reviewer_id\x18\x02 \x01(\tR\roldReviewerId\x12&\n" +
+	"\x0fnew_reviewer_id\x18\x03 \x01(\tR\rnewReviewerId\"N\n" +
+	"\x14RemoveMembersRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12\x19\n" +
+	"\buser_ids\x18\x02 \x03(\tR\auserIds\"\x87\x01\n" +
+	"\x15RemoveMembersResponse\x12(\n" +
+	"\x04team\x18\x01 \x01(\v2\x14.prreviewers.v1.TeamR\x04team\x12D\n" +
+	"\rreassignments\x18\x02 \x03(\v2\x1e.prreviewers.v1.ReviewerChangeR\rreassignments\"G\n" +
+	"\x0fMoveUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tteam_name\x18\x02 \x01(\tR\bteamName\"\x82\x01\n" +
+	"\x10MoveUserResponse\x12(\n" +
+	"\x04user\x18\x01 \x01(\v2\x14.prreviewers.v1.UserR\x04user\x12D\n" +
+	"\rreassignments\x18\x02 \x03(\v2\x1e.prreviewers.v1.ReviewerChangeR\rreassignments\"T\n" +
+	"\x11RenameTeamRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12\"\n" +
+	"\rnew_team_name\x18\x02 \x01(\tR\vnewTeamName\"0\n" +
+	"\x11DeleteTeamRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\"1\n" +
+	"\x12DeleteTeamResponse\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName2\xd8\x05\n" +
+	"\vTeamService\x12E\n" +
+	"\n" +
+	"CreateTeam\x12!.prreviewers.v1.CreateTeamRequest\x1a\x14.prreviewers.v1.Team\x12?\n" +
+	"\aGetTeam\x12\x1e.prreviewers.v1.GetTeamRequest\x1a\x14.prreviewers.v1.Team\x12P\n" +
+	"\tListTeams\x12 .prreviewers.v1.ListTeamsRequest\x1a!.prreviewers.v1.ListTeamsResponse\x12_\n" +
+	"\x0eDeactivateTeam\x12%.prreviewers.v1.DeactivateTeamRequest\x1a&.prreviewers.v1.DeactivateTeamResponse\x12E\n" +
+	"\n" +
+	"AddMembers\x12!.prreviewers.v1.AddMembersRequest\x1a\x14.prreviewers.v1.Team\x12\\\n" +
+	"\rRemoveMembers\x12$.prreviewers.v1.RemoveMembersRequest\x1a%.prreviewers.v1.RemoveMembersResponse\x12M\n" +
+	"\bMoveUser\x12\x1f.prreviewers.v1.MoveUserRequest\x1a .prreviewers.v1.MoveUserResponse\x12E\n" +
+	"\n" +
+	"RenameTeam\x12!.prreviewers.v1.RenameTeamRequest\x1a\x14.prreviewers.v1.Team\x12S\n" +
+	"\n" +
+	"DeleteTeam\x12!.prreviewers.v1.DeleteTeamRequest\x1a\".prreviewers.v1.DeleteTeamResponseBMZKgithub.com/IlyaAGL/avito_autumn_2025/api/proto/prreviewers/v1;prreviewersv1b\x06proto3"
+
+var (
+	file_prreviewers_v1_teams_proto_rawDescOnce sync.Once
+	file_prreviewers_v1_teams_proto_rawDescData []byte
+)
+
+func file_prreviewers_v1_teams_proto_rawDescGZIP() []byte {
+	file_prreviewers_v1_teams_proto_rawDescOnce.Do(func() {
+		file_prreviewers_v1_teams_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_prreviewers_v1_teams_proto_rawDesc), len(file_prreviewers_v1_teams_proto_rawDesc)))
+	})
+	return file_prreviewers_v1_teams_proto_rawDescData
+}
+
+var file_prreviewers_v1_teams_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_prreviewers_v1_teams_proto_goTypes = []any{
+	(*TeamMember)(nil),             // 0: prreviewers.v1.TeamMember
+	(*Team)(nil),                   // 1: prreviewers.v1.Team
+	(*TeamSummary)(nil),            // 2: prreviewers.v1.TeamSummary
+	(*CreateTeamRequest)(nil),      // 3: prreviewers.v1.CreateTeamRequest
+	(*GetTeamRequest)(nil),         // 4: prreviewers.v1.GetTeamRequest
+	(*ListTeamsRequest)(nil),       // 5: prreviewers.v1.ListTeamsRequest
+	(*ListTeamsResponse)(nil),      // 6: prreviewers.v1.ListTeamsResponse
+	(*DeactivateTeamRequest)(nil),  // 7: prreviewers.v1.DeactivateTeamRequest
+	(*DeactivateTeamResponse)(nil), // 8: prreviewers.v1.DeactivateTeamResponse
+	(*AddMembersRequest)(nil),      // 9: prreviewers.v1.AddMembersRequest
+	(*ReviewerChange)(nil),         // 10: prreviewers.v1.ReviewerChange
+	(*RemoveMembersRequest)(nil),   // 11: prreviewers.v1.RemoveMembersRequest
+	(*RemoveMembersResponse)(nil),  // 12: prreviewers.v1.RemoveMembersResponse
+	(*MoveUserRequest)(nil),        // 13: prreviewers.v1.MoveUserRequest
+	(*MoveUserResponse)(nil),       // 14: prreviewers.v1.MoveUserResponse
+	(*RenameTeamRequest)(nil),      // 15: prreviewers.v1.RenameTeamRequest
+	(*DeleteTeamRequest)(nil),      // 16: prreviewers.v1.DeleteTeamRequest
+	(*DeleteTeamResponse)(nil),     // 17: prreviewers.v1.DeleteTeamResponse
+	(*User)(nil),                   // 18: prreviewers.v1.User
+}
+var file_prreviewers_v1_teams_proto_depIdxs = []int32{
+	0,  // 0: prreviewers.v1.Team.members:type_name -> prreviewers.v1.TeamMember
+	0,  // 1: prreviewers.v1.CreateTeamRequest.members:type_name -> prreviewers.v1.TeamMember
+	2,  // 2: prreviewers.v1.ListTeamsResponse.teams:type_name -> prreviewers.v1.TeamSummary
+	0,  // 3: prreviewers.v1.AddMembersRequest.members:type_name -> prreviewers.v1.TeamMember
+	1,  // 4: prreviewers.v1.RemoveMembersResponse.team:type_name -> prreviewers.v1.Team
+	10, // 5: prreviewers.v1.RemoveMembersResponse.reassignments:type_name -> prreviewers.v1.ReviewerChange
+	18, // 6: prreviewers.v1.MoveUserResponse.user:type_name -> prreviewers.v1.User
+	10, // 7: prreviewers.v1.MoveUserResponse.reassignments:type_name -> prreviewers.v1.ReviewerChange
+	3,  // 8: prreviewers.v1.TeamService.CreateTeam:input_type -> prreviewers.v1.CreateTeamRequest
+	4,  // 9: prreviewers.v1.TeamService.GetTeam:input_type -> prreviewers.v1.GetTeamRequest
+	5,  // 10: prreviewers.v1.TeamService.ListTeams:input_type -> prreviewers.v1.ListTeamsRequest
+	7,  // 11: prreviewers.v1.TeamService.DeactivateTeam:input_type -> prreviewers.v1.DeactivateTeamRequest
+	9,  // 12: prreviewers.v1.TeamService.AddMembers:input_type -> prreviewers.v1.AddMembersRequest
+	11, // 13: prreviewers.v1.TeamService.RemoveMembers:input_type -> prreviewers.v1.RemoveMembersRequest
+	13, // 14: prreviewers.v1.TeamService.MoveUser:input_type -> prreviewers.v1.MoveUserRequest
+	15, // 15: prreviewers.v1.TeamService.RenameTeam:input_type -> prreviewers.v1.RenameTeamRequest
+	16, // 16: prreviewers.v1.TeamService.DeleteTeam:input_type -> prreviewers.v1.DeleteTeamRequest
+	1,  // 17: prreviewers.v1.TeamService.CreateTeam:output_type -> prreviewers.v1.Team
+	1,  // 18: prreviewers.v1.TeamService.GetTeam:output_type -> prreviewers.v1.Team
+	6,  // 19: prreviewers.v1.TeamService.ListTeams:output_type -> prreviewers.v1.ListTeamsResponse
+	8,  // 20: prreviewers.v1.TeamService.DeactivateTeam:output_type -> prreviewers.v1.DeactivateTeamResponse
+	1,  // 21: prreviewers.v1.TeamService.AddMembers:output_type -> prreviewers.v1.Team
+	12, // 22: prreviewers.v1.TeamService.RemoveMembers:output_type -> prreviewers.v1.RemoveMembersResponse
+	14, // 23: prreviewers.v1.TeamService.MoveUser:output_type -> prreviewers.v1.MoveUserResponse
+	1,  // 24: prreviewers.v1.TeamService.RenameTeam:output_type -> prreviewers.v1.Team
+	17, // 25: prreviewers.v1.TeamService.DeleteTeam:output_type -> prreviewers.v1.DeleteTeamResponse
+	17, // [17:26] is the sub-list for method output_type
+	8,  // [8:17] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_prreviewers_v1_teams_proto_init() }
+func file_prreviewers_v1_teams_proto_init() {
+	if File_prreviewers_v1_teams_proto != nil {
+		return
+	}
+	file_prreviewers_v1_users_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_prreviewers_v1_teams_proto_rawDesc), len(file_prreviewers_v1_teams_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_prreviewers_v1_teams_proto_goTypes,
+		DependencyIndexes: file_prreviewers_v1_teams_proto_depIdxs,
+		MessageInfos:      file_prreviewers_v1_teams_proto_msgTypes,
+	}.Build()
+	File_prreviewers_v1_teams_proto = out.File
+	file_prreviewers_v1_teams_proto_goTypes = nil
+	file_prreviewers_v1_teams_proto_depIdxs = nil
+}
@@ -2,89 +2,203 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"log"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
-	"github.com/IlyaAGL/avito_autumn_2025/internal/app/handler"
+	"github.com/IlyaAGL/avito_autumn_2025/api"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/app/grpcapi"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/app/router"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/app/validation"
 	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/service"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/infrastructure/metrics"
 	"github.com/IlyaAGL/avito_autumn_2025/internal/infrastructure/persistence/postgres"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/models"
 	"github.com/IlyaAGL/avito_autumn_2025/pkg/bootstrap/connections"
+	"github.com/IlyaAGL/avito_autumn_2025/pkg/bootstrap/migrations"
+	"github.com/IlyaAGL/avito_autumn_2025/pkg/config"
+	"github.com/IlyaAGL/avito_autumn_2025/pkg/logger"
+	"github.com/IlyaAGL/avito_autumn_2025/pkg/tracing"
 	"github.com/gin-gonic/gin"
 )
 
-const timeToCloseServerConnection = 30
-
 func main() {
-	serverPort := os.Getenv("SERVER_PORT")
-	if serverPort == "" {
-		serverPort = "8080"
+	cfg, err := config.Load(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		log.Fatalf("api: %v", err)
+	}
+
+	appLogger, err := logger.New(os.Stdout, cfg.Log.Level, cfg.Log.Format)
+	if err != nil {
+		log.Fatalf("api: %v", err)
+	}
+
+	slog.SetDefault(appLogger)
+	slog.Info("effective config", "config", cfg)
+
+	if cfg.Log.Level != "debug" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	if err := validation.Register(); err != nil {
+		log.Fatalf("api: %v", err)
+	}
+
+	apiSpec, err := api.Load()
+	if err != nil {
+		log.Fatalf("api: %v", err)
+	}
+
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Fatalf("api: %v", err)
 	}
 
-	db_pg := connections.InitPostgres()
 	defer func() {
-		log.Fatal(db_pg.Close())
+		if err := shutdownTracing(context.Background()); err != nil {
+			slog.Error("failed to flush traces", "error", err)
+		}
 	}()
 
-	userRepo := postgres.NewPostgresUserRepository(db_pg)
-	prRepo := postgres.NewPostgresPullRequestRepository(db_pg)
-	teamRepo := postgres.NewPostgresTeamRepository(db_pg)
+	pool := connections.InitPostgres(cfg.Database)
+	defer pool.Close()
+
+	userRepo := postgres.NewPostgresUserRepository(pool)
+	prRepo := postgres.NewPostgresPullRequestRepository(pool)
+	teamRepo := postgres.NewPostgresTeamRepository(pool)
+	timeOffRepo := postgres.NewPostgresTimeOffRepository(pool)
+	codeOwnersRepo := postgres.NewPostgresCodeOwnersRepository(pool)
+	repositoryRepo := postgres.NewPostgresRepositoryRepository(pool)
 
 	userService := service.NewUserService(userRepo, prRepo)
-	prService := service.NewPullRequestService(prRepo, userRepo, teamRepo)
-	teamService := service.NewTeamService(teamRepo)
+	appMetrics := metrics.New()
+	appMetrics.MustRegister(
+		metrics.NewPoolCollector(pool),
+		metrics.NewOpenPRsCollector(prRepo),
+	)
+
+	reviewPolicy := models.ReviewPolicy{
+		Reviewers:  cfg.Review.Reviewers,
+		Strategy:   cfg.Review.Strategy,
+		MinExperts: cfg.Review.MinExperts,
+	}
 
-	userHandler := handler.NewUserHandler(userService)
-	prHandler := handler.NewpullRequestHandler(prService)
-	teamHandler := handler.NewTeamHandler(teamService)
+	prService := service.NewPullRequestService(prRepo, userRepo, teamRepo, codeOwnersRepo, repositoryRepo, appMetrics, reviewPolicy)
+	teamService := service.NewTeamService(teamRepo, userRepo, appMetrics)
+	timeOffService := service.NewTimeOffService(timeOffRepo, userRepo, teamRepo, appMetrics)
+	codeOwnersService := service.NewCodeOwnersService(codeOwnersRepo)
+	repositoryService := service.NewRepositoryService(repositoryRepo, reviewPolicy)
 
-	r := gin.Default()
+	if cfg.Migrations.AutoMigrate {
+		migrations.RunMigrationsPG(pool, cfg.Migrations.Path, cfg.Migrations.LockTimeout)
+	}
 
-	teams := r.Group("/team")
-	{
-		teams.GET("/get", teamHandler.GetTeam)
-		teams.POST("/add", teamHandler.AddTeam)
-		teams.POST("/bulk", teamHandler.BulkDeactivateUsers)
+	expectedSchemaVersion := migrations.VerifySchemaPG(pool, cfg.Migrations.Path, cfg.Migrations.LockTimeout)
+
+	healthService := service.NewHealthService(postgres.NewPostgresHealthRepository(pool), expectedSchemaVersion)
+
+	r, err := router.New(router.Config{
+		Spec:        apiSpec,
+		Metrics:     appMetrics,
+		ServiceName: cfg.Tracing.ServiceName,
+		SCIMToken:   cfg.SCIM.Token,
+	}, router.Services{
+		Users:        userService,
+		PullRequests: prService,
+		Teams:        teamService,
+		TimeOff:      timeOffService,
+		CodeOwners:   codeOwnersService,
+		Repositories: repositoryService,
+		Health:       healthService,
+		SCIM:         service.NewSCIMService(userRepo, teamRepo, appMetrics),
+	})
+	if err != nil {
+		log.Fatalf("api: %v", err)
 	}
 
-	users := r.Group("/users")
-    {
-        users.POST("/setIsActive", userHandler.SetIsActive)
-        users.GET("/getReview", userHandler.GetReview)
-    }
+	server := &http.Server{
+		Addr:              ":" + strconv.Itoa(cfg.Server.Port),
+		Handler:           r,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+	}
 
-	prs := r.Group("/pullRequest")
-    {
-        prs.POST("/create", prHandler.CreatePR)
-        prs.POST("/merge", prHandler.MergePR)
-        prs.POST("/reassign", prHandler.ReassignReviewer)
-        prs.GET("/statistics", prHandler.GetStats)
-    }
+	go func() {
+		slog.Info("http server started", "addr", server.Addr)
 
-	server := &http.Server{
-		Addr:    ":" + serverPort,
-		Handler: r,
-		ReadHeaderTimeout: 5 * time.Second,
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("http server failed", "error", err)
+			os.Exit(1)
+		}
+	}()
+
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	schedulerStopped := make(chan struct{})
+
+	go func() {
+		slog.Info("time off scheduler started", "interval", cfg.Scheduler.Interval.String())
+
+		timeOffService.RunScheduler(schedulerCtx, cfg.Scheduler.Interval)
+		close(schedulerStopped)
+	}()
+
+	grpcServer := grpcapi.NewServer(teamService, userService, prService)
+
+	grpcListener, err := net.Listen("tcp", ":"+strconv.Itoa(cfg.Server.GRPCPort))
+	if err != nil {
+		log.Fatalf("api: %v", err)
 	}
 
 	go func() {
-		log.Fatal(server.ListenAndServe())
+		slog.Info("grpc server started", "addr", grpcListener.Addr().String())
+
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			slog.Error("grpc server failed", "error", err)
+			os.Exit(1)
+		}
 	}()
 
 	shutdown := make(chan os.Signal, 1)
 	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM)
 
 	<-shutdown
-	ctx, cancel := context.WithTimeout(context.Background(), timeToCloseServerConnection*time.Second)
+
+	slog.Info("draining before shutdown", "delay", cfg.Server.DrainDelay.String())
+	healthService.MarkShuttingDown()
+	time.Sleep(cfg.Server.DrainDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 	defer cancel()
 
+	slog.Info("shutting down")
+
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+
 	if err := server.Shutdown(ctx); err != nil {
-		log.Printf("Error while shutdowning: %v", err)
+		slog.Error("error while shutting down", "error", err)
 		if err := server.Close(); err != nil {
-			log.Printf("Forced to close the server: %v", err)
+			slog.Error("forced to close the server", "error", err)
 		}
 	}
+
+	select {
+	case <-grpcStopped:
+	case <-ctx.Done():
+		slog.Error("grpc server did not stop in time, forcing")
+		grpcServer.Stop()
+	}
+
+	stopScheduler()
+	<-schedulerStopped
 }
@@ -1,17 +1,312 @@
 package main
 
 import (
+	"errors"
+	"flag"
+	"fmt"
 	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"text/tabwriter"
 
 	"github.com/IlyaAGL/avito_autumn_2025/pkg/bootstrap/connections"
 	"github.com/IlyaAGL/avito_autumn_2025/pkg/bootstrap/migrations"
+	"github.com/IlyaAGL/avito_autumn_2025/pkg/config"
+	"github.com/IlyaAGL/avito_autumn_2025/pkg/logger"
+	"github.com/golang-migrate/migrate/v4"
 )
 
+const (
+	exitOK          = 0
+	exitFailure     = 1
+	exitUsage       = 2
+	exitLockTimeout = 3
+	exitDirty       = 4
+)
+
+const usage = `Usage: migrator [flags] <command> [args]
+
+Commands:
+  up [N]      apply all pending migrations, or the next N
+  down N      roll back N migrations
+  goto V      migrate up or down to version V
+  version     print the current schema version
+  force V     set the version without running migrations (fixes a dirty state)
+  status      list applied and pending migrations
+
+Running without a command is the same as "up".
+With -dry-run, up, down and goto print the pending SQL instead of applying it.
+
+Flags:
+`
+
 func main() {
-	db_pg := connections.InitPostgres()
+	os.Exit(run())
+}
+
+func run() int {
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+
+	dryRun := flag.Bool("dry-run", false, "print the SQL of pending migrations without applying them")
+
+	cfg, err := config.Load(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		log.Printf("migrator: %v", err)
+		return exitUsage
+	}
+
+	appLogger, err := logger.New(os.Stderr, cfg.Log.Level, cfg.Log.Format)
+	if err != nil {
+		log.Printf("migrator: %v", err)
+		return exitUsage
+	}
+
+	slog.SetDefault(appLogger)
+
+	command, args := "up", flag.Args()
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+
+	cmdFlags := flag.NewFlagSet(command, flag.ContinueOnError)
+	cmdFlags.BoolVar(dryRun, "dry-run", *dryRun, "print the SQL of pending migrations without applying them")
+	if err := cmdFlags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	j, err := parseJob(command, cmdFlags.Args(), *dryRun)
+	if err != nil {
+		return exitCode(err)
+	}
+
+	pool := connections.InitPostgres(cfg.Database)
+	defer pool.Close()
+
+	m, err := migrations.NewMigrator(pool, cfg.Migrations.Path, cfg.Migrations.LockTimeout)
+	if err != nil {
+		slog.Error("failed to create migrator", "error", err)
+		return exitFailure
+	}
+
 	defer func() {
-		log.Fatal(db_pg.Close())
+		if err := m.Close(); err != nil {
+			slog.Error("failed to close migrator", "error", err)
+		}
+	}()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-stop
+		slog.Warn("stopping after the current migration")
+		m.GracefulStop()
 	}()
 
-	migrations.RunMigrationsPG(db_pg)
+	if err := execute(m, j); err != nil {
+		return exitCode(err)
+	}
+
+	return exitOK
+}
+
+// job is a parsed command, validated before connecting to the database.
+type job struct {
+	command string
+	// n is the step count for up/down or the version for goto/force.
+	n      int
+	dryRun bool
+}
+
+var errUsage = errors.New("usage error")
+
+func parseJob(command string, args []string, dryRun bool) (job, error) {
+	j := job{command: command, dryRun: dryRun}
+
+	var err error
+
+	switch command {
+	case "up":
+		if len(args) == 0 {
+			return j, nil
+		}
+
+		j.n, err = singleNumber(args)
+		if err != nil || j.n <= 0 {
+			return j, usageError("up accepts an optional positive number of migrations")
+		}
+	case "down":
+		j.n, err = singleNumber(args)
+		if err != nil || j.n <= 0 {
+			return j, usageError("down requires a positive number of migrations")
+		}
+	case "goto":
+		j.n, err = singleNumber(args)
+		if err != nil || j.n < 0 {
+			return j, usageError("goto requires a version")
+		}
+	case "force":
+		j.n, err = singleNumber(args)
+		if err != nil || j.n < migrations.NilVersion {
+			return j, usageError("force requires a version (-1 resets to no version)")
+		}
+	case "version", "status":
+		if len(args) != 0 {
+			return j, usageError(command + " takes no arguments")
+		}
+	default:
+		return j, usageError(fmt.Sprintf("unknown command %q", command))
+	}
+
+	if dryRun && (command == "force" || command == "version" || command == "status") {
+		return j, usageError("-dry-run is only supported by up, down and goto")
+	}
+
+	return j, nil
+}
+
+func execute(m *migrations.Migrator, j job) error {
+	if j.dryRun {
+		target, err := planTarget(m, j)
+		if err != nil {
+			return logged("plan", err)
+		}
+
+		return printPlan(m, target)
+	}
+
+	switch j.command {
+	case "up":
+		if j.n > 0 {
+			return logged("up", m.Steps(j.n))
+		}
+		return logged("up", m.Up())
+	case "down":
+		return logged("down", m.Steps(-j.n))
+	case "goto":
+		return logged("goto", m.Goto(uint(j.n)))
+	case "force":
+		return logged("force", m.Force(j.n))
+	case "version":
+		return printVersion(m)
+	default:
+		return printStatus(m)
+	}
+}
+
+func planTarget(m *migrations.Migrator, j job) (int, error) {
+	switch {
+	case j.command == "goto":
+		return j.n, nil
+	case j.command == "down":
+		return m.TargetForSteps(-j.n)
+	case j.n > 0:
+		return m.TargetForSteps(j.n)
+	default:
+		return m.LatestVersion()
+	}
+}
+
+func printVersion(m *migrations.Migrator) error {
+	version, dirty, err := m.Version()
+	if err != nil {
+		return logged("version", err)
+	}
+
+	switch {
+	case version == migrations.NilVersion:
+		fmt.Println("no migrations applied")
+	case dirty:
+		fmt.Printf("%d (dirty)\n", version)
+	default:
+		fmt.Println(version)
+	}
+
+	return nil
+}
+
+func printStatus(m *migrations.Migrator) error {
+	statuses, err := m.Status()
+	if err != nil {
+		return logged("status", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS")
+
+	for _, status := range statuses {
+		state := "pending"
+		switch {
+		case status.Dirty:
+			state = "dirty"
+		case status.Applied:
+			state = "applied"
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Identifier, state)
+	}
+
+	return w.Flush()
+}
+
+func printPlan(m *migrations.Migrator, target int) error {
+	plan, err := m.Plan(target)
+	if err != nil {
+		return logged("plan", err)
+	}
+
+	if len(plan) == 0 {
+		fmt.Println("-- no pending migrations")
+		return nil
+	}
+
+	for _, migration := range plan {
+		fmt.Printf("-- %d %s (%s)\n%s\n\n", migration.Version, migration.Identifier, migration.Direction, migration.SQL)
+	}
+
+	return nil
+}
+
+func singleNumber(args []string) (int, error) {
+	if len(args) != 1 {
+		return 0, errUsage
+	}
+
+	return strconv.Atoi(args[0])
+}
+
+func usageError(message string) error {
+	fmt.Fprintf(os.Stderr, "migrator: %s\n\n", message)
+	flag.Usage()
+	return errUsage
+}
+
+func logged(command string, err error) error {
+	if err != nil {
+		slog.Error("migration command failed", "command", command, "error", err)
+		return err
+	}
+
+	slog.Info("migration command finished", "command", command)
+	return nil
+}
+
+func exitCode(err error) int {
+	var dirtyErr migrate.ErrDirty
+
+	switch {
+	case errors.Is(err, errUsage):
+		return exitUsage
+	case errors.Is(err, migrate.ErrLockTimeout):
+		return exitLockTimeout
+	case errors.As(err, &dirtyErr):
+		return exitDirty
+	default:
+		return exitFailure
+	}
 }
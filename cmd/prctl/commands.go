@@ -0,0 +1,1251 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IlyaAGL/avito_autumn_2025/pkg/client"
+	"gopkg.in/yaml.v3"
+)
+
+var errUsage = errors.New("usage error")
+
+type cli struct {
+	client  *client.Client
+	printer printer
+}
+
+func (c *cli) run(ctx context.Context, args []string) error {
+	command, args := args[0], args[1:]
+
+	if command == "stats" {
+		if err := noArgs(command, args); err != nil {
+			return err
+		}
+		return c.stats(ctx)
+	}
+
+	if len(args) == 0 {
+		return usageError("%s requires a subcommand", command)
+	}
+
+	subcommand, args := args[0], args[1:]
+
+	switch command + " " + subcommand {
+	case "team add":
+		return c.teamAdd(ctx, args)
+	case "team get":
+		return c.teamGet(ctx, args)
+	case "team deactivate":
+		return c.teamDeactivate(ctx, args)
+	case "team list":
+		return c.teamList(ctx, args)
+	case "team add-members":
+		return c.teamAddMembers(ctx, args)
+	case "team remove-members":
+		return c.teamRemoveMembers(ctx, args)
+	case "team rename":
+		return c.teamRename(ctx, args)
+	case "team delete":
+		return c.teamDelete(ctx, args)
+	case "team sync":
+		return c.teamSync(ctx, args)
+	case "team import":
+		return c.teamImport(ctx, args)
+	case "team export":
+		return c.export(ctx, "team export", args, client.ExportResourceTeams, func(ctx context.Context) (any, error) {
+			return c.client.ExportTeams(ctx)
+		})
+	case "team away":
+		return c.teamAway(ctx, args)
+	case "team limits":
+		return c.teamLimits(ctx, args)
+	case "team load":
+		return c.teamLoad(ctx, args)
+	case "user move":
+		return c.userMove(ctx, args)
+	case "user activate":
+		return c.userSetActive(ctx, args, true)
+	case "user deactivate":
+		return c.userSetActive(ctx, args, false)
+	case "user reviews":
+		return c.userReviews(ctx, args)
+	case "user limits":
+		return c.userLimits(ctx, args)
+	case "user skills":
+		return c.userSkills(ctx, args)
+	case "user set-skills":
+		return c.userSetSkills(ctx, args)
+	case "user export":
+		return c.export(ctx, "user export", args, client.ExportResourceUsers, func(ctx context.Context) (any, error) {
+			return c.client.ExportUsers(ctx)
+		})
+	case "timeoff add":
+		return c.timeOffAdd(ctx, args)
+	case "timeoff list":
+		return c.timeOffList(ctx, args)
+	case "timeoff cancel":
+		return c.timeOffCancel(ctx, args)
+	case "pr create":
+		return c.prCreate(ctx, args)
+	case "pr merge":
+		return c.prMerge(ctx, args)
+	case "pr reassign":
+		return c.prReassign(ctx, args)
+	case "pr get":
+		return c.prGet(ctx, args)
+	case "pr list":
+		return c.prList(ctx, args)
+	case "pr understaffed":
+		return c.prUnderstaffed(ctx, args)
+	case "pr export":
+		return c.export(ctx, "pr export", args, client.ExportResourcePullRequests, func(ctx context.Context) (any, error) {
+			return c.client.ExportPRs(ctx)
+		})
+	case "repo add":
+		return c.repoAdd(ctx, args)
+	case "repo get":
+		return c.repoGet(ctx, args)
+	case "repo list":
+		return c.repoList(ctx, args)
+	case "repo update":
+		return c.repoUpdate(ctx, args)
+	case "repo delete":
+		return c.repoDelete(ctx, args)
+	case "codeowners upload":
+		return c.codeOwnersUpload(ctx, args)
+	case "codeowners get":
+		return c.codeOwnersGet(ctx, args)
+	default:
+		return usageError("unknown command %q", command+" "+subcommand)
+	}
+}
+
+func (c *cli) teamAdd(ctx context.Context, args []string) error {
+	fs := newFlagSet("team add")
+	name := fs.String("name", "", "team name")
+	file := fs.String("f", "", "read the team from a JSON or YAML file")
+	upsert := fs.Bool("upsert", false, "update the team if it already exists")
+	allowMove := fs.Bool("allow-move", false, "move members that belong to another team")
+	var members memberFlags
+	fs.Var(&members, "member", "member as ID:USERNAME[:inactive], repeatable")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+
+	var req client.CreateTeamRequest
+	switch {
+	case *file != "" && (*name != "" || len(members) > 0):
+		return usageError("-f cannot be combined with -name or -member")
+	case *file != "":
+		if err := readFile(*file, &req); err != nil {
+			return err
+		}
+	case *name == "":
+		return usageError("team add requires -name or -f")
+	default:
+		req = client.CreateTeamRequest{TeamName: *name, Members: members}
+	}
+
+	req.Upsert = req.Upsert || *upsert
+	req.AllowMove = req.AllowMove || *allowMove
+
+	resp, err := c.client.CreateTeam(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return c.printTeam(resp, resp.Team, resp.Reassignments...)
+}
+
+func (c *cli) teamGet(ctx context.Context, args []string) error {
+	name, err := singleArg("team get", "NAME", args)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.GetTeam(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	return c.printTeam(resp, *resp)
+}
+
+func (c *cli) teamDeactivate(ctx context.Context, args []string) error {
+	name, err := singleArg("team deactivate", "NAME", args)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.DeactivateTeam(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	return c.printer.print(resp, func(w io.Writer) {
+		row(w, "TEAM", "RESULT")
+		row(w, resp.Team, resp.Message)
+	})
+}
+
+func (c *cli) teamList(ctx context.Context, args []string) error {
+	if err := noArgs("team list", args); err != nil {
+		return err
+	}
+
+	resp, err := c.client.ListTeams(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.printer.print(resp, func(w io.Writer) {
+		row(w, "TEAM", "MEMBERS", "ACTIVE")
+		for _, team := range resp.Teams {
+			row(w, team.TeamName, team.Members, team.ActiveMembers)
+		}
+	})
+}
+
+func (c *cli) teamAddMembers(ctx context.Context, args []string) error {
+	fs := newFlagSet("team add-members")
+	name := fs.String("name", "", "team name")
+	var members memberFlags
+	fs.Var(&members, "member", "member as ID:USERNAME[:inactive], repeatable")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+
+	if *name == "" || len(members) == 0 || fs.NArg() != 0 {
+		return usageError("team add-members requires -name and at least one -member")
+	}
+
+	resp, err := c.client.AddTeamMembers(ctx, client.AddMembersRequest{TeamName: *name, Members: members})
+	if err != nil {
+		return err
+	}
+
+	return c.printTeam(resp, *resp)
+}
+
+func (c *cli) teamRemoveMembers(ctx context.Context, args []string) error {
+	fs := newFlagSet("team remove-members")
+	name := fs.String("name", "", "team name")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+
+	if *name == "" || fs.NArg() == 0 {
+		return usageError("team remove-members requires -name and USER_ID...")
+	}
+
+	resp, err := c.client.RemoveTeamMembers(ctx, client.RemoveMembersRequest{TeamName: *name, UserIDs: fs.Args()})
+	if err != nil {
+		return err
+	}
+
+	return c.printTeam(resp, resp.Team, resp.Reassignments...)
+}
+
+func (c *cli) teamRename(ctx context.Context, args []string) error {
+	if len(args) != 2 || args[0] == "" || args[1] == "" {
+		return usageError("team rename requires NAME and NEW_NAME")
+	}
+
+	resp, err := c.client.RenameTeam(ctx, args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	return c.printTeam(resp, *resp)
+}
+
+func (c *cli) teamDelete(ctx context.Context, args []string) error {
+	name, err := singleArg("team delete", "NAME", args)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.DeleteTeam(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	return c.printer.print(resp, func(w io.Writer) {
+		row(w, "TEAM", "RESULT")
+		row(w, resp.Team, resp.Message)
+	})
+}
+
+func (c *cli) teamSync(ctx context.Context, args []string) error {
+	fs := newFlagSet("team sync")
+	file := fs.String("f", "", "roster file in JSON or YAML")
+	dryRun := fs.Bool("dry-run", false, "print the plan without applying it")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+
+	if *file == "" || fs.NArg() != 0 {
+		return usageError("team sync requires -f")
+	}
+
+	var req client.SyncRequest
+	if err := readFile(*file, &req); err != nil {
+		return err
+	}
+
+	req.DryRun = *dryRun
+
+	resp, err := c.client.SyncTeams(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return c.printer.print(resp, func(w io.Writer) {
+		writeSyncPlan(w, resp)
+	})
+}
+
+func (c *cli) teamImport(ctx context.Context, args []string) error {
+	fs := newFlagSet("team import")
+	file := fs.String("f", "", "CSV file with team_name,user_id,username[,is_active] columns")
+	bestEffort := fs.Bool("best-effort", false, "skip rejected rows instead of failing the whole import")
+	allowMove := fs.Bool("allow-move", false, "move users that belong to another team")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+
+	if *file == "" || fs.NArg() != 0 {
+		return usageError("team import requires -f")
+	}
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		return err
+	}
+
+	params := client.ImportParams{AllowMove: *allowMove}
+	if *bestEffort {
+		params.Mode = "best_effort"
+	}
+
+	resp, err := c.client.ImportTeams(ctx, data, params)
+	if err != nil {
+		return err
+	}
+
+	return c.printer.print(resp, func(w io.Writer) {
+		fmt.Fprintf(w, "imported %d rows into %d teams\n", resp.Imported, len(resp.Teams))
+
+		if len(resp.Skipped) > 0 {
+			fmt.Fprintln(w)
+			row(w, "LINE", "FIELD", "REASON")
+			for _, skipped := range resp.Skipped {
+				row(w, fmt.Sprint(skipped.Line), orDash(skipped.Field), skipped.Reason)
+			}
+		}
+
+		writeReassignments(w, resp.Reassignments)
+	})
+}
+
+// export writes the CSV export of resource to -f FILE or stdout; with -o json
+// or -o yaml the JSON export returned by fetch is printed instead.
+func (c *cli) export(ctx context.Context, name string, args []string, resource client.ExportResource, fetch func(context.Context) (any, error)) error {
+	fs := newFlagSet(name)
+	file := fs.String("f", "", "write to FILE instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+
+	if err := noArgs(name, fs.Args()); err != nil {
+		return err
+	}
+
+	out := c.printer.w
+	if *file != "" {
+		f, err := os.Create(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		out = f
+	}
+
+	if c.printer.format != "json" && c.printer.format != "yaml" {
+		return c.client.ExportCSV(ctx, resource, out)
+	}
+
+	v, err := fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	return printer{format: c.printer.format, w: out}.print(v, nil)
+}
+
+func (c *cli) userMove(ctx context.Context, args []string) error {
+	fs := newFlagSet("user move")
+	team := fs.String("team", "", "destination team")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+
+	userID, err := singleArg("user move", "USER_ID", fs.Args())
+	if err != nil {
+		return err
+	}
+
+	if *team == "" {
+		return usageError("user move requires -team")
+	}
+
+	resp, err := c.client.MoveUser(ctx, userID, *team)
+	if err != nil {
+		return err
+	}
+
+	return c.printer.print(resp, func(w io.Writer) {
+		row(w, "USER_ID", "USERNAME", "TEAM", "ACTIVE")
+		row(w, resp.User.UserID, resp.User.Username, resp.User.TeamName, resp.User.IsActive)
+		writeReassignments(w, resp.Reassignments)
+	})
+}
+
+func (c *cli) userSetActive(ctx context.Context, args []string, isActive bool) error {
+	command := "user deactivate"
+	if isActive {
+		command = "user activate"
+	}
+
+	userID, err := singleArg(command, "USER_ID", args)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.SetUserActive(ctx, userID, isActive)
+	if err != nil {
+		return err
+	}
+
+	return c.printer.print(resp, func(w io.Writer) {
+		row(w, "USER_ID", "USERNAME", "TEAM", "ACTIVE")
+		row(w, resp.User.UserID, resp.User.Username, resp.User.TeamName, resp.User.IsActive)
+	})
+}
+
+func (c *cli) userReviews(ctx context.Context, args []string) error {
+	userID, err := singleArg("user reviews", "USER_ID", args)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.GetUserReviews(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.printer.print(resp, func(w io.Writer) {
+		row(w, "PR_ID", "NAME", "AUTHOR", "STATUS")
+		for _, pr := range resp.PullRequests {
+			row(w, pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status)
+		}
+	})
+}
+
+func (c *cli) teamLimits(ctx context.Context, args []string) error {
+	fs := newFlagSet("team limits")
+	var limits client.ReviewLimits
+	fs.Var(optionalInt{&limits.MaxOpenReviews}, "max-open", "most open reviews per member; unset means no limit")
+	fs.Var(optionalInt{&limits.ReviewCooldownMinutes}, "cooldown", "minutes between assignments; unset means none")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+
+	name, err := singleArg("team limits", "NAME", fs.Args())
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.SetTeamReviewLimits(ctx, name, limits)
+	if err != nil {
+		return err
+	}
+
+	return c.printer.print(resp, func(w io.Writer) {
+		row(w, "TEAM", "MAX_OPEN", "COOLDOWN_MIN")
+		row(w, resp.TeamName, orDashInt(resp.MaxOpenReviews), orDashInt(resp.ReviewCooldownMinutes))
+	})
+}
+
+func (c *cli) teamLoad(ctx context.Context, args []string) error {
+	name, err := singleArg("team load", "NAME", args)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.GetReviewLoad(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	return c.printer.print(resp, func(w io.Writer) {
+		row(w, "USER_ID", "USERNAME", "OPEN", "MAX_OPEN", "COOLDOWN_UNTIL", "AVAILABLE")
+		for _, member := range resp.Members {
+			cooldownUntil := ""
+			if member.CooldownUntil != nil {
+				cooldownUntil = *member.CooldownUntil
+			}
+
+			row(w, member.UserID, member.Username, member.OpenReviews, orDashInt(member.MaxOpenReviews),
+				orDash(cooldownUntil), member.Available)
+		}
+	})
+}
+
+func (c *cli) userLimits(ctx context.Context, args []string) error {
+	fs := newFlagSet("user limits")
+	var limits client.ReviewLimits
+	fs.Var(optionalInt{&limits.MaxOpenReviews}, "max-open", "most open reviews; unset falls back to the team")
+	fs.Var(optionalInt{&limits.ReviewCooldownMinutes}, "cooldown", "minutes between assignments; unset falls back to the team")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+
+	userID, err := singleArg("user limits", "USER_ID", fs.Args())
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.SetUserReviewLimits(ctx, userID, limits)
+	if err != nil {
+		return err
+	}
+
+	return c.printer.print(resp, func(w io.Writer) {
+		row(w, "USER_ID", "MAX_OPEN", "COOLDOWN_MIN")
+		row(w, resp.UserID, orDashInt(resp.MaxOpenReviews), orDashInt(resp.ReviewCooldownMinutes))
+	})
+}
+
+func (c *cli) userSkills(ctx context.Context, args []string) error {
+	userID, err := singleArg("user skills", "USER_ID", args)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.GetUserSkills(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.printer.print(resp, func(w io.Writer) {
+		row(w, "USER_ID", "SKILLS")
+		row(w, resp.UserID, orDash(strings.Join(resp.Skills, ",")))
+	})
+}
+
+func (c *cli) userSetSkills(ctx context.Context, args []string) error {
+	if len(args) == 0 || args[0] == "" {
+		return usageError("user set-skills requires USER_ID")
+	}
+
+	resp, err := c.client.SetUserSkills(ctx, args[0], args[1:])
+	if err != nil {
+		return err
+	}
+
+	return c.printer.print(resp, func(w io.Writer) {
+		row(w, "USER_ID", "SKILLS")
+		row(w, resp.UserID, orDash(strings.Join(resp.Skills, ",")))
+	})
+}
+
+func (c *cli) teamAway(ctx context.Context, args []string) error {
+	name, err := singleArg("team away", "NAME", args)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.ListAway(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	return c.printer.print(resp, func(w io.Writer) {
+		row(w, "USER_ID", "USERNAME", "UNTIL", "REASON")
+		for _, member := range resp.Away {
+			row(w, member.UserID, member.Username, member.EndsAt, orDash(member.Reason))
+		}
+	})
+}
+
+func (c *cli) timeOffAdd(ctx context.Context, args []string) error {
+	fs := newFlagSet("timeoff add")
+	from := fs.String("from", "", "start, RFC 3339 or YYYY-MM-DD")
+	to := fs.String("to", "", "end (exclusive), RFC 3339 or YYYY-MM-DD")
+	reason := fs.String("reason", "", "reason shown to the team")
+	reassign := fs.Bool("reassign", false, "hand open reviews over when the period starts")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+
+	userID, err := singleArg("timeoff add", "USER_ID", fs.Args())
+	if err != nil {
+		return err
+	}
+
+	if *from == "" || *to == "" {
+		return usageError("timeoff add requires -from and -to")
+	}
+
+	startsAt, err := parseTime(*from)
+	if err != nil {
+		return usageError("timeoff add: -from: %v", err)
+	}
+
+	endsAt, err := parseTime(*to)
+	if err != nil {
+		return usageError("timeoff add: -to: %v", err)
+	}
+
+	resp, err := c.client.CreateTimeOff(ctx, client.CreateTimeOffRequest{
+		UserID:          userID,
+		StartsAt:        startsAt,
+		EndsAt:          endsAt,
+		Reason:          *reason,
+		ReassignReviews: *reassign,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.printer.print(resp, func(w io.Writer) {
+		writeTimeOffTable(w, resp.TimeOff)
+	})
+}
+
+func (c *cli) timeOffList(ctx context.Context, args []string) error {
+	userID, err := singleArg("timeoff list", "USER_ID", args)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.ListTimeOff(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.printer.print(resp, func(w io.Writer) {
+		writeTimeOffTable(w, resp.TimeOff...)
+	})
+}
+
+func (c *cli) timeOffCancel(ctx context.Context, args []string) error {
+	if len(args) != 2 || args[0] == "" {
+		return usageError("timeoff cancel requires USER_ID and TIME_OFF_ID")
+	}
+
+	timeOffID, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		return usageError("timeoff cancel: invalid TIME_OFF_ID %q", args[1])
+	}
+
+	resp, err := c.client.CancelTimeOff(ctx, args[0], timeOffID)
+	if err != nil {
+		return err
+	}
+
+	return c.printer.print(resp, func(w io.Writer) {
+		fmt.Fprintf(w, "time off %d cancelled\n", resp.TimeOffID)
+	})
+}
+
+func (c *cli) prCreate(ctx context.Context, args []string) error {
+	fs := newFlagSet("pr create")
+	id := fs.String("id", "", "pull request ID; a PR of -repo gets <repository>:<number>")
+	name := fs.String("name", "", "pull request name")
+	author := fs.String("author", "", "author user ID")
+	tags := fs.String("tags", "", "comma-separated tags matched against reviewer skills")
+	repository := fs.String("repo", "", "repository the pull request belongs to")
+	number := fs.Int("number", 0, "number in the repository, the next free one if omitted; requires -repo")
+	files := fs.String("files", "", "comma-separated changed paths, requires -repo")
+	var added, removed, filesChanged *int
+	fs.Var(optionalInt{&added}, "added", "lines added")
+	fs.Var(optionalInt{&removed}, "removed", "lines removed")
+	fs.Var(optionalInt{&filesChanged}, "files-changed", "files changed; defaults to the number of -files")
+	priority := fs.String("priority", "", "low, normal or urgent; urgent PRs go to the least loaded reviewers")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+
+	if (*id == "") == (*repository == "") || *name == "" || *author == "" || fs.NArg() != 0 {
+		return usageError("pr create requires one of -id and -repo, -name and -author")
+	}
+
+	if (*files != "" || *number != 0) && *repository == "" {
+		return usageError("pr create -files and -number require -repo")
+	}
+
+	resp, err := c.client.CreatePR(ctx, client.CreatePRRequest{
+		PullRequestID:   *id,
+		PullRequestName: *name,
+		AuthorID:        *author,
+		Tags:            splitList(*tags),
+		Repository:      *repository,
+		Number:          *number,
+		ChangedFiles:    splitList(*files),
+		LinesAdded:      added,
+		LinesRemoved:    removed,
+		FilesChanged:    filesChanged,
+		Priority:        strings.ToLower(*priority),
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.printer.print(resp, func(w io.Writer) {
+		writePRTable(w, resp.PR)
+		writeReviewerMatches(w, resp.PR.ReviewerMatches)
+	})
+}
+
+func (c *cli) prMerge(ctx context.Context, args []string) error {
+	prID, err := singleArg("pr merge", "PR_ID", args)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.MergePR(ctx, prID)
+	if err != nil {
+		return err
+	}
+
+	return c.printPRs(resp, resp.PR)
+}
+
+func (c *cli) prReassign(ctx context.Context, args []string) error {
+	fs := newFlagSet("pr reassign")
+	id := fs.String("id", "", "pull request ID")
+	old := fs.String("old", "", "reviewer to replace")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+
+	if *id == "" || *old == "" || fs.NArg() != 0 {
+		return usageError("pr reassign requires -id and -old")
+	}
+
+	resp, err := c.client.ReassignReviewer(ctx, client.ReassignRequest{
+		PullRequestID: *id,
+		OldUserID:     *old,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.printer.print(resp, func(w io.Writer) {
+		writePRTable(w, resp.PR)
+		fmt.Fprintf(w, "\nreplaced %s with %s\n", *old, resp.ReplacedBy)
+	})
+}
+
+func (c *cli) prGet(ctx context.Context, args []string) error {
+	prID, err := singleArg("pr get", "PR_ID", args)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.GetPR(ctx, prID)
+	if err != nil {
+		return err
+	}
+
+	return c.printPRs(resp, resp.PR)
+}
+
+func (c *cli) prList(ctx context.Context, args []string) error {
+	fs := newFlagSet("pr list")
+	status := fs.String("status", "", "filter by status: OPEN or MERGED")
+	author := fs.String("author", "", "filter by author user ID")
+	repository := fs.String("repo", "", "filter by repository")
+	understaffed := fs.Bool("understaffed", false, "only open PRs with fewer reviewers than required")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+
+	if err := noArgs("pr list", fs.Args()); err != nil {
+		return err
+	}
+
+	resp, err := c.client.ListPRs(ctx, client.ListPRsParams{
+		Status:       strings.ToUpper(*status),
+		AuthorID:     *author,
+		Repository:   *repository,
+		Understaffed: *understaffed,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.printPRs(resp, resp.PullRequests...)
+}
+
+func (c *cli) prUnderstaffed(ctx context.Context, args []string) error {
+	if err := noArgs("pr understaffed", args); err != nil {
+		return err
+	}
+
+	resp, err := c.client.ListUnderstaffedPRs(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.printPRs(resp, resp.PullRequests...)
+}
+
+func (c *cli) repoAdd(ctx context.Context, args []string) error {
+	fs := newFlagSet("repo add")
+	team := fs.String("team", "", "owning team whose members review the pull requests")
+	var policy client.RepositoryPolicy
+	fs.Var(optionalInt{&policy.Reviewers}, "reviewers", "reviewers per pull request; unset keeps the service default")
+	fs.Var(optionalString{&policy.Strategy}, "strategy", "random or least_loaded; unset keeps the service default")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+
+	repository, err := singleArg("repo add", "REPOSITORY", fs.Args())
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.CreateRepository(ctx, client.CreateRepositoryRequest{
+		Repository: repository,
+		TeamName:   *team,
+		Policy:     policy,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.printRepositories(resp, *resp)
+}
+
+func (c *cli) repoGet(ctx context.Context, args []string) error {
+	repository, err := singleArg("repo get", "REPOSITORY", args)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.GetRepository(ctx, repository)
+	if err != nil {
+		return err
+	}
+
+	return c.printRepositories(resp, *resp)
+}
+
+func (c *cli) repoList(ctx context.Context, args []string) error {
+	if err := noArgs("repo list", args); err != nil {
+		return err
+	}
+
+	resp, err := c.client.ListRepositories(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.printRepositories(resp, resp.Repositories...)
+}
+
+// repoUpdate replaces the team and policy, so flags left out are cleared.
+func (c *cli) repoUpdate(ctx context.Context, args []string) error {
+	fs := newFlagSet("repo update")
+	team := fs.String("team", "", "owning team; unset leaves the repository without one")
+	var policy client.RepositoryPolicy
+	fs.Var(optionalInt{&policy.Reviewers}, "reviewers", "reviewers per pull request; unset falls back to the service default")
+	fs.Var(optionalString{&policy.Strategy}, "strategy", "random or least_loaded; unset falls back to the service default")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+
+	repository, err := singleArg("repo update", "REPOSITORY", fs.Args())
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.UpdateRepository(ctx, client.UpdateRepositoryRequest{
+		Repository: repository,
+		TeamName:   *team,
+		Policy:     policy,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.printRepositories(resp, *resp)
+}
+
+func (c *cli) repoDelete(ctx context.Context, args []string) error {
+	repository, err := singleArg("repo delete", "REPOSITORY", args)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.DeleteRepository(ctx, repository)
+	if err != nil {
+		return err
+	}
+
+	return c.printer.print(resp, func(w io.Writer) {
+		row(w, "REPOSITORY", "RESULT")
+		row(w, resp.Repository, resp.Message)
+	})
+}
+
+func (c *cli) codeOwnersUpload(ctx context.Context, args []string) error {
+	fs := newFlagSet("codeowners upload")
+	repository := fs.String("repo", "", "repository the rules belong to")
+	file := fs.String("f", "", "CODEOWNERS file")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+
+	if *repository == "" || *file == "" || fs.NArg() != 0 {
+		return usageError("codeowners upload requires -repo and -f")
+	}
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.UploadCodeOwners(ctx, *repository, data)
+	if err != nil {
+		return err
+	}
+
+	return c.printer.print(resp, func(w io.Writer) {
+		fmt.Fprintf(w, "stored %d rules for %s\n", resp.Rules, resp.Repository)
+
+		if len(resp.UnknownOwners) > 0 {
+			fmt.Fprintf(w, "unknown owners: %s\n", strings.Join(resp.UnknownOwners, ", "))
+		}
+	})
+}
+
+func (c *cli) codeOwnersGet(ctx context.Context, args []string) error {
+	repository, err := singleArg("codeowners get", "REPOSITORY", args)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.GetCodeOwners(ctx, repository)
+	if err != nil {
+		return err
+	}
+
+	return c.printer.print(resp, func(w io.Writer) {
+		row(w, "LINE", "PATTERN", "OWNERS")
+		for _, rule := range resp.Rules {
+			row(w, fmt.Sprint(rule.Line), rule.Pattern, orDash(strings.Join(rule.Owners, " ")))
+		}
+	})
+}
+
+func (c *cli) stats(ctx context.Context) error {
+	resp, err := c.client.GetStats(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.printer.print(resp, func(w io.Writer) {
+		writeStatsTable(w, resp.UserStats)
+		fmt.Fprintln(w)
+		writePRStatsTable(w, resp.PullRequests)
+	})
+}
+
+func (c *cli) printTeam(v any, team client.Team, reassignments ...client.ReviewerChange) error {
+	return c.printer.print(v, func(w io.Writer) {
+		fmt.Fprintf(w, "team: %s\n\n", team.TeamName)
+		row(w, "USER_ID", "USERNAME", "ACTIVE")
+		for _, member := range team.Members {
+			row(w, member.UserID, member.Username, member.IsActive)
+		}
+		writeReassignments(w, reassignments)
+	})
+}
+
+// writeReassignments lists the open reviews handed over after a membership
+// change; a dash means the review was dropped.
+func writeReassignments(w io.Writer, changes []client.ReviewerChange) {
+	if len(changes) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w)
+	row(w, "PR_ID", "OLD_REVIEWER", "NEW_REVIEWER")
+	for _, change := range changes {
+		row(w, change.PullRequestID, change.OldReviewerID, orDash(change.NewReviewerID))
+	}
+}
+
+// printRepositories shows the effective policy; a star marks the values the
+// repository overrides.
+func (c *cli) printRepositories(v any, repositories ...client.Repository) error {
+	return c.printer.print(v, func(w io.Writer) {
+		row(w, "REPOSITORY", "TEAM", "REVIEWERS", "STRATEGY", "PRS")
+		for _, repository := range repositories {
+			reviewers := strconv.Itoa(repository.EffectiveReviewers)
+			if repository.Reviewers != nil {
+				reviewers += "*"
+			}
+
+			strategy := repository.EffectiveStrategy
+			if repository.Strategy != nil {
+				strategy += "*"
+			}
+
+			row(w, repository.Repository, orDash(repository.TeamName), reviewers, strategy, repository.PullRequests)
+		}
+	})
+}
+
+func (c *cli) printPRs(v any, prs ...client.PullRequest) error {
+	return c.printer.print(v, func(w io.Writer) {
+		writePRTable(w, prs...)
+	})
+}
+
+func writePRTable(w io.Writer, prs ...client.PullRequest) {
+	row(w, "PR_ID", "NAME", "AUTHOR", "STATUS", "SIZE", "PRIORITY", "REVIEWERS", "MERGED_AT")
+	for _, pr := range prs {
+		mergedAt := ""
+		if pr.MergedAt != nil {
+			mergedAt = *pr.MergedAt
+		}
+
+		row(w, pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status, orDash(pr.Size), pr.Priority,
+			orDash(strings.Join(pr.AssignedReviewers, ",")), orDash(mergedAt))
+	}
+}
+
+// writeReviewerMatches tells why each newly assigned reviewer was picked.
+func writeReviewerMatches(w io.Writer, matches []client.ReviewerMatch) {
+	if len(matches) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w)
+	row(w, "REVIEWER", "REASON", "SKILLS", "RULE")
+	for _, match := range matches {
+		row(w, match.UserID, match.Reason, orDash(strings.Join(match.Skills, ",")), orDash(match.Rule))
+	}
+}
+
+func writeSyncPlan(w io.Writer, plan *client.SyncResponse) {
+	row(w, "CHANGE", "TEAM", "USER_ID", "DETAILS")
+	for _, team := range plan.TeamsCreated {
+		row(w, "create team", team, "-", "-")
+	}
+	for _, team := range plan.TeamsUpdated {
+		row(w, "update team", team, "-", "review limits")
+	}
+	for _, user := range plan.UsersAdded {
+		row(w, "add user", user.TeamName, user.UserID, fmt.Sprintf("%s active=%t", user.Username, user.IsActive))
+	}
+	for _, user := range plan.UsersMoved {
+		row(w, "move user", user.TeamName, user.UserID, "from "+user.FromTeam)
+	}
+	for _, user := range plan.UsersUpdated {
+		row(w, "update user", user.TeamName, user.UserID, fmt.Sprintf("%s active=%t", user.Username, user.IsActive))
+	}
+	for _, user := range plan.UsersRemoved {
+		row(w, "remove user", user.TeamName, user.UserID, "deactivated")
+	}
+	for _, team := range plan.TeamsRemoved {
+		row(w, "remove team", team, "-", "-")
+	}
+
+	switch {
+	case plan.DryRun:
+		fmt.Fprintln(w, "\ndry run, nothing applied")
+	default:
+		writeReassignments(w, plan.Reassignments)
+	}
+}
+
+func writeTimeOffTable(w io.Writer, periods ...client.TimeOff) {
+	row(w, "ID", "USER_ID", "FROM", "TO", "REASSIGN", "REASON")
+	for _, timeOff := range periods {
+		row(w, timeOff.TimeOffID, timeOff.UserID, timeOff.StartsAt, timeOff.EndsAt, timeOff.ReassignReviews, orDash(timeOff.Reason))
+	}
+}
+
+func writeStatsTable(w io.Writer, stats []client.ReviewStats) {
+	row(w, "USER_ID", "OPEN_PRS", "TOTAL_PRS", "OPEN_LOAD")
+	for _, s := range stats {
+		row(w, s.UserID, s.OpenPRs, s.TotalPRs, s.OpenLoad)
+	}
+}
+
+func writePRStatsTable(w io.Writer, stats []client.PullRequestStats) {
+	row(w, "SIZE", "PRIORITY", "OPEN_PRS", "TOTAL_PRS")
+	for _, s := range stats {
+		row(w, orDash(s.Size), s.Priority, s.OpenPRs, s.TotalPRs)
+	}
+}
+
+// memberFlags collects repeated -member ID:USERNAME[:inactive] values.
+type memberFlags []client.TeamMemberInput
+
+func (m *memberFlags) String() string {
+	return fmt.Sprint(len(*m), " members")
+}
+
+func (m *memberFlags) Set(value string) error {
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("expected ID:USERNAME[:inactive], got %q", value)
+	}
+
+	member := client.TeamMemberInput{UserID: parts[0], Username: parts[1], IsActive: true}
+	if len(parts) == 3 {
+		switch parts[2] {
+		case "active":
+		case "inactive":
+			member.IsActive = false
+		default:
+			return fmt.Errorf("member state must be active or inactive, got %q", parts[2])
+		}
+	}
+
+	*m = append(*m, member)
+
+	return nil
+}
+
+// optionalInt is an int flag that stays nil when not given.
+type optionalInt struct {
+	value **int
+}
+
+func (o optionalInt) String() string {
+	if o.value == nil || *o.value == nil {
+		return ""
+	}
+
+	return strconv.Itoa(**o.value)
+}
+
+func (o optionalInt) Set(value string) error {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("expected an integer, got %q", value)
+	}
+
+	*o.value = &n
+
+	return nil
+}
+
+// optionalString is a string flag that stays nil when not given.
+type optionalString struct {
+	value **string
+}
+
+func (o optionalString) String() string {
+	if o.value == nil || *o.value == nil {
+		return ""
+	}
+
+	return **o.value
+}
+
+func (o optionalString) Set(value string) error {
+	*o.value = &value
+
+	return nil
+}
+
+// readFile decodes a JSON or YAML file into v using v's JSON field names.
+func readFile(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	data, err = json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// parseTime accepts RFC 3339 timestamps and plain dates, which mean local
+// midnight.
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.ParseInLocation(time.DateOnly, value, time.Local); err == nil {
+		return t, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+// splitList splits a comma-separated flag value, dropping empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	return fs
+}
+
+func singleArg(command, name string, args []string) (string, error) {
+	if len(args) != 1 || args[0] == "" {
+		return "", usageError("%s requires %s", command, name)
+	}
+
+	return args[0], nil
+}
+
+func noArgs(command string, args []string) error {
+	if len(args) != 0 {
+		return usageError("%s takes no arguments", command)
+	}
+
+	return nil
+}
+
+func usageError(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", errUsage, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// cliConfig holds the prctl settings, loaded from
+// ~/.config/prctl/config.yaml unless -config points elsewhere.
+type cliConfig struct {
+	Server string `yaml:"server"`
+	Token  string `yaml:"token"`
+	Output string `yaml:"output"`
+}
+
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "prctl", "config.yaml")
+}
+
+func loadConfig(path, server, token, output string) (*cliConfig, error) {
+	cfg := &cliConfig{
+		Server: "http://localhost:8080",
+		Output: "table",
+	}
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+		case err != nil:
+			return nil, fmt.Errorf("read config: %w", err)
+		default:
+			if err := yaml.Unmarshal(data, cfg); err != nil {
+				return nil, fmt.Errorf("parse config %s: %w", path, err)
+			}
+		}
+	}
+
+	override(&cfg.Server, os.Getenv("PRCTL_SERVER"), server)
+	override(&cfg.Token, os.Getenv("PRCTL_TOKEN"), token)
+	override(&cfg.Output, os.Getenv("PRCTL_OUTPUT"), output)
+
+	switch cfg.Output {
+	case "table", "json", "yaml":
+	default:
+		return nil, fmt.Errorf("unknown output format %q", cfg.Output)
+	}
+
+	if cfg.Server == "" {
+		return nil, errors.New("server URL is required")
+	}
+
+	return cfg, nil
+}
+
+// override sets dst to the last non-empty value.
+func override(dst *string, values ...string) {
+	for _, v := range values {
+		if v != "" {
+			*dst = v
+		}
+	}
+}
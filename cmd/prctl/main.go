@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/IlyaAGL/avito_autumn_2025/pkg/client"
+)
+
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUsage   = 2
+)
+
+const usage = `Usage: prctl [flags] <command> <subcommand> [args]
+
+Commands:
+  team add [-upsert] [-allow-move] -name NAME (-member ID:USERNAME[:inactive] ... | -f FILE)
+  team get NAME
+  team deactivate NAME
+  team list
+  team add-members -name NAME -member ID:USERNAME[:inactive] ...
+  team remove-members -name NAME USER_ID...
+  team rename NAME NEW_NAME
+  team delete NAME
+  team sync -f FILE [-dry-run]
+  team import -f FILE.csv [-best-effort] [-allow-move]
+  team export [-f FILE]
+  team away NAME
+  team limits [-max-open N] [-cooldown MINUTES] NAME
+  team load NAME
+  user activate USER_ID
+  user deactivate USER_ID
+  user reviews USER_ID
+  user limits [-max-open N] [-cooldown MINUTES] USER_ID
+  user skills USER_ID
+  user set-skills USER_ID [SKILL...]
+  user move -team TEAM USER_ID
+  user export [-f FILE]
+  timeoff add -from TIME -to TIME [-reason TEXT] [-reassign] USER_ID
+  timeoff list USER_ID
+  timeoff cancel USER_ID TIME_OFF_ID
+  pr create (-id ID | -repo REPOSITORY [-number N] [-files PATH,...]) -name NAME -author USER_ID [-tags TAG,...]
+            [-added N] [-removed N] [-files-changed N] [-priority low|normal|urgent]
+  pr merge PR_ID
+  pr reassign -id PR_ID -old USER_ID
+  pr get PR_ID
+  pr list [-status OPEN|MERGED] [-author USER_ID] [-repo REPOSITORY] [-understaffed]
+  pr understaffed
+  pr export [-f FILE]
+  repo add [-team TEAM] [-reviewers N] [-strategy random|least_loaded] REPOSITORY
+  repo get REPOSITORY
+  repo list
+  repo update [-team TEAM] [-reviewers N] [-strategy random|least_loaded] REPOSITORY
+  repo delete REPOSITORY
+  codeowners upload -repo REPOSITORY -f FILE
+  codeowners get REPOSITORY
+  stats
+
+Settings are read from the config file, then PRCTL_SERVER, PRCTL_TOKEN and
+PRCTL_OUTPUT, then flags.
+
+Flags:
+`
+
+func main() {
+	os.Exit(run())
+}
+
+func run() int {
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+
+	configPath := flag.String("config", defaultConfigPath(), "path to the prctl config file")
+	server := flag.String("server", "", "API base URL")
+	token := flag.String("token", "", "bearer token sent to the API")
+	output := flag.String("o", "", "output format: table, json or yaml")
+	flag.Parse()
+
+	cfg, err := loadConfig(*configPath, *server, *token, *output)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "prctl: %v\n", err)
+		return exitUsage
+	}
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		return exitUsage
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	app := &cli{
+		client:  client.New(cfg.Server, client.WithToken(cfg.Token)),
+		printer: printer{format: cfg.Output, w: os.Stdout},
+	}
+
+	if err := app.run(ctx, flag.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "prctl: %v\n", err)
+
+		if errors.Is(err, errUsage) {
+			return exitUsage
+		}
+		return exitFailure
+	}
+
+	return exitOK
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+type printer struct {
+	format string
+	w      io.Writer
+}
+
+// print writes v as JSON or YAML, or calls table for the table format.
+// YAML is produced from the JSON encoding so both formats share field names.
+func (p printer) print(v any, table func(w io.Writer)) error {
+	switch p.format {
+	case "json":
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case "yaml":
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+
+		var node yaml.Node
+		if err := yaml.Unmarshal(data, &node); err != nil {
+			return err
+		}
+
+		blockStyle(&node)
+
+		enc := yaml.NewEncoder(p.w)
+		enc.SetIndent(2)
+		if err := enc.Encode(&node); err != nil {
+			return err
+		}
+		return enc.Close()
+	default:
+		w := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+		table(w)
+		return w.Flush()
+	}
+}
+
+// blockStyle drops the flow style the JSON input was parsed with.
+func blockStyle(node *yaml.Node) {
+	node.Style &^= yaml.FlowStyle | yaml.DoubleQuotedStyle
+
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+func row(w io.Writer, cells ...any) {
+	parts := make([]string, len(cells))
+	for i, cell := range cells {
+		parts[i] = fmt.Sprint(cell)
+	}
+
+	fmt.Fprintln(w, strings.Join(parts, "\t"))
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
+
+func orDashInt(n *int) string {
+	if n == nil {
+		return "-"
+	}
+
+	return strconv.Itoa(*n)
+}
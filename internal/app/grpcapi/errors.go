@@ -0,0 +1,96 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/IlyaAGL/avito_autumn_2025/internal/app/validation"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/models"
+	"github.com/gin-gonic/gin/binding"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// validate runs the DTO binding rules the HTTP handlers use, so both
+// transports reject the same input.
+func validate(obj any) error {
+	err := binding.Validator.ValidateStruct(obj)
+	if err == nil {
+		return nil
+	}
+
+	st := status.New(codes.InvalidArgument, "Request validation failed")
+
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0)
+	for _, detail := range validation.Details(err) {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       detail.Field,
+			Description: detail.Reason,
+		})
+	}
+
+	withDetails, detailErr := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if detailErr != nil {
+		return st.Err()
+	}
+
+	return withDetails.Err()
+}
+
+// toStatus maps a domain error to a gRPC status. message describes what was
+// not found, mirroring the HTTP NOT_FOUND messages.
+func toStatus(ctx context.Context, err error, op, message string) error {
+	var conflictErr *models.MemberConflictError
+
+	switch {
+	case errors.As(err, &conflictErr):
+		return memberConflictStatus(conflictErr)
+	case errors.Is(err, models.ErrNotFound):
+		return status.Error(codes.NotFound, message)
+	case errors.Is(err, models.ErrTeamExists):
+		return status.Error(codes.AlreadyExists, "Team already exists")
+	case errors.Is(err, models.ErrTeamNotEmpty):
+		return status.Error(codes.FailedPrecondition, "Team still has members")
+	case errors.Is(err, models.ErrPRExists):
+		return status.Error(codes.AlreadyExists, "PR already exists")
+	case errors.Is(err, models.ErrPRMerged):
+		return status.Error(codes.FailedPrecondition, "Cannot reassign on merged PR")
+	case errors.Is(err, models.ErrNotAssigned):
+		return status.Error(codes.FailedPrecondition, "Reviewer is not assigned to this PR")
+	case errors.Is(err, models.ErrNoCandidate):
+		return status.Error(codes.FailedPrecondition, "No active replacement candidate in team")
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "Request canceled")
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, "Deadline exceeded")
+	default:
+		slog.ErrorContext(ctx, op+" failed", "error", err)
+
+		return status.Error(codes.Internal, "Internal server error")
+	}
+}
+
+// memberConflictStatus reports each user that belongs to another team as a
+// google.rpc.PreconditionFailure violation.
+func memberConflictStatus(err *models.MemberConflictError) error {
+	st := status.New(codes.FailedPrecondition, "Users already belong to another team")
+
+	violations := make([]*errdetails.PreconditionFailure_Violation, len(err.Conflicts))
+	for i, conflict := range err.Conflicts {
+		violations[i] = &errdetails.PreconditionFailure_Violation{
+			Type:        "MEMBER_CONFLICT",
+			Subject:     conflict.UserID,
+			Description: fmt.Sprintf("user %s already belongs to team %s", conflict.UserID, conflict.TeamName),
+		}
+	}
+
+	withDetails, detailErr := st.WithDetails(&errdetails.PreconditionFailure{Violations: violations})
+	if detailErr != nil {
+		return st.Err()
+	}
+
+	return withDetails.Err()
+}
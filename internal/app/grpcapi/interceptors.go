@@ -0,0 +1,85 @@
+package grpcapi
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	"github.com/IlyaAGL/avito_autumn_2025/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// requestIDKey is the metadata counterpart of the X-Request-ID HTTP header.
+const requestIDKey = "x-request-id"
+
+const maxRequestIDLength = 128
+
+func requestIDInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	var requestID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDKey); len(values) > 0 {
+			requestID = values[0]
+		}
+	}
+
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		requestID = newRequestID()
+	}
+
+	if err := grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, requestID)); err != nil {
+		slog.DebugContext(ctx, "failed to set request id header", "error", err)
+	}
+
+	return handler(logger.WithRequestID(ctx, requestID), req)
+}
+
+func loggingInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+
+	resp, err := handler(ctx, req)
+
+	code := status.Code(err)
+
+	level := slog.LevelInfo
+	switch code {
+	case codes.OK:
+	case codes.Internal, codes.Unknown, codes.Unavailable, codes.DataLoss:
+		level = slog.LevelError
+	default:
+		level = slog.LevelWarn
+	}
+
+	slog.Log(ctx, level, "grpc request",
+		"method", info.FullMethod,
+		"code", code.String(),
+		"latency_ms", time.Since(start).Milliseconds(),
+	)
+
+	return resp, err
+}
+
+func recoveryInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			slog.ErrorContext(ctx, "panic recovered", "panic", recovered)
+
+			err = status.Error(codes.Internal, "Internal server error")
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+func newRequestID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "unknown"
+	}
+
+	return hex.EncodeToString(buf)
+}
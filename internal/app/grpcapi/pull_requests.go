@@ -0,0 +1,160 @@
+package grpcapi
+
+import (
+	"context"
+	"time"
+
+	prreviewersv1 "github.com/IlyaAGL/avito_autumn_2025/api/proto/prreviewers/v1"
+	pullrequests "github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/prs"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type pullRequestServer struct {
+	prreviewersv1.UnimplementedPullRequestServiceServer
+	prService PullRequestService
+}
+
+func (s *pullRequestServer) CreatePullRequest(ctx context.Context, req *prreviewersv1.CreatePullRequestRequest) (*prreviewersv1.PullRequest, error) {
+	createReq := pullrequests.CreateRequest{
+		PullRequestID:   req.GetPullRequestId(),
+		PullRequestName: req.GetPullRequestName(),
+		AuthorID:        req.GetAuthorId(),
+	}
+	if err := validate(createReq); err != nil {
+		return nil, err
+	}
+
+	response, err := s.prService.CreatePR(ctx, createReq)
+	if err != nil {
+		return nil, toStatus(ctx, err, "create pull request", "Author not found")
+	}
+
+	return toPullRequest(response.PR), nil
+}
+
+func (s *pullRequestServer) GetPullRequest(ctx context.Context, req *prreviewersv1.GetPullRequestRequest) (*prreviewersv1.PullRequest, error) {
+	if err := validate(pullrequests.GetParams{PullRequestID: req.GetPullRequestId()}); err != nil {
+		return nil, err
+	}
+
+	response, err := s.prService.GetPR(ctx, req.GetPullRequestId())
+	if err != nil {
+		return nil, toStatus(ctx, err, "get pull request", "PR not found")
+	}
+
+	return toPullRequest(*response), nil
+}
+
+func (s *pullRequestServer) ListPullRequests(ctx context.Context, req *prreviewersv1.ListPullRequestsRequest) (*prreviewersv1.ListPullRequestsResponse, error) {
+	params := pullrequests.ListParams{
+		Status:   fromStatus(req.GetStatus()),
+		AuthorID: req.GetAuthorId(),
+	}
+	if err := validate(params); err != nil {
+		return nil, err
+	}
+
+	response, err := s.prService.ListPRs(ctx, params)
+	if err != nil {
+		return nil, toStatus(ctx, err, "list pull requests", "PR not found")
+	}
+
+	prs := make([]*prreviewersv1.PullRequest, len(response.PullRequests))
+	for i, pr := range response.PullRequests {
+		prs[i] = toPullRequest(pr)
+	}
+
+	return &prreviewersv1.ListPullRequestsResponse{PullRequests: prs}, nil
+}
+
+func (s *pullRequestServer) MergePullRequest(ctx context.Context, req *prreviewersv1.MergePullRequestRequest) (*prreviewersv1.PullRequest, error) {
+	mergeReq := pullrequests.MergeRequest{PullRequestID: req.GetPullRequestId()}
+	if err := validate(mergeReq); err != nil {
+		return nil, err
+	}
+
+	response, err := s.prService.MergePR(ctx, mergeReq)
+	if err != nil {
+		return nil, toStatus(ctx, err, "merge pull request", "PR not found")
+	}
+
+	return toPullRequest(response.PR), nil
+}
+
+func (s *pullRequestServer) ReassignReviewer(ctx context.Context, req *prreviewersv1.ReassignReviewerRequest) (*prreviewersv1.ReassignReviewerResponse, error) {
+	reassignReq := pullrequests.ReassignRequest{
+		PullRequestID: req.GetPullRequestId(),
+		OldUserID:     req.GetOldReviewerId(),
+	}
+	if err := validate(reassignReq); err != nil {
+		return nil, err
+	}
+
+	response, err := s.prService.ReassignReviewer(ctx, reassignReq)
+	if err != nil {
+		return nil, toStatus(ctx, err, "reassign reviewer", "PR or user not found")
+	}
+
+	return &prreviewersv1.ReassignReviewerResponse{
+		PullRequest: toPullRequest(response.PR),
+		ReplacedBy:  response.ReplacedBy,
+	}, nil
+}
+
+func (s *pullRequestServer) GetReviewStats(ctx context.Context, _ *prreviewersv1.GetReviewStatsRequest) (*prreviewersv1.GetReviewStatsResponse, error) {
+	response, err := s.prService.GetStats(ctx)
+	if err != nil {
+		return nil, toStatus(ctx, err, "get review stats", "Stats not found")
+	}
+
+	stats := make([]*prreviewersv1.ReviewStats, len(response.UserStats))
+	for i, stat := range response.UserStats {
+		stats[i] = &prreviewersv1.ReviewStats{
+			UserId:   stat.UserID,
+			OpenPrs:  int32(stat.OpenPRs),
+			TotalPrs: int32(stat.TotalPRs),
+		}
+	}
+
+	return &prreviewersv1.GetReviewStatsResponse{UserStats: stats}, nil
+}
+
+func toPullRequest(pr pullrequests.PullRequestResponse) *prreviewersv1.PullRequest {
+	result := &prreviewersv1.PullRequest{
+		PullRequestId:     pr.PullRequestID,
+		PullRequestName:   pr.PullRequestName,
+		AuthorId:          pr.AuthorID,
+		Status:            toStatusEnum(pr.Status),
+		AssignedReviewers: pr.AssignedReviewers,
+	}
+
+	if pr.MergedAt != nil {
+		if mergedAt, err := time.Parse(time.RFC3339, *pr.MergedAt); err == nil {
+			result.MergedAt = timestamppb.New(mergedAt)
+		}
+	}
+
+	return result
+}
+
+func toStatusEnum(status string) prreviewersv1.PullRequestStatus {
+	switch status {
+	case "OPEN":
+		return prreviewersv1.PullRequestStatus_PULL_REQUEST_STATUS_OPEN
+	case "MERGED":
+		return prreviewersv1.PullRequestStatus_PULL_REQUEST_STATUS_MERGED
+	default:
+		return prreviewersv1.PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+	}
+}
+
+func fromStatus(status prreviewersv1.PullRequestStatus) string {
+	switch status {
+	case prreviewersv1.PullRequestStatus_PULL_REQUEST_STATUS_OPEN:
+		return "OPEN"
+	case prreviewersv1.PullRequestStatus_PULL_REQUEST_STATUS_MERGED:
+		return "MERGED"
+	default:
+		return ""
+	}
+}
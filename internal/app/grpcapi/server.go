@@ -0,0 +1,63 @@
+// Package grpcapi serves the prreviewers.v1 gRPC API on top of the same
+// services as the HTTP handlers.
+package grpcapi
+
+import (
+	"context"
+
+	prreviewersv1 "github.com/IlyaAGL/avito_autumn_2025/api/proto/prreviewers/v1"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/common"
+	pullrequests "github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/prs"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/teams"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/users"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+type TeamService interface {
+	CreateTeam(ctx context.Context, req teams.CreateRequest) (*teams.CreateResponse, error)
+	GetTeam(ctx context.Context, teamName string) (*teams.TeamResponse, error)
+	ListTeams(ctx context.Context) (*teams.ListResponse, error)
+	BulkDeactivateUsers(ctx context.Context, teamName string) error
+	AddMembers(ctx context.Context, req teams.AddMembersRequest) (*teams.TeamResponse, error)
+	RemoveMembers(ctx context.Context, req teams.RemoveMembersRequest) (*teams.RemoveMembersResponse, error)
+	MoveUser(ctx context.Context, req users.MoveRequest) (*users.MoveResponse, error)
+	RenameTeam(ctx context.Context, req teams.RenameRequest) (*teams.TeamResponse, error)
+	DeleteTeam(ctx context.Context, teamName string) error
+}
+
+type UserService interface {
+	SetUserActive(ctx context.Context, req users.SetActiveRequest) (*users.SetActiveResponse, error)
+	GetUserReviewPRs(ctx context.Context, userID string) (*users.ReviewResponse, error)
+	GetUser(ctx context.Context, userID string) (*users.UserResponse, error)
+}
+
+type PullRequestService interface {
+	CreatePR(ctx context.Context, req pullrequests.CreateRequest) (*pullrequests.CreateResponse, error)
+	MergePR(ctx context.Context, req pullrequests.MergeRequest) (*pullrequests.MergeResponse, error)
+	ReassignReviewer(ctx context.Context, req pullrequests.ReassignRequest) (*pullrequests.ReassignResponse, error)
+	GetPR(ctx context.Context, prID string) (*pullrequests.PullRequestResponse, error)
+	ListPRs(ctx context.Context, params pullrequests.ListParams) (*pullrequests.ListResponse, error)
+	GetStats(ctx context.Context) (*common.StatsResponse, error)
+}
+
+// NewServer builds a gRPC server with the team, user and pull request
+// services and server reflection registered.
+func NewServer(teamService TeamService, userService UserService, prService PullRequestService) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			requestIDInterceptor,
+			loggingInterceptor,
+			recoveryInterceptor,
+		),
+	)
+
+	prreviewersv1.RegisterTeamServiceServer(server, &teamServer{teamService: teamService})
+	prreviewersv1.RegisterUserServiceServer(server, &userServer{userService: userService})
+	prreviewersv1.RegisterPullRequestServiceServer(server, &pullRequestServer{prService: prService})
+	reflection.Register(server)
+
+	return server
+}
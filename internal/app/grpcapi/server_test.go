@@ -0,0 +1,614 @@
+package grpcapi_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	prreviewersv1 "github.com/IlyaAGL/avito_autumn_2025/api/proto/prreviewers/v1"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/app/grpcapi"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/app/validation"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/common"
+	pullrequests "github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/prs"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/teams"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/users"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/models"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// The fake services answer from their input, or fail with err when it is
+// set, so the tests see only what the transport does with them.
+
+type fakeTeamService struct {
+	err error
+}
+
+func (s *fakeTeamService) team(name string) teams.TeamResponse {
+	return teams.TeamResponse{
+		TeamName: name,
+		Members:  []teams.MemberResponse{{UserID: "u1", Username: "Alice", IsActive: true}},
+	}
+}
+
+func (s *fakeTeamService) CreateTeam(_ context.Context, req teams.CreateRequest) (*teams.CreateResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return &teams.CreateResponse{Team: s.team(req.TeamName)}, nil
+}
+
+func (s *fakeTeamService) GetTeam(_ context.Context, teamName string) (*teams.TeamResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	team := s.team(teamName)
+	return &team, nil
+}
+
+func (s *fakeTeamService) ListTeams(context.Context) (*teams.ListResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return &teams.ListResponse{Teams: []teams.TeamSummary{{TeamName: "backend", Members: 2, ActiveMembers: 1}}}, nil
+}
+
+func (s *fakeTeamService) BulkDeactivateUsers(context.Context, string) error {
+	return s.err
+}
+
+func (s *fakeTeamService) AddMembers(_ context.Context, req teams.AddMembersRequest) (*teams.TeamResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	team := s.team(req.TeamName)
+	return &team, nil
+}
+
+func (s *fakeTeamService) RemoveMembers(_ context.Context, req teams.RemoveMembersRequest) (*teams.RemoveMembersResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return &teams.RemoveMembersResponse{
+		Team:          s.team(req.TeamName),
+		Reassignments: []common.ReviewerChange{{PullRequestID: "pr-1", OldReviewerID: req.UserIDs[0], NewReviewerID: "u1"}},
+	}, nil
+}
+
+func (s *fakeTeamService) MoveUser(_ context.Context, req users.MoveRequest) (*users.MoveResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return &users.MoveResponse{User: users.UserResponse{UserID: req.UserID, TeamName: req.TeamName, IsActive: true}}, nil
+}
+
+func (s *fakeTeamService) RenameTeam(_ context.Context, req teams.RenameRequest) (*teams.TeamResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	team := s.team(req.NewTeamName)
+	return &team, nil
+}
+
+func (s *fakeTeamService) DeleteTeam(context.Context, string) error {
+	return s.err
+}
+
+type fakeUserService struct {
+	err error
+}
+
+func (s *fakeUserService) SetUserActive(_ context.Context, req users.SetActiveRequest) (*users.SetActiveResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return &users.SetActiveResponse{User: users.UserResponse{UserID: req.UserID, TeamName: "backend", IsActive: req.IsActive}}, nil
+}
+
+func (s *fakeUserService) GetUserReviewPRs(_ context.Context, userID string) (*users.ReviewResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return &users.ReviewResponse{
+		UserID:       userID,
+		PullRequests: []users.PullRequestShort{{PullRequestID: "pr-1", AuthorID: "u1", Status: "OPEN"}},
+	}, nil
+}
+
+func (s *fakeUserService) GetUser(_ context.Context, userID string) (*users.UserResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return &users.UserResponse{UserID: userID, Username: "Bob", TeamName: "backend", IsActive: true}, nil
+}
+
+type fakePullRequestService struct {
+	err error
+}
+
+func (s *fakePullRequestService) pr(prID, status string) pullrequests.PullRequestResponse {
+	return pullrequests.PullRequestResponse{
+		PullRequestID:     prID,
+		PullRequestName:   "Add search",
+		AuthorID:          "u1",
+		Status:            status,
+		AssignedReviewers: []string{"u2", "u3"},
+	}
+}
+
+func (s *fakePullRequestService) CreatePR(_ context.Context, req pullrequests.CreateRequest) (*pullrequests.CreateResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return &pullrequests.CreateResponse{PR: s.pr(req.PullRequestID, "OPEN")}, nil
+}
+
+func (s *fakePullRequestService) MergePR(_ context.Context, req pullrequests.MergeRequest) (*pullrequests.MergeResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	pr := s.pr(req.PullRequestID, "MERGED")
+	mergedAt := "2025-11-20T12:00:00Z"
+	pr.MergedAt = &mergedAt
+
+	return &pullrequests.MergeResponse{PR: pr}, nil
+}
+
+func (s *fakePullRequestService) ReassignReviewer(_ context.Context, req pullrequests.ReassignRequest) (*pullrequests.ReassignResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return &pullrequests.ReassignResponse{PR: s.pr(req.PullRequestID, "OPEN"), ReplacedBy: "u4"}, nil
+}
+
+func (s *fakePullRequestService) GetPR(_ context.Context, prID string) (*pullrequests.PullRequestResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	pr := s.pr(prID, "OPEN")
+	return &pr, nil
+}
+
+func (s *fakePullRequestService) ListPRs(_ context.Context, params pullrequests.ListParams) (*pullrequests.ListResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return &pullrequests.ListResponse{PullRequests: []pullrequests.PullRequestResponse{s.pr("pr-1", params.Status)}}, nil
+}
+
+func (s *fakePullRequestService) GetStats(context.Context) (*common.StatsResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return &common.StatsResponse{UserStats: []common.ReviewStats{{UserID: "u2", OpenPRs: 1, TotalPRs: 3}}}, nil
+}
+
+type clients struct {
+	teams prreviewersv1.TeamServiceClient
+	users prreviewersv1.UserServiceClient
+	prs   prreviewersv1.PullRequestServiceClient
+}
+
+// dial serves the fakes failing with err over an in-memory listener.
+func dial(t *testing.T, err error) clients {
+	t.Helper()
+
+	if err := validation.Register(); err != nil {
+		t.Fatal(err)
+	}
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpcapi.NewServer(&fakeTeamService{err: err}, &fakeUserService{err: err}, &fakePullRequestService{err: err})
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, dialErr := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if dialErr != nil {
+		t.Fatal(dialErr)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return clients{
+		teams: prreviewersv1.NewTeamServiceClient(conn),
+		users: prreviewersv1.NewUserServiceClient(conn),
+		prs:   prreviewersv1.NewPullRequestServiceClient(conn),
+	}
+}
+
+var member = &prreviewersv1.TeamMember{UserId: "u1", Username: "Alice", IsActive: true}
+
+func TestRPCs(t *testing.T) {
+	ctx := context.Background()
+	c := dial(t, nil)
+
+	tests := []struct {
+		name string
+		call func() (string, error)
+		want string
+	}{
+		{
+			name: "CreateTeam",
+			call: func() (string, error) {
+				team, err := c.teams.CreateTeam(ctx, &prreviewersv1.CreateTeamRequest{TeamName: "backend", Members: []*prreviewersv1.TeamMember{member}})
+				return team.GetTeamName() + " " + team.GetMembers()[0].GetUserId(), err
+			},
+			want: "backend u1",
+		},
+		{
+			name: "GetTeam",
+			call: func() (string, error) {
+				team, err := c.teams.GetTeam(ctx, &prreviewersv1.GetTeamRequest{TeamName: "backend"})
+				return team.GetTeamName(), err
+			},
+			want: "backend",
+		},
+		{
+			name: "ListTeams",
+			call: func() (string, error) {
+				resp, err := c.teams.ListTeams(ctx, &prreviewersv1.ListTeamsRequest{})
+				if err != nil {
+					return "", err
+				}
+				return resp.GetTeams()[0].String(), err
+			},
+			want: (&prreviewersv1.TeamSummary{TeamName: "backend", Members: 2, ActiveMembers: 1}).String(),
+		},
+		{
+			name: "DeactivateTeam",
+			call: func() (string, error) {
+				resp, err := c.teams.DeactivateTeam(ctx, &prreviewersv1.DeactivateTeamRequest{TeamName: "backend"})
+				return resp.GetTeamName(), err
+			},
+			want: "backend",
+		},
+		{
+			name: "AddMembers",
+			call: func() (string, error) {
+				team, err := c.teams.AddMembers(ctx, &prreviewersv1.AddMembersRequest{TeamName: "backend", Members: []*prreviewersv1.TeamMember{member}})
+				return team.GetTeamName(), err
+			},
+			want: "backend",
+		},
+		{
+			name: "RemoveMembers",
+			call: func() (string, error) {
+				resp, err := c.teams.RemoveMembers(ctx, &prreviewersv1.RemoveMembersRequest{TeamName: "backend", UserIds: []string{"u2"}})
+				if err != nil {
+					return "", err
+				}
+				change := resp.GetReassignments()[0]
+				return change.GetPullRequestId() + " " + change.GetOldReviewerId() + "->" + change.GetNewReviewerId(), err
+			},
+			want: "pr-1 u2->u1",
+		},
+		{
+			name: "MoveUser",
+			call: func() (string, error) {
+				resp, err := c.teams.MoveUser(ctx, &prreviewersv1.MoveUserRequest{UserId: "u2", TeamName: "frontend"})
+				return resp.GetUser().GetUserId() + " " + resp.GetUser().GetTeamName(), err
+			},
+			want: "u2 frontend",
+		},
+		{
+			name: "RenameTeam",
+			call: func() (string, error) {
+				team, err := c.teams.RenameTeam(ctx, &prreviewersv1.RenameTeamRequest{TeamName: "backend", NewTeamName: "platform"})
+				return team.GetTeamName(), err
+			},
+			want: "platform",
+		},
+		{
+			name: "DeleteTeam",
+			call: func() (string, error) {
+				resp, err := c.teams.DeleteTeam(ctx, &prreviewersv1.DeleteTeamRequest{TeamName: "backend"})
+				return resp.GetTeamName(), err
+			},
+			want: "backend",
+		},
+		{
+			name: "GetUser",
+			call: func() (string, error) {
+				user, err := c.users.GetUser(ctx, &prreviewersv1.GetUserRequest{UserId: "u2"})
+				return user.GetUserId() + " " + user.GetUsername(), err
+			},
+			want: "u2 Bob",
+		},
+		{
+			name: "SetUserActive",
+			call: func() (string, error) {
+				user, err := c.users.SetUserActive(ctx, &prreviewersv1.SetUserActiveRequest{UserId: "u2", IsActive: false})
+				if user.GetIsActive() {
+					return "active", err
+				}
+				return user.GetUserId(), err
+			},
+			want: "u2",
+		},
+		{
+			name: "GetUserReviews",
+			call: func() (string, error) {
+				resp, err := c.users.GetUserReviews(ctx, &prreviewersv1.GetUserReviewsRequest{UserId: "u2"})
+				if err != nil {
+					return "", err
+				}
+				return resp.GetUserId() + " " + resp.GetPullRequests()[0].GetPullRequestId(), err
+			},
+			want: "u2 pr-1",
+		},
+		{
+			name: "CreatePullRequest",
+			call: func() (string, error) {
+				pr, err := c.prs.CreatePullRequest(ctx, &prreviewersv1.CreatePullRequestRequest{PullRequestId: "pr-1", PullRequestName: "Add search", AuthorId: "u1"})
+				return pr.GetPullRequestId() + " " + pr.GetStatus().String(), err
+			},
+			want: "pr-1 PULL_REQUEST_STATUS_OPEN",
+		},
+		{
+			name: "GetPullRequest",
+			call: func() (string, error) {
+				pr, err := c.prs.GetPullRequest(ctx, &prreviewersv1.GetPullRequestRequest{PullRequestId: "pr-1"})
+				return pr.GetPullRequestId(), err
+			},
+			want: "pr-1",
+		},
+		{
+			name: "ListPullRequests",
+			call: func() (string, error) {
+				resp, err := c.prs.ListPullRequests(ctx, &prreviewersv1.ListPullRequestsRequest{Status: prreviewersv1.PullRequestStatus_PULL_REQUEST_STATUS_MERGED})
+				if err != nil {
+					return "", err
+				}
+				return resp.GetPullRequests()[0].GetStatus().String(), err
+			},
+			want: "PULL_REQUEST_STATUS_MERGED",
+		},
+		{
+			name: "MergePullRequest",
+			call: func() (string, error) {
+				pr, err := c.prs.MergePullRequest(ctx, &prreviewersv1.MergePullRequestRequest{PullRequestId: "pr-1"})
+				return pr.GetStatus().String() + " " + pr.GetMergedAt().AsTime().Format("2006-01-02"), err
+			},
+			want: "PULL_REQUEST_STATUS_MERGED 2025-11-20",
+		},
+		{
+			name: "ReassignReviewer",
+			call: func() (string, error) {
+				resp, err := c.prs.ReassignReviewer(ctx, &prreviewersv1.ReassignReviewerRequest{PullRequestId: "pr-1", OldReviewerId: "u2"})
+				return resp.GetPullRequest().GetPullRequestId() + " " + resp.GetReplacedBy(), err
+			},
+			want: "pr-1 u4",
+		},
+		{
+			name: "GetReviewStats",
+			call: func() (string, error) {
+				resp, err := c.prs.GetReviewStats(ctx, &prreviewersv1.GetReviewStatsRequest{})
+				if err != nil {
+					return "", err
+				}
+				return resp.GetUserStats()[0].String(), err
+			},
+			want: (&prreviewersv1.ReviewStats{UserId: "u2", OpenPrs: 1, TotalPrs: 3}).String(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.call()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorCodes(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		err  error
+		call func(c clients) error
+		want codes.Code
+	}{
+		{
+			name: "not found",
+			err:  models.ErrNotFound,
+			call: func(c clients) error {
+				_, err := c.teams.GetTeam(ctx, &prreviewersv1.GetTeamRequest{TeamName: "backend"})
+				return err
+			},
+			want: codes.NotFound,
+		},
+		{
+			name: "wrapped not found",
+			err:  errors.Join(errors.New("author u1"), models.ErrNotFound),
+			call: func(c clients) error {
+				_, err := c.prs.CreatePullRequest(ctx, &prreviewersv1.CreatePullRequestRequest{PullRequestId: "pr-1", PullRequestName: "Add search", AuthorId: "u1"})
+				return err
+			},
+			want: codes.NotFound,
+		},
+		{
+			name: "team exists",
+			err:  models.ErrTeamExists,
+			call: func(c clients) error {
+				_, err := c.teams.CreateTeam(ctx, &prreviewersv1.CreateTeamRequest{TeamName: "backend", Members: []*prreviewersv1.TeamMember{member}})
+				return err
+			},
+			want: codes.AlreadyExists,
+		},
+		{
+			name: "PR exists",
+			err:  models.ErrPRExists,
+			call: func(c clients) error {
+				_, err := c.prs.CreatePullRequest(ctx, &prreviewersv1.CreatePullRequestRequest{PullRequestId: "pr-1", PullRequestName: "Add search", AuthorId: "u1"})
+				return err
+			},
+			want: codes.AlreadyExists,
+		},
+		{
+			name: "team not empty",
+			err:  models.ErrTeamNotEmpty,
+			call: func(c clients) error {
+				_, err := c.teams.DeleteTeam(ctx, &prreviewersv1.DeleteTeamRequest{TeamName: "backend"})
+				return err
+			},
+			want: codes.FailedPrecondition,
+		},
+		{
+			name: "PR merged",
+			err:  models.ErrPRMerged,
+			call: func(c clients) error {
+				_, err := c.prs.ReassignReviewer(ctx, &prreviewersv1.ReassignReviewerRequest{PullRequestId: "pr-1", OldReviewerId: "u2"})
+				return err
+			},
+			want: codes.FailedPrecondition,
+		},
+		{
+			name: "not assigned",
+			err:  models.ErrNotAssigned,
+			call: func(c clients) error {
+				_, err := c.prs.ReassignReviewer(ctx, &prreviewersv1.ReassignReviewerRequest{PullRequestId: "pr-1", OldReviewerId: "u2"})
+				return err
+			},
+			want: codes.FailedPrecondition,
+		},
+		{
+			name: "no candidate",
+			err:  models.ErrNoCandidate,
+			call: func(c clients) error {
+				_, err := c.prs.ReassignReviewer(ctx, &prreviewersv1.ReassignReviewerRequest{PullRequestId: "pr-1", OldReviewerId: "u2"})
+				return err
+			},
+			want: codes.FailedPrecondition,
+		},
+		{
+			name: "unexpected",
+			err:  errors.New("connection refused"),
+			call: func(c clients) error {
+				_, err := c.users.GetUser(ctx, &prreviewersv1.GetUserRequest{UserId: "u2"})
+				return err
+			},
+			want: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call(dial(t, tt.err))
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("code = %s, want %s (error %v)", got, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestMemberConflictDetails(t *testing.T) {
+	c := dial(t, &models.MemberConflictError{Conflicts: []models.MemberConflict{
+		{UserID: "u1", TeamName: "frontend"},
+	}})
+
+	_, err := c.teams.AddMembers(context.Background(), &prreviewersv1.AddMembersRequest{
+		TeamName: "backend",
+		Members:  []*prreviewersv1.TeamMember{member},
+	})
+
+	st := status.Convert(err)
+	if st.Code() != codes.FailedPrecondition {
+		t.Fatalf("code = %s, want %s", st.Code(), codes.FailedPrecondition)
+	}
+
+	for _, detail := range st.Details() {
+		if failure, ok := detail.(*errdetails.PreconditionFailure); ok {
+			violation := failure.GetViolations()[0]
+			if violation.GetType() != "MEMBER_CONFLICT" || violation.GetSubject() != "u1" {
+				t.Errorf("violation = %v, want MEMBER_CONFLICT for u1", violation)
+			}
+			return
+		}
+	}
+
+	t.Errorf("details = %v, want a PreconditionFailure", st.Details())
+}
+
+func TestValidation(t *testing.T) {
+	c := dial(t, nil)
+
+	_, err := c.prs.CreatePullRequest(context.Background(), &prreviewersv1.CreatePullRequestRequest{
+		PullRequestId:   "pr 1",
+		PullRequestName: "Add search",
+	})
+
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("code = %s, want %s", st.Code(), codes.InvalidArgument)
+	}
+
+	var fields []string
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.GetFieldViolations() {
+				fields = append(fields, violation.GetField())
+			}
+		}
+	}
+
+	if len(fields) != 2 || fields[0] != "pull_request_id" || fields[1] != "author_id" {
+		t.Errorf("violations = %v, want pull_request_id and author_id", fields)
+	}
+}
+
+func TestValidationRejectsRepositoryPRID(t *testing.T) {
+	c := dial(t, nil)
+
+	_, err := c.prs.CreatePullRequest(context.Background(), &prreviewersv1.CreatePullRequestRequest{
+		PullRequestId:   "backend-api:1",
+		PullRequestName: "Add search",
+		AuthorId:        "u1",
+	})
+
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("code = %s, want %s", st.Code(), codes.InvalidArgument)
+	}
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			violations = append(violations, badRequest.GetFieldViolations()...)
+		}
+	}
+
+	if len(violations) != 1 || violations[0].GetField() != "pull_request_id" || violations[0].GetDescription() != "must not contain ':'" {
+		t.Errorf("violations = %v, want pull_request_id must not contain ':'", violations)
+	}
+}
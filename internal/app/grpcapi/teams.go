@@ -0,0 +1,205 @@
+package grpcapi
+
+import (
+	"context"
+
+	prreviewersv1 "github.com/IlyaAGL/avito_autumn_2025/api/proto/prreviewers/v1"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/common"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/teams"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/users"
+)
+
+type teamServer struct {
+	prreviewersv1.UnimplementedTeamServiceServer
+	teamService TeamService
+}
+
+func (s *teamServer) CreateTeam(ctx context.Context, req *prreviewersv1.CreateTeamRequest) (*prreviewersv1.Team, error) {
+	createReq := teams.CreateRequest{
+		TeamName:  req.GetTeamName(),
+		Members:   toMemberCreates(req.GetMembers()),
+		Upsert:    req.GetUpsert(),
+		AllowMove: req.GetAllowMove(),
+	}
+	if err := validate(createReq); err != nil {
+		return nil, err
+	}
+
+	response, err := s.teamService.CreateTeam(ctx, createReq)
+	if err != nil {
+		return nil, toStatus(ctx, err, "create team", "Team not found")
+	}
+
+	return toTeam(response.Team), nil
+}
+
+func (s *teamServer) GetTeam(ctx context.Context, req *prreviewersv1.GetTeamRequest) (*prreviewersv1.Team, error) {
+	if err := validate(teams.GetParams{TeamName: req.GetTeamName()}); err != nil {
+		return nil, err
+	}
+
+	response, err := s.teamService.GetTeam(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, toStatus(ctx, err, "get team", "Team not found")
+	}
+
+	return toTeam(*response), nil
+}
+
+func (s *teamServer) ListTeams(ctx context.Context, _ *prreviewersv1.ListTeamsRequest) (*prreviewersv1.ListTeamsResponse, error) {
+	response, err := s.teamService.ListTeams(ctx)
+	if err != nil {
+		return nil, toStatus(ctx, err, "list teams", "Team not found")
+	}
+
+	summaries := make([]*prreviewersv1.TeamSummary, len(response.Teams))
+	for i, summary := range response.Teams {
+		summaries[i] = &prreviewersv1.TeamSummary{
+			TeamName:      summary.TeamName,
+			Members:       int32(summary.Members),
+			ActiveMembers: int32(summary.ActiveMembers),
+		}
+	}
+
+	return &prreviewersv1.ListTeamsResponse{Teams: summaries}, nil
+}
+
+func (s *teamServer) DeactivateTeam(ctx context.Context, req *prreviewersv1.DeactivateTeamRequest) (*prreviewersv1.DeactivateTeamResponse, error) {
+	if err := validate(teams.BulkDeactivateRequest{TeamName: req.GetTeamName()}); err != nil {
+		return nil, err
+	}
+
+	if err := s.teamService.BulkDeactivateUsers(ctx, req.GetTeamName()); err != nil {
+		return nil, toStatus(ctx, err, "deactivate team", "Team not found")
+	}
+
+	return &prreviewersv1.DeactivateTeamResponse{TeamName: req.GetTeamName()}, nil
+}
+
+func (s *teamServer) AddMembers(ctx context.Context, req *prreviewersv1.AddMembersRequest) (*prreviewersv1.Team, error) {
+	addReq := teams.AddMembersRequest{
+		TeamName: req.GetTeamName(),
+		Members:  toMemberCreates(req.GetMembers()),
+	}
+	if err := validate(addReq); err != nil {
+		return nil, err
+	}
+
+	response, err := s.teamService.AddMembers(ctx, addReq)
+	if err != nil {
+		return nil, toStatus(ctx, err, "add team members", "Team not found")
+	}
+
+	return toTeam(*response), nil
+}
+
+func (s *teamServer) RemoveMembers(ctx context.Context, req *prreviewersv1.RemoveMembersRequest) (*prreviewersv1.RemoveMembersResponse, error) {
+	removeReq := teams.RemoveMembersRequest{
+		TeamName: req.GetTeamName(),
+		UserIDs:  req.GetUserIds(),
+	}
+	if err := validate(removeReq); err != nil {
+		return nil, err
+	}
+
+	response, err := s.teamService.RemoveMembers(ctx, removeReq)
+	if err != nil {
+		return nil, toStatus(ctx, err, "remove team members", "Team or member not found")
+	}
+
+	return &prreviewersv1.RemoveMembersResponse{
+		Team:          toTeam(response.Team),
+		Reassignments: toReviewerChanges(response.Reassignments),
+	}, nil
+}
+
+func (s *teamServer) MoveUser(ctx context.Context, req *prreviewersv1.MoveUserRequest) (*prreviewersv1.MoveUserResponse, error) {
+	moveReq := users.MoveRequest{
+		UserID:   req.GetUserId(),
+		TeamName: req.GetTeamName(),
+	}
+	if err := validate(moveReq); err != nil {
+		return nil, err
+	}
+
+	response, err := s.teamService.MoveUser(ctx, moveReq)
+	if err != nil {
+		return nil, toStatus(ctx, err, "move user", "User or team not found")
+	}
+
+	return &prreviewersv1.MoveUserResponse{
+		User:          toUser(response.User),
+		Reassignments: toReviewerChanges(response.Reassignments),
+	}, nil
+}
+
+func (s *teamServer) RenameTeam(ctx context.Context, req *prreviewersv1.RenameTeamRequest) (*prreviewersv1.Team, error) {
+	renameReq := teams.RenameRequest{
+		TeamName:    req.GetTeamName(),
+		NewTeamName: req.GetNewTeamName(),
+	}
+	if err := validate(renameReq); err != nil {
+		return nil, err
+	}
+
+	response, err := s.teamService.RenameTeam(ctx, renameReq)
+	if err != nil {
+		return nil, toStatus(ctx, err, "rename team", "Team not found")
+	}
+
+	return toTeam(*response), nil
+}
+
+func (s *teamServer) DeleteTeam(ctx context.Context, req *prreviewersv1.DeleteTeamRequest) (*prreviewersv1.DeleteTeamResponse, error) {
+	if err := validate(teams.DeleteRequest{TeamName: req.GetTeamName()}); err != nil {
+		return nil, err
+	}
+
+	if err := s.teamService.DeleteTeam(ctx, req.GetTeamName()); err != nil {
+		return nil, toStatus(ctx, err, "delete team", "Team not found")
+	}
+
+	return &prreviewersv1.DeleteTeamResponse{TeamName: req.GetTeamName()}, nil
+}
+
+func toMemberCreates(members []*prreviewersv1.TeamMember) []teams.MemberCreate {
+	result := make([]teams.MemberCreate, len(members))
+	for i, member := range members {
+		result[i] = teams.MemberCreate{
+			UserID:   member.GetUserId(),
+			Username: member.GetUsername(),
+			IsActive: member.GetIsActive(),
+		}
+	}
+
+	return result
+}
+
+func toReviewerChanges(changes []common.ReviewerChange) []*prreviewersv1.ReviewerChange {
+	result := make([]*prreviewersv1.ReviewerChange, len(changes))
+	for i, change := range changes {
+		result[i] = &prreviewersv1.ReviewerChange{
+			PullRequestId: change.PullRequestID,
+			OldReviewerId: change.OldReviewerID,
+			NewReviewerId: change.NewReviewerID,
+		}
+	}
+
+	return result
+}
+
+func toTeam(team teams.TeamResponse) *prreviewersv1.Team {
+	members := make([]*prreviewersv1.TeamMember, len(team.Members))
+	for i, member := range team.Members {
+		members[i] = &prreviewersv1.TeamMember{
+			UserId:   member.UserID,
+			Username: member.Username,
+			IsActive: member.IsActive,
+		}
+	}
+
+	return &prreviewersv1.Team{
+		TeamName: team.TeamName,
+		Members:  members,
+	}
+}
@@ -0,0 +1,78 @@
+package grpcapi
+
+import (
+	"context"
+
+	prreviewersv1 "github.com/IlyaAGL/avito_autumn_2025/api/proto/prreviewers/v1"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/users"
+)
+
+type userServer struct {
+	prreviewersv1.UnimplementedUserServiceServer
+	userService UserService
+}
+
+func (s *userServer) GetUser(ctx context.Context, req *prreviewersv1.GetUserRequest) (*prreviewersv1.User, error) {
+	if err := validate(users.PathParams{UserID: req.GetUserId()}); err != nil {
+		return nil, err
+	}
+
+	response, err := s.userService.GetUser(ctx, req.GetUserId())
+	if err != nil {
+		return nil, toStatus(ctx, err, "get user", "User not found")
+	}
+
+	return toUser(*response), nil
+}
+
+func (s *userServer) SetUserActive(ctx context.Context, req *prreviewersv1.SetUserActiveRequest) (*prreviewersv1.User, error) {
+	setReq := users.SetActiveRequest{
+		UserID:   req.GetUserId(),
+		IsActive: req.GetIsActive(),
+	}
+	if err := validate(setReq); err != nil {
+		return nil, err
+	}
+
+	response, err := s.userService.SetUserActive(ctx, setReq)
+	if err != nil {
+		return nil, toStatus(ctx, err, "set user active", "User not found")
+	}
+
+	return toUser(response.User), nil
+}
+
+func (s *userServer) GetUserReviews(ctx context.Context, req *prreviewersv1.GetUserReviewsRequest) (*prreviewersv1.GetUserReviewsResponse, error) {
+	if err := validate(users.ReviewParams{UserID: req.GetUserId()}); err != nil {
+		return nil, err
+	}
+
+	response, err := s.userService.GetUserReviewPRs(ctx, req.GetUserId())
+	if err != nil {
+		return nil, toStatus(ctx, err, "get user reviews", "User not found")
+	}
+
+	prs := make([]*prreviewersv1.PullRequestShort, len(response.PullRequests))
+	for i, pr := range response.PullRequests {
+		prs[i] = &prreviewersv1.PullRequestShort{
+			PullRequestId:   pr.PullRequestID,
+			PullRequestName: pr.PullRequestName,
+			AuthorId:        pr.AuthorID,
+			Status:          pr.Status,
+		}
+	}
+
+	return &prreviewersv1.GetUserReviewsResponse{
+		UserId:       response.UserID,
+		PullRequests: prs,
+	}, nil
+}
+
+func toUser(user users.UserResponse) *prreviewersv1.User {
+	return &prreviewersv1.User{
+		UserId:   user.UserID,
+		Username: user.Username,
+		TeamName: user.TeamName,
+		IsActive: user.IsActive,
+	}
+}
@@ -1,10 +1,18 @@
 package handler
 
 import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
 
+	"github.com/IlyaAGL/avito_autumn_2025/internal/app/validation"
 	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/common"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/models"
+	"github.com/IlyaAGL/avito_autumn_2025/pkg/logger"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
 type BaseHandler struct{}
@@ -18,10 +26,16 @@ func (h *BaseHandler) Created(c *gin.Context, data any) {
 }
 
 func (h *BaseHandler) Error(c *gin.Context, code int, errorCode, message string) {
+	h.ErrorWithDetails(c, code, errorCode, message, nil)
+}
+
+func (h *BaseHandler) ErrorWithDetails(c *gin.Context, code int, errorCode, message string, details []common.FieldError) {
 	c.JSON(code, common.ErrorResponse{
 		Error: common.ErrorDetail{
-			Code:    errorCode,
-			Message: message,
+			Code:      errorCode,
+			Message:   message,
+			RequestID: logger.RequestID(c.Request.Context()),
+			Details:   details,
 		},
 	})
 }
@@ -38,6 +52,80 @@ func (h *BaseHandler) Conflict(c *gin.Context, errorCode, message string) {
 	h.Error(c, http.StatusConflict, errorCode, message)
 }
 
+func (h *BaseHandler) NoContent(c *gin.Context) {
+	c.Status(http.StatusNoContent)
+}
+
+// CSV sends records as a downloadable CSV file.
+func (h *BaseHandler) CSV(c *gin.Context, filename string, records [][]string) {
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+	c.Writer.Header().Set("Content-Type", "text/csv; charset=utf-8")
+
+	w := csv.NewWriter(c.Writer)
+	if err := w.WriteAll(records); err != nil {
+		slog.WarnContext(c.Request.Context(), "write csv failed", "error", err)
+	}
+}
+
 func (h *BaseHandler) InternalError(c *gin.Context, message string) {
 	h.Error(c, http.StatusInternalServerError, "INTERNAL_ERROR", message)
 }
+
+// NotFoundOrInternal responds 404 NOT_FOUND when err wraps models.ErrNotFound
+// and 500 INTERNAL_ERROR otherwise.
+func (h *BaseHandler) NotFoundOrInternal(c *gin.Context, err error, notFoundMessage, internalMessage string) {
+	if errors.Is(err, models.ErrNotFound) {
+		h.NotFound(c, "NOT_FOUND", notFoundMessage)
+		return
+	}
+
+	h.InternalError(c, internalMessage)
+}
+
+// BindJSON decodes and validates the request body, responding with
+// 400 INVALID_REQUEST and per-field details when it is invalid.
+func (h *BaseHandler) BindJSON(c *gin.Context, obj any) bool {
+	if err := c.ShouldBindJSON(obj); err != nil {
+		h.ValidationError(c, err)
+		return false
+	}
+
+	return true
+}
+
+// BindQuery is BindJSON for query parameters.
+func (h *BaseHandler) BindQuery(c *gin.Context, obj any) bool {
+	if err := c.ShouldBindQuery(obj); err != nil {
+		h.ValidationError(c, err)
+		return false
+	}
+
+	return true
+}
+
+// BindURI is BindJSON for path parameters.
+func (h *BaseHandler) BindURI(c *gin.Context, obj any) bool {
+	if err := c.ShouldBindUri(obj); err != nil {
+		h.ValidationError(c, err)
+		return false
+	}
+
+	return true
+}
+
+func (h *BaseHandler) ValidationError(c *gin.Context, err error) {
+	c.JSON(http.StatusBadRequest, common.ErrorResponse{
+		Error: common.ErrorDetail{
+			Code:      "INVALID_REQUEST",
+			Message:   "Invalid request",
+			RequestID: logger.RequestID(c.Request.Context()),
+			Details:   validation.Details(err),
+		},
+	})
+}
+
+// validate checks a DTO assembled by the handler rather than bound by gin.
+func validate(obj any) error {
+	return binding.Validator.ValidateStruct(obj)
+}
@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/codeowners"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/common"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	maxCodeOwnersRules   = 5000
+	maxCodeOwnersPattern = 1024
+	maxCodeOwnersOwner   = 255
+)
+
+type CodeOwnersService interface {
+	SetRules(ctx context.Context, req codeowners.SetRequest) (*codeowners.SetResponse, error)
+	GetRules(ctx context.Context, repository string) (*codeowners.RulesResponse, error)
+}
+
+type codeOwnersHandler struct {
+	BaseHandler
+	codeOwnersService CodeOwnersService
+}
+
+func NewCodeOwnersHandler(codeOwnersService CodeOwnersService) *codeOwnersHandler {
+	return &codeOwnersHandler{
+		codeOwnersService: codeOwnersService,
+	}
+}
+
+// UploadCodeOwners serves POST /codeOwners/upload. The body is a CODEOWNERS
+// file that replaces the rules of the repository.
+func (h *codeOwnersHandler) UploadCodeOwners(c *gin.Context) {
+	var params codeowners.Params
+	if !h.BindQuery(c, &params) {
+		return
+	}
+
+	h.setRules(c, params.Repository)
+}
+
+func (h *codeOwnersHandler) GetCodeOwners(c *gin.Context) {
+	var params codeowners.Params
+	if !h.BindQuery(c, &params) {
+		return
+	}
+
+	h.getRules(c, params.Repository)
+}
+
+func (h *codeOwnersHandler) SetCodeOwnersV2(c *gin.Context) {
+	var params codeowners.PathParams
+	if !h.BindURI(c, &params) {
+		return
+	}
+
+	h.setRules(c, params.Repository)
+}
+
+func (h *codeOwnersHandler) GetCodeOwnersV2(c *gin.Context) {
+	var params codeowners.PathParams
+	if !h.BindURI(c, &params) {
+		return
+	}
+
+	h.getRules(c, params.Repository)
+}
+
+func (h *codeOwnersHandler) setRules(c *gin.Context, repository string) {
+	rules, lineErrors, err := readCodeOwners(http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize))
+	if err != nil {
+		h.ErrorWithDetails(c, http.StatusBadRequest, "INVALID_REQUEST", "Invalid CODEOWNERS file", []common.FieldError{
+			{Field: "body", Reason: err.Error()},
+		})
+		return
+	}
+
+	if len(lineErrors) > 0 {
+		h.ErrorWithDetails(c, http.StatusBadRequest, "INVALID_REQUEST", "Invalid CODEOWNERS file, nothing was applied", lineErrors)
+		return
+	}
+
+	response, err := h.codeOwnersService.SetRules(c.Request.Context(), codeowners.SetRequest{
+		Repository: repository,
+		Rules:      rules,
+	})
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "set code owners failed", "error", err)
+
+		switch {
+		case errors.Is(err, models.ErrInvalidValue):
+			h.ErrorWithDetails(c, http.StatusBadRequest, "INVALID_REQUEST", "Invalid CODEOWNERS file", []common.FieldError{
+				{Field: "body", Reason: err.Error()},
+			})
+		case errors.Is(err, models.ErrNotFound):
+			h.NotFound(c, "NOT_FOUND", "Repository not found")
+		default:
+			h.InternalError(c, "Failed to set code owners")
+		}
+		return
+	}
+
+	h.Success(c, response)
+}
+
+func (h *codeOwnersHandler) getRules(c *gin.Context, repository string) {
+	response, err := h.codeOwnersService.GetRules(c.Request.Context(), repository)
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "get code owners failed", "error", err)
+
+		if errors.Is(err, models.ErrNotFound) {
+			h.NotFound(c, "NOT_FOUND", "Repository not found")
+			return
+		}
+
+		h.InternalError(c, "Failed to get code owners")
+		return
+	}
+
+	h.Success(c, response)
+}
+
+// readCodeOwners parses a CODEOWNERS file: one "pattern owner..." rule per
+// line, with "#" starting a comment. Lines the service cannot use are
+// returned as details keyed by line number, e.g. "lines[3]".
+func readCodeOwners(body io.Reader) ([]codeowners.Rule, []common.FieldError, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(nil, 64<<10)
+
+	rules := []codeowners.Rule{}
+	lineErrors := []common.FieldError{}
+
+	line := 0
+	for scanner.Scan() {
+		line++
+
+		fields := strings.Fields(scanner.Text())
+		for i, field := range fields {
+			if strings.HasPrefix(field, "#") {
+				fields = fields[:i]
+				break
+			}
+		}
+
+		if len(fields) == 0 {
+			continue
+		}
+
+		if reason := codeOwnersLineError(fields); reason != "" {
+			lineErrors = append(lineErrors, common.FieldError{
+				Field:  fmt.Sprintf("lines[%d]", line),
+				Reason: reason,
+			})
+			continue
+		}
+
+		rules = append(rules, codeowners.Rule{
+			Line:    line,
+			Pattern: fields[0],
+			Owners:  fields[1:],
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	if len(rules) > maxCodeOwnersRules {
+		return nil, nil, fmt.Errorf("has %d rules, at most %d are allowed", len(rules), maxCodeOwnersRules)
+	}
+
+	return rules, lineErrors, nil
+}
+
+func codeOwnersLineError(fields []string) string {
+	pattern := fields[0]
+
+	switch {
+	case strings.HasPrefix(pattern, "!"):
+		return "negated patterns are not supported"
+	case strings.ContainsAny(pattern, "[]\\"):
+		return "character ranges and escapes are not supported"
+	case len(pattern) > maxCodeOwnersPattern:
+		return fmt.Sprintf("pattern must be at most %d characters", maxCodeOwnersPattern)
+	}
+
+	for _, owner := range fields[1:] {
+		if len(owner) > maxCodeOwnersOwner {
+			return fmt.Sprintf("owner must be at most %d characters", maxCodeOwnersOwner)
+		}
+	}
+
+	return ""
+}
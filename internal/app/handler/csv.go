@@ -0,0 +1,283 @@
+package handler
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/IlyaAGL/avito_autumn_2025/internal/app/validation"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/common"
+	pullrequests "github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/prs"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/teams"
+	"github.com/gin-gonic/gin"
+)
+
+const maxImportSize = 10 << 20
+
+// rosterColumns is the layout of team import and export files. is_active may
+// be left out of an import file, in which case every user is active.
+var rosterColumns = []string{"team_name", "user_id", "username", "is_active"}
+
+// ImportTeams serves POST /team/import. The body is a CSV file; rows are
+// checked one by one and every rejected row is reported with its line.
+func (h *teamHandler) ImportTeams(c *gin.Context) {
+	var params teams.ImportParams
+	if !h.BindQuery(c, &params) {
+		return
+	}
+
+	if params.Mode == "" {
+		params.Mode = teams.ImportAtomic
+	}
+
+	rows, rowErrors, err := readImportRows(http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize))
+	if err != nil {
+		h.ErrorWithDetails(c, http.StatusBadRequest, "INVALID_REQUEST", "Invalid CSV file", []common.FieldError{
+			{Field: "body", Reason: err.Error()},
+		})
+		return
+	}
+
+	if params.Mode == teams.ImportAtomic && len(rowErrors) > 0 {
+		h.importRejected(c, rowErrors)
+		return
+	}
+
+	response, err := h.teamService.ImportTeams(c.Request.Context(), teams.ImportRequest{
+		Rows:      rows,
+		Mode:      params.Mode,
+		AllowMove: params.AllowMove,
+	})
+	if err != nil {
+		h.membershipError(c, err, "Team not found", nil)
+		return
+	}
+
+	response.Skipped = append(rowErrors, response.Skipped...)
+	slices.SortStableFunc(response.Skipped, func(a, b teams.ImportError) int {
+		return a.Line - b.Line
+	})
+
+	if params.Mode == teams.ImportAtomic && len(response.Skipped) > 0 {
+		h.importRejected(c, response.Skipped)
+		return
+	}
+
+	h.Success(c, response)
+}
+
+// importRejected reports the rows that made an atomic import fail. Details
+// are keyed by line number, e.g. "lines[3].user_id".
+func (h *teamHandler) importRejected(c *gin.Context, rowErrors []teams.ImportError) {
+	details := make([]common.FieldError, len(rowErrors))
+	for i, rowErr := range rowErrors {
+		field := fmt.Sprintf("lines[%d]", rowErr.Line)
+		if rowErr.Field != "" {
+			field += "." + rowErr.Field
+		}
+
+		details[i] = common.FieldError{Field: field, Reason: rowErr.Reason}
+	}
+
+	h.ErrorWithDetails(c, http.StatusBadRequest, "INVALID_REQUEST", "Import rejected, nothing was applied", details)
+}
+
+func (h *teamHandler) ExportTeams(c *gin.Context) {
+	var params common.ExportParams
+	if !h.BindQuery(c, &params) {
+		return
+	}
+
+	response, err := h.teamService.ExportTeams(c.Request.Context())
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "export teams failed", "error", err)
+
+		h.InternalError(c, "Failed to export teams")
+		return
+	}
+
+	if params.Format == common.FormatJSON {
+		h.Success(c, response)
+		return
+	}
+
+	records := [][]string{rosterColumns}
+	for _, team := range response.Teams {
+		for _, member := range team.Members {
+			records = append(records, []string{
+				team.TeamName, member.UserID, member.Username, strconv.FormatBool(member.IsActive),
+			})
+		}
+	}
+
+	h.CSV(c, "teams.csv", records)
+}
+
+func (h *userHandler) ExportUsers(c *gin.Context) {
+	var params common.ExportParams
+	if !h.BindQuery(c, &params) {
+		return
+	}
+
+	response, err := h.userService.ExportUsers(c.Request.Context())
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "export users failed", "error", err)
+
+		h.InternalError(c, "Failed to export users")
+		return
+	}
+
+	if params.Format == common.FormatJSON {
+		h.Success(c, response)
+		return
+	}
+
+	records := [][]string{{"user_id", "username", "team_name", "is_active"}}
+	for _, user := range response.Users {
+		records = append(records, []string{
+			user.UserID, user.Username, user.TeamName, strconv.FormatBool(user.IsActive),
+		})
+	}
+
+	h.CSV(c, "users.csv", records)
+}
+
+func (h *pullRequestHandler) ExportPRs(c *gin.Context) {
+	var params common.ExportParams
+	if !h.BindQuery(c, &params) {
+		return
+	}
+
+	response, err := h.prService.ListPRs(c.Request.Context(), pullrequests.ListParams{})
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "export pull requests failed", "error", err)
+
+		h.InternalError(c, "Failed to export pull requests")
+		return
+	}
+
+	if params.Format == common.FormatJSON {
+		h.Success(c, response)
+		return
+	}
+
+	records := [][]string{{"pull_request_id", "pull_request_name", "author_id", "status", "assigned_reviewers", "merged_at"}}
+	for _, pr := range response.PullRequests {
+		var mergedAt string
+		if pr.MergedAt != nil {
+			mergedAt = *pr.MergedAt
+		}
+
+		records = append(records, []string{
+			pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status,
+			strings.Join(pr.AssignedReviewers, ";"), mergedAt,
+		})
+	}
+
+	h.CSV(c, "pull_requests.csv", records)
+}
+
+// readImportRows parses an import file. Rows with invalid fields are
+// returned as row errors; only a missing or malformed header, or a file that
+// is not CSV at all, fails the whole file.
+func readImportRows(body io.Reader) ([]teams.ImportRow, []teams.ImportError, error) {
+	r := csv.NewReader(body)
+
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, errors.New("file is empty")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Spreadsheet exports often start with a UTF-8 byte order mark.
+	header[0] = strings.TrimPrefix(header[0], "\uFEFF")
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if !slices.Contains(rosterColumns, name) {
+			return nil, nil, fmt.Errorf("unknown column %q, want %s", name, strings.Join(rosterColumns, ","))
+		}
+
+		if _, ok := columns[name]; ok {
+			return nil, nil, fmt.Errorf("duplicate column %q", name)
+		}
+
+		columns[name] = i
+	}
+
+	for _, name := range rosterColumns[:3] {
+		if _, ok := columns[name]; !ok {
+			return nil, nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+
+	rows := []teams.ImportRow{}
+	rowErrors := []teams.ImportError{}
+
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) && errors.Is(err, csv.ErrFieldCount) {
+			rowErrors = append(rowErrors, teams.ImportError{
+				Line:   parseErr.StartLine,
+				Reason: fmt.Sprintf("has %d fields, want %d", len(record), len(header)),
+			})
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		line, _ := r.FieldPos(0)
+		row, errs := parseImportRow(line, record, columns)
+		if len(errs) > 0 {
+			rowErrors = append(rowErrors, errs...)
+			continue
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, rowErrors, nil
+}
+
+func parseImportRow(line int, record []string, columns map[string]int) (teams.ImportRow, []teams.ImportError) {
+	row := teams.ImportRow{
+		Line:     line,
+		TeamName: record[columns["team_name"]],
+		UserID:   record[columns["user_id"]],
+		Username: record[columns["username"]],
+		IsActive: true,
+	}
+
+	var rowErrors []teams.ImportError
+
+	if i, ok := columns["is_active"]; ok && record[i] != "" {
+		isActive, err := strconv.ParseBool(record[i])
+		if err != nil {
+			rowErrors = append(rowErrors, teams.ImportError{Line: line, Field: "is_active", Reason: "must be true or false"})
+		}
+
+		row.IsActive = isActive
+	}
+
+	if err := validate(row); err != nil {
+		for _, detail := range validation.Details(err) {
+			rowErrors = append(rowErrors, teams.ImportError{Line: line, Field: detail.Field, Reason: detail.Reason})
+		}
+	}
+
+	return row, rowErrors
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/health"
+	"github.com/gin-gonic/gin"
+)
+
+type HealthService interface {
+	Liveness() *health.LivenessResponse
+	Readiness(ctx context.Context) (*health.ReadinessResponse, bool)
+}
+
+type healthHandler struct {
+	BaseHandler
+	healthService HealthService
+}
+
+func NewHealthHandler(healthService HealthService) *healthHandler {
+	return &healthHandler{
+		healthService: healthService,
+	}
+}
+
+func (h *healthHandler) Liveness(c *gin.Context) {
+	h.Success(c, h.healthService.Liveness())
+}
+
+func (h *healthHandler) Readiness(c *gin.Context) {
+	response, ready := h.healthService.Readiness(c.Request.Context())
+	if !ready {
+		c.JSON(http.StatusServiceUnavailable, response)
+		return
+	}
+
+	h.Success(c, response)
+}
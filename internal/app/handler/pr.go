@@ -2,9 +2,12 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"log/slog"
 
 	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/common"
 	pullrequests "github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/prs"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/models"
 	"github.com/gin-gonic/gin"
 )
 
@@ -13,6 +16,8 @@ type PullRequestService interface {
 	MergePR(ctx context.Context, req pullrequests.MergeRequest) (*pullrequests.MergeResponse, error)
 	ReassignReviewer(ctx context.Context, req pullrequests.ReassignRequest) (*pullrequests.ReassignResponse, error)
 	GetPR(ctx context.Context, prID string) (*pullrequests.PullRequestResponse, error)
+	GetPRByNumber(ctx context.Context, repository string, number int) (*pullrequests.PullRequestResponse, error)
+	ListPRs(ctx context.Context, params pullrequests.ListParams) (*pullrequests.ListResponse, error)
 	GetStats(ctx context.Context) (*common.StatsResponse, error)
 }
 
@@ -29,92 +34,123 @@ func NewpullRequestHandler(prService PullRequestService) *pullRequestHandler {
 
 func (h *pullRequestHandler) CreatePR(c *gin.Context) {
 	var req pullrequests.CreateRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		h.BadRequest(c, "INVALID_REQUEST", "Invalid request body")
+	if !h.BindJSON(c, &req) {
 		return
 	}
 
-	if req.PullRequestID == "" {
-		h.BadRequest(c, "INVALID_REQUEST", "PullRequestID is required")
+	response, err := h.prService.CreatePR(c.Request.Context(), req)
+	if err != nil {
+		h.createError(c, err)
 		return
 	}
 
-	if req.PullRequestName == "" {
-		h.BadRequest(c, "INVALID_REQUEST", "PullRequestName is required")
-		return
+	h.Created(c, response)
+}
+
+func (h *pullRequestHandler) createError(c *gin.Context, err error) {
+	slog.WarnContext(c.Request.Context(), "create pull request failed", "error", err)
+
+	switch {
+	case errors.Is(err, models.ErrNotFound):
+		h.NotFound(c, "NOT_FOUND", "Author or repository not found")
+	case errors.Is(err, models.ErrPRExists):
+		h.Conflict(c, "PR_EXISTS", "PR already exists")
+	default:
+		h.InternalError(c, "Failed to create pull request")
 	}
+}
 
-	if req.AuthorID == "" {
-		h.BadRequest(c, "INVALID_REQUEST", "AuthorID is required")
+func (h *pullRequestHandler) MergePR(c *gin.Context) {
+	var req pullrequests.MergeRequest
+	if !h.BindJSON(c, &req) {
 		return
 	}
 
-	response, err := h.prService.CreatePR(c.Request.Context(), req)
+	response, err := h.prService.MergePR(c.Request.Context(), req)
 	if err != nil {
-		switch {
-		case err.Error() == "author not found":
-			h.NotFound(c, "NOT_FOUND", "Author not found")
-		case err.Error() == "team not found":
-			h.NotFound(c, "NOT_FOUND", "Team not found")
-		default:
-			h.Conflict(c, "PR_EXISTS", "PR already exists")
-		}
+		slog.WarnContext(c.Request.Context(), "merge pull request failed", "error", err)
+
+		h.NotFoundOrInternal(c, err, "PR not found", "Failed to merge pull request")
 		return
 	}
 
-	h.Created(c, response)
+	h.Success(c, response)
 }
 
-func (h *pullRequestHandler) MergePR(c *gin.Context) {
-	var req pullrequests.MergeRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		h.BadRequest(c, "INVALID_REQUEST", "Invalid request body")
-		return
-	}
-
-	if req.PullRequestID == "" {
-		h.BadRequest(c, "INVALID_REQUEST", "PullRequestID is required")
+func (h *pullRequestHandler) ReassignReviewer(c *gin.Context) {
+	var req pullrequests.ReassignRequest
+	if !h.BindJSON(c, &req) {
 		return
 	}
 
-	response, err := h.prService.MergePR(c.Request.Context(), req)
+	response, err := h.prService.ReassignReviewer(c.Request.Context(), req)
 	if err != nil {
-		h.NotFound(c, "NOT_FOUND", "PR not found")
+		h.reassignError(c, err)
 		return
 	}
 
 	h.Success(c, response)
 }
 
-func (h *pullRequestHandler) ReassignReviewer(c *gin.Context) {
-	var req pullrequests.ReassignRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		h.BadRequest(c, "INVALID_REQUEST", "Invalid request body")
+func (h *pullRequestHandler) reassignError(c *gin.Context, err error) {
+	slog.WarnContext(c.Request.Context(), "reassign reviewer failed", "error", err)
+
+	switch {
+	case errors.Is(err, models.ErrPRMerged):
+		h.Conflict(c, "PR_MERGED", "Cannot reassign on merged PR")
+	case errors.Is(err, models.ErrNotAssigned):
+		h.Conflict(c, "NOT_ASSIGNED", "Reviewer is not assigned to this PR")
+	case errors.Is(err, models.ErrNoCandidate):
+		h.Conflict(c, "NO_CANDIDATE", "No active replacement candidate in team")
+	case errors.Is(err, models.ErrNotFound):
+		h.NotFound(c, "NOT_FOUND", "PR or user not found")
+	default:
+		h.InternalError(c, "Failed to reassign reviewer")
+	}
+}
+
+func (h *pullRequestHandler) GetPR(c *gin.Context) {
+	var params pullrequests.GetParams
+	if !h.BindQuery(c, &params) {
+		return
+	}
+
+	response, err := h.prService.GetPR(c.Request.Context(), params.PullRequestID)
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "get pull request failed", "error", err)
+
+		h.NotFoundOrInternal(c, err, "PR not found", "Failed to get pull request")
 		return
 	}
 
-	if req.PullRequestID == "" {
-		h.BadRequest(c, "INVALID_REQUEST", "PullRequestID is required")
+	h.Success(c, pullrequests.GetResponse{PR: *response})
+}
+
+func (h *pullRequestHandler) ListPRs(c *gin.Context) {
+	var params pullrequests.ListParams
+	if !h.BindQuery(c, &params) {
 		return
 	}
 
-	if req.OldUserID == "" {
-		h.BadRequest(c, "INVALID_REQUEST", "OldUserID is required")
+	response, err := h.prService.ListPRs(c.Request.Context(), params)
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "list pull requests failed", "error", err)
+
+		h.InternalError(c, "Failed to list pull requests")
 		return
 	}
 
-	response, err := h.prService.ReassignReviewer(c.Request.Context(), req)
+	h.Success(c, response)
+}
+
+// ListUnderstaffed lists open PRs with fewer reviewers than the policy asks
+// for, newest first.
+func (h *pullRequestHandler) ListUnderstaffed(c *gin.Context) {
+	response, err := h.prService.ListPRs(c.Request.Context(), pullrequests.ListParams{Understaffed: true})
 	if err != nil {
-		switch err.Error() {
-		case "cannot reassign on merged PR":
-			h.Conflict(c, "PR_MERGED", "Cannot reassign on merged PR")
-		case "reviewer is not assigned to this PR":
-			h.Conflict(c, "NOT_ASSIGNED", "Reviewer is not assigned to this PR")
-		case "no active replacement candidate in team":
-			h.Conflict(c, "NO_CANDIDATE", "No active replacement candidate in team")
-		default:
-			h.NotFound(c, "NOT_FOUND", "PR or user not found")
-		}
+		slog.WarnContext(c.Request.Context(), "list understaffed pull requests failed", "error", err)
+
+		h.InternalError(c, "Failed to list pull requests")
 		return
 	}
 
@@ -124,6 +160,8 @@ func (h *pullRequestHandler) ReassignReviewer(c *gin.Context) {
 func (h *pullRequestHandler) GetStats(c *gin.Context) {
 	response, err := h.prService.GetStats(c.Request.Context())
 	if err != nil {
+		slog.WarnContext(c.Request.Context(), "get statistics failed", "error", err)
+
 		h.InternalError(c, "Failed to get statistics")
 		return
 	}
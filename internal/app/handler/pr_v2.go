@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"log/slog"
+	"strings"
+
+	pullrequests "github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/prs"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/repositories"
+	"github.com/gin-gonic/gin"
+)
+
+const reassignAction = ":reassign"
+
+func (h *pullRequestHandler) ListPRsV2(c *gin.Context) {
+	var params pullrequests.ListParams
+	if !h.BindQuery(c, &params) {
+		return
+	}
+
+	response, err := h.prService.ListPRs(c.Request.Context(), params)
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "list pull requests failed", "error", err)
+
+		h.InternalError(c, "Failed to list pull requests")
+		return
+	}
+
+	prs := make([]pullrequests.PullRequestV2, len(response.PullRequests))
+	for i, pr := range response.PullRequests {
+		prs[i] = toPullRequestV2(pr)
+	}
+
+	h.Success(c, pullrequests.ListV2Response{PullRequests: prs})
+}
+
+func (h *pullRequestHandler) CreatePRV2(c *gin.Context) {
+	var req pullrequests.CreateRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.prService.CreatePR(c.Request.Context(), req)
+	if err != nil {
+		h.createError(c, err)
+		return
+	}
+
+	h.Created(c, toPullRequestV2(response.PR))
+}
+
+func (h *pullRequestHandler) GetPRV2(c *gin.Context) {
+	var params pullrequests.PathParams
+	if !h.BindURI(c, &params) {
+		return
+	}
+
+	response, err := h.prService.GetPR(c.Request.Context(), params.PullRequestID)
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "get pull request failed", "error", err)
+
+		h.NotFoundOrInternal(c, err, "PR not found", "Failed to get pull request")
+		return
+	}
+
+	h.Success(c, toPullRequestV2(*response))
+}
+
+// GetRepositoryPRV2 serves GET /api/v2/repositories/:repository/pull-requests/:number.
+func (h *pullRequestHandler) GetRepositoryPRV2(c *gin.Context) {
+	var params repositories.PullRequestPathParams
+	if !h.BindURI(c, &params) {
+		return
+	}
+
+	response, err := h.prService.GetPRByNumber(c.Request.Context(), params.Repository, params.Number)
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "get pull request failed", "error", err)
+
+		h.NotFoundOrInternal(c, err, "PR not found", "Failed to get pull request")
+		return
+	}
+
+	h.Success(c, toPullRequestV2(*response))
+}
+
+func (h *pullRequestHandler) MergePRV2(c *gin.Context) {
+	var params pullrequests.PathParams
+	if !h.BindURI(c, &params) {
+		return
+	}
+
+	response, err := h.prService.MergePR(c.Request.Context(), pullrequests.MergeRequest{
+		PullRequestID: params.PullRequestID,
+	})
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "merge pull request failed", "error", err)
+
+		h.NotFoundOrInternal(c, err, "PR not found", "Failed to merge pull request")
+		return
+	}
+
+	h.Success(c, toPullRequestV2(response.PR))
+}
+
+// ReviewerActionV2 serves POST /pull-requests/:id/reviewers/:action. gin
+// cannot route on a suffix inside a path segment, so the "<user_id>:reassign"
+// segment is bound whole and split here.
+func (h *pullRequestHandler) ReviewerActionV2(c *gin.Context) {
+	var params pullrequests.ReassignPathParams
+	if !h.BindURI(c, &params) {
+		return
+	}
+
+	userID, ok := strings.CutSuffix(params.Action, reassignAction)
+	if !ok || userID == "" {
+		h.NotFound(c, "NOT_FOUND", "Unknown reviewer action")
+		return
+	}
+
+	req := pullrequests.ReassignRequest{
+		PullRequestID: params.PullRequestID,
+		OldUserID:     userID,
+	}
+	if err := validate(req); err != nil {
+		h.ValidationError(c, err)
+		return
+	}
+
+	response, err := h.prService.ReassignReviewer(c.Request.Context(), req)
+	if err != nil {
+		h.reassignError(c, err)
+		return
+	}
+
+	h.Success(c, pullrequests.ReassignV2Response{
+		PR:         toPullRequestV2(response.PR),
+		ReplacedBy: response.ReplacedBy,
+	})
+}
+
+func toPullRequestV2(pr pullrequests.PullRequestResponse) pullrequests.PullRequestV2 {
+	return pullrequests.PullRequestV2{
+		PullRequestResponse: pr,
+		MergedAt:            pr.MergedAt,
+	}
+}
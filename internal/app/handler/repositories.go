@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/repositories"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+type RepositoryService interface {
+	CreateRepository(ctx context.Context, req repositories.CreateRequest) (*repositories.RepositoryResponse, error)
+	GetRepository(ctx context.Context, name string) (*repositories.RepositoryResponse, error)
+	ListRepositories(ctx context.Context) (*repositories.ListResponse, error)
+	UpdateRepository(ctx context.Context, req repositories.UpdateRequest) (*repositories.RepositoryResponse, error)
+	DeleteRepository(ctx context.Context, name string) error
+}
+
+type repositoryHandler struct {
+	BaseHandler
+	repositoryService RepositoryService
+}
+
+func NewRepositoryHandler(repositoryService RepositoryService) *repositoryHandler {
+	return &repositoryHandler{
+		repositoryService: repositoryService,
+	}
+}
+
+func (h *repositoryHandler) CreateRepository(c *gin.Context) {
+	var req repositories.CreateRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.repositoryService.CreateRepository(c.Request.Context(), req)
+	if err != nil {
+		h.repositoryError(c, err, "Team not found")
+		return
+	}
+
+	h.Created(c, response)
+}
+
+func (h *repositoryHandler) GetRepository(c *gin.Context) {
+	var params repositories.GetParams
+	if !h.BindQuery(c, &params) {
+		return
+	}
+
+	response, err := h.repositoryService.GetRepository(c.Request.Context(), params.Repository)
+	if err != nil {
+		h.repositoryError(c, err, "Repository not found")
+		return
+	}
+
+	h.Success(c, response)
+}
+
+func (h *repositoryHandler) ListRepositories(c *gin.Context) {
+	response, err := h.repositoryService.ListRepositories(c.Request.Context())
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "list repositories failed", "error", err)
+
+		h.InternalError(c, "Failed to list repositories")
+		return
+	}
+
+	h.Success(c, response)
+}
+
+func (h *repositoryHandler) UpdateRepository(c *gin.Context) {
+	var req repositories.UpdateRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.repositoryService.UpdateRepository(c.Request.Context(), req)
+	if err != nil {
+		h.repositoryError(c, err, "Repository or team not found")
+		return
+	}
+
+	h.Success(c, response)
+}
+
+func (h *repositoryHandler) DeleteRepository(c *gin.Context) {
+	var req repositories.DeleteRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	if err := h.repositoryService.DeleteRepository(c.Request.Context(), req.Repository); err != nil {
+		h.repositoryError(c, err, "Repository not found")
+		return
+	}
+
+	h.Success(c, repositories.DeleteResponse{
+		Message:    "Deleted",
+		Repository: req.Repository,
+	})
+}
+
+func (h *repositoryHandler) repositoryError(c *gin.Context, err error, notFoundMessage string) {
+	slog.WarnContext(c.Request.Context(), "repository request failed", "error", err)
+
+	switch {
+	case errors.Is(err, models.ErrNotFound):
+		h.NotFound(c, "NOT_FOUND", notFoundMessage)
+	case errors.Is(err, models.ErrRepoExists):
+		h.Conflict(c, "REPOSITORY_EXISTS", "Repository already exists")
+	case errors.Is(err, models.ErrRepoNotEmpty):
+		h.Conflict(c, "REPOSITORY_NOT_EMPTY", "Repository still has pull requests")
+	default:
+		h.InternalError(c, "Failed to update repository")
+	}
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/repositories"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *repositoryHandler) GetRepositoryV2(c *gin.Context) {
+	var params repositories.PathParams
+	if !h.BindURI(c, &params) {
+		return
+	}
+
+	response, err := h.repositoryService.GetRepository(c.Request.Context(), params.Repository)
+	if err != nil {
+		h.repositoryError(c, err, "Repository not found")
+		return
+	}
+
+	h.Success(c, response)
+}
+
+func (h *repositoryHandler) UpdateRepositoryV2(c *gin.Context) {
+	var params repositories.PathParams
+	if !h.BindURI(c, &params) {
+		return
+	}
+
+	var req repositories.UpdateV2Request
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.repositoryService.UpdateRepository(c.Request.Context(), repositories.UpdateRequest{
+		Repository: params.Repository,
+		TeamName:   req.TeamName,
+		Policy:     req.Policy,
+	})
+	if err != nil {
+		h.repositoryError(c, err, "Repository or team not found")
+		return
+	}
+
+	h.Success(c, response)
+}
+
+func (h *repositoryHandler) DeleteRepositoryV2(c *gin.Context) {
+	var params repositories.PathParams
+	if !h.BindURI(c, &params) {
+		return
+	}
+
+	if err := h.repositoryService.DeleteRepository(c.Request.Context(), params.Repository); err != nil {
+		h.repositoryError(c, err, "Repository not found")
+		return
+	}
+
+	h.NoContent(c)
+}
@@ -0,0 +1,298 @@
+package handler
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/IlyaAGL/avito_autumn_2025/internal/app/validation"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/scim"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+const scimContentType = "application/scim+json"
+
+type SCIMService interface {
+	ListUsers(ctx context.Context, params scim.ListParams) (*scim.UserList, error)
+	GetUser(ctx context.Context, id string) (*scim.User, error)
+	CreateUser(ctx context.Context, req scim.UserRequest) (*scim.User, error)
+	ReplaceUser(ctx context.Context, id string, req scim.UserRequest) (*scim.User, error)
+	PatchUser(ctx context.Context, id string, req scim.PatchRequest) (*scim.User, error)
+	DeactivateUser(ctx context.Context, id string) error
+	ListGroups(ctx context.Context, params scim.ListParams) (*scim.GroupList, error)
+	GetGroup(ctx context.Context, id string) (*scim.Group, error)
+	CreateGroup(ctx context.Context, req scim.GroupRequest) (*scim.Group, error)
+	ReplaceGroup(ctx context.Context, id string, req scim.GroupRequest) (*scim.Group, error)
+	PatchGroup(ctx context.Context, id string, req scim.PatchRequest) (*scim.Group, error)
+	DeleteGroup(ctx context.Context, id string) error
+}
+
+// scimHandler serves /scim/v2. It answers in the SCIM error format rather
+// than the API's, since identity providers parse those.
+type scimHandler struct {
+	scimService SCIMService
+	token       string
+}
+
+func NewSCIMHandler(scimService SCIMService, token string) *scimHandler {
+	return &scimHandler{
+		scimService: scimService,
+		token:       token,
+	}
+}
+
+// Authenticate rejects requests without the configured bearer token.
+func (h *scimHandler) Authenticate(c *gin.Context) {
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+		c.Header("WWW-Authenticate", `Bearer realm="scim"`)
+		h.error(c, http.StatusUnauthorized, "", "Missing or invalid bearer token")
+		c.Abort()
+		return
+	}
+
+	c.Next()
+}
+
+func (h *scimHandler) ServiceProviderConfig(c *gin.Context) {
+	h.respond(c, http.StatusOK, scim.ServiceProviderConfig{
+		Schemas:        []string{scim.ConfigSchema},
+		Patch:          scim.Supported{Supported: true},
+		Bulk:           scim.BulkConfig{},
+		Filter:         scim.FilterConfig{Supported: true, MaxResults: scim.MaxResults},
+		ChangePassword: scim.Supported{},
+		Sort:           scim.Supported{},
+		ETag:           scim.Supported{},
+		AuthenticationSchemes: []scim.AuthenticationScheme{{
+			Type:        "oauthbearertoken",
+			Name:        "Bearer token",
+			Description: "Static token from the scim.token setting",
+		}},
+	})
+}
+
+func (h *scimHandler) ListUsers(c *gin.Context) {
+	var params scim.ListParams
+	if !h.bindQuery(c, &params) {
+		return
+	}
+
+	response, err := h.scimService.ListUsers(c.Request.Context(), params)
+	if err != nil {
+		h.serviceError(c, err)
+		return
+	}
+
+	h.respond(c, http.StatusOK, response)
+}
+
+func (h *scimHandler) GetUser(c *gin.Context) {
+	response, err := h.scimService.GetUser(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.serviceError(c, err)
+		return
+	}
+
+	h.respond(c, http.StatusOK, response)
+}
+
+func (h *scimHandler) CreateUser(c *gin.Context) {
+	var req scim.UserRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.scimService.CreateUser(c.Request.Context(), req)
+	if err != nil {
+		h.serviceError(c, err)
+		return
+	}
+
+	c.Header("Location", response.Meta.Location)
+	h.respond(c, http.StatusCreated, response)
+}
+
+func (h *scimHandler) ReplaceUser(c *gin.Context) {
+	var req scim.UserRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.scimService.ReplaceUser(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		h.serviceError(c, err)
+		return
+	}
+
+	h.respond(c, http.StatusOK, response)
+}
+
+func (h *scimHandler) PatchUser(c *gin.Context) {
+	var req scim.PatchRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.scimService.PatchUser(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		h.serviceError(c, err)
+		return
+	}
+
+	h.respond(c, http.StatusOK, response)
+}
+
+func (h *scimHandler) DeleteUser(c *gin.Context) {
+	if err := h.scimService.DeactivateUser(c.Request.Context(), c.Param("id")); err != nil {
+		h.serviceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *scimHandler) ListGroups(c *gin.Context) {
+	var params scim.ListParams
+	if !h.bindQuery(c, &params) {
+		return
+	}
+
+	response, err := h.scimService.ListGroups(c.Request.Context(), params)
+	if err != nil {
+		h.serviceError(c, err)
+		return
+	}
+
+	h.respond(c, http.StatusOK, response)
+}
+
+func (h *scimHandler) GetGroup(c *gin.Context) {
+	response, err := h.scimService.GetGroup(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.serviceError(c, err)
+		return
+	}
+
+	h.respond(c, http.StatusOK, response)
+}
+
+func (h *scimHandler) CreateGroup(c *gin.Context) {
+	var req scim.GroupRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.scimService.CreateGroup(c.Request.Context(), req)
+	if err != nil {
+		h.serviceError(c, err)
+		return
+	}
+
+	c.Header("Location", response.Meta.Location)
+	h.respond(c, http.StatusCreated, response)
+}
+
+func (h *scimHandler) ReplaceGroup(c *gin.Context) {
+	var req scim.GroupRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.scimService.ReplaceGroup(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		h.serviceError(c, err)
+		return
+	}
+
+	h.respond(c, http.StatusOK, response)
+}
+
+func (h *scimHandler) PatchGroup(c *gin.Context) {
+	var req scim.PatchRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.scimService.PatchGroup(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		h.serviceError(c, err)
+		return
+	}
+
+	h.respond(c, http.StatusOK, response)
+}
+
+func (h *scimHandler) DeleteGroup(c *gin.Context) {
+	if err := h.scimService.DeleteGroup(c.Request.Context(), c.Param("id")); err != nil {
+		h.serviceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *scimHandler) bindJSON(c *gin.Context, obj any) bool {
+	if err := c.ShouldBindJSON(obj); err != nil {
+		h.invalidValue(c, err)
+		return false
+	}
+
+	return true
+}
+
+func (h *scimHandler) bindQuery(c *gin.Context, obj any) bool {
+	if err := c.ShouldBindQuery(obj); err != nil {
+		h.invalidValue(c, err)
+		return false
+	}
+
+	return true
+}
+
+func (h *scimHandler) invalidValue(c *gin.Context, err error) {
+	details := validation.Details(err)
+
+	reasons := make([]string, len(details))
+	for i, detail := range details {
+		reasons[i] = detail.Field + " " + detail.Reason
+	}
+
+	h.error(c, http.StatusBadRequest, "invalidValue", strings.Join(reasons, "; "))
+}
+
+// serviceError maps domain errors onto SCIM statuses and scimType values.
+func (h *scimHandler) serviceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, models.ErrNotFound):
+		h.error(c, http.StatusNotFound, "", err.Error())
+	case errors.Is(err, models.ErrUserExists), errors.Is(err, models.ErrTeamExists):
+		h.error(c, http.StatusConflict, "uniqueness", err.Error())
+	case errors.Is(err, models.ErrInvalidFilter):
+		h.error(c, http.StatusBadRequest, "invalidFilter", err.Error())
+	case errors.Is(err, models.ErrInvalidValue):
+		h.error(c, http.StatusBadRequest, "invalidValue", err.Error())
+	default:
+		slog.ErrorContext(c.Request.Context(), "scim request failed", "error", err)
+
+		h.error(c, http.StatusInternalServerError, "", "Internal error")
+	}
+}
+
+func (h *scimHandler) error(c *gin.Context, status int, scimType, detail string) {
+	h.respond(c, status, scim.Error{
+		Schemas:  []string{scim.ErrorSchema},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+}
+
+func (h *scimHandler) respond(c *gin.Context, status int, body any) {
+	c.Header("Content-Type", scimContentType)
+	c.JSON(status, body)
+}
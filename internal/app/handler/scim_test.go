@@ -0,0 +1,614 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/IlyaAGL/avito_autumn_2025/api"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/app/router"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/app/validation"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/scim"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/service"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/infrastructure/metrics"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+const scimToken = "scim-token"
+
+// roster keeps users and teams in memory for the SCIM service. It
+// implements the repository methods SCIM reaches, with the semantics of the
+// Postgres repositories.
+type roster struct {
+	users map[string]*models.User
+	teams []string
+}
+
+type rosterUsers struct {
+	service.UserRepository
+	*roster
+}
+
+type rosterTeams struct {
+	service.TeamRepository
+	*roster
+}
+
+func newRoster(teams []string, users ...models.User) *roster {
+	r := &roster{users: make(map[string]*models.User), teams: teams}
+	for _, user := range users {
+		r.users[user.UserID] = &user
+	}
+
+	return r
+}
+
+func (r rosterUsers) ListUsers(context.Context) ([]models.User, error) {
+	users := make([]models.User, 0, len(r.users))
+	for _, user := range r.users {
+		users = append(users, *user)
+	}
+	slices.SortFunc(users, func(a, b models.User) int { return strings.Compare(a.UserID, b.UserID) })
+
+	return users, nil
+}
+
+func (r rosterUsers) GetUser(_ context.Context, userID string) (*models.User, error) {
+	user, ok := r.users[userID]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+
+	copied := *user
+	return &copied, nil
+}
+
+func (r rosterUsers) CreateUser(_ context.Context, user *models.User) error {
+	if _, ok := r.users[user.UserID]; ok {
+		return models.ErrUserExists
+	}
+
+	copied := *user
+	r.users[user.UserID] = &copied
+
+	return nil
+}
+
+func (r rosterUsers) UpdateUser(ctx context.Context, userID, username string, isActive bool) (*models.User, error) {
+	user, ok := r.users[userID]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+
+	user.Username, user.IsActive = username, isActive
+
+	return r.GetUser(ctx, userID)
+}
+
+func (r rosterUsers) SetUserActive(ctx context.Context, userID string, isActive bool) (*models.User, error) {
+	user, ok := r.users[userID]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+
+	user.IsActive = isActive
+
+	return r.GetUser(ctx, userID)
+}
+
+func (r rosterTeams) CreateTeam(_ context.Context, team *models.Team, opts models.CreateTeamOptions) ([]models.ReviewerChange, error) {
+	exists := slices.Contains(r.teams, team.Name)
+	if exists && !opts.Upsert {
+		return nil, models.ErrTeamExists
+	}
+
+	var conflicts []models.MemberConflict
+	for _, member := range team.Members {
+		if user := r.users[member.UserID]; user != nil && user.TeamName != "" && user.TeamName != team.Name && !opts.AllowMove {
+			conflicts = append(conflicts, models.MemberConflict{UserID: user.UserID, TeamName: user.TeamName})
+		}
+	}
+	if len(conflicts) > 0 {
+		return nil, &models.MemberConflictError{Conflicts: conflicts}
+	}
+
+	if !exists {
+		r.teams = append(r.teams, team.Name)
+	}
+	for _, member := range team.Members {
+		r.users[member.UserID].TeamName = team.Name
+	}
+
+	return nil, nil
+}
+
+func (r rosterTeams) GetTeam(_ context.Context, teamName string) (*models.Team, error) {
+	if !slices.Contains(r.teams, teamName) {
+		return nil, models.ErrNotFound
+	}
+
+	team := &models.Team{Name: teamName}
+	for _, user := range r.members(teamName) {
+		team.Members = append(team.Members, models.Member{UserID: user.UserID, Username: user.Username, IsActive: user.IsActive})
+	}
+
+	return team, nil
+}
+
+func (r rosterTeams) ListTeams(context.Context) ([]models.TeamSummary, error) {
+	summaries := make([]models.TeamSummary, len(r.teams))
+	for i, name := range r.teams {
+		summaries[i] = models.TeamSummary{Name: name, Members: len(r.members(name))}
+	}
+
+	return summaries, nil
+}
+
+func (r rosterTeams) RemoveMembers(_ context.Context, teamName string, userIDs []string, _ models.RemoveMembersOptions) ([]models.ReviewerChange, error) {
+	for _, userID := range userIDs {
+		user := r.users[userID]
+		if user == nil || user.TeamName != teamName {
+			return nil, models.ErrNotFound
+		}
+		user.TeamName = ""
+	}
+
+	return nil, nil
+}
+
+func (r rosterTeams) RenameTeam(_ context.Context, teamName, newName string) error {
+	i := slices.Index(r.teams, teamName)
+	if i < 0 {
+		return models.ErrNotFound
+	}
+	if slices.Contains(r.teams, newName) {
+		return models.ErrTeamExists
+	}
+
+	r.teams[i] = newName
+	for _, user := range r.members(teamName) {
+		user.TeamName = newName
+	}
+
+	return nil
+}
+
+func (r rosterTeams) DeleteTeam(_ context.Context, teamName string) error {
+	i := slices.Index(r.teams, teamName)
+	if i < 0 {
+		return models.ErrNotFound
+	}
+	if len(r.members(teamName)) > 0 {
+		return models.ErrTeamNotEmpty
+	}
+
+	r.teams = slices.Delete(r.teams, i, i+1)
+
+	return nil
+}
+
+func (r *roster) members(teamName string) []*models.User {
+	var members []*models.User
+	for _, user := range r.users {
+		if user.TeamName == teamName {
+			members = append(members, user)
+		}
+	}
+	slices.SortFunc(members, func(a, b *models.User) int { return strings.Compare(a.UserID, b.UserID) })
+
+	return members
+}
+
+// teamOf returns the team of userID, or "-" for an unknown user.
+func (r *roster) teamOf(userID string) string {
+	if user := r.users[userID]; user != nil {
+		return user.TeamName
+	}
+
+	return "-"
+}
+
+// seed has alice and bob in backend, carol in frontend and dave without a
+// team.
+func seed() *roster {
+	return newRoster([]string{"backend", "frontend"},
+		models.User{UserID: "u1", Username: "alice@example.com", TeamName: "backend", IsActive: true},
+		models.User{UserID: "u2", Username: "bob@example.com", TeamName: "backend", IsActive: true},
+		models.User{UserID: "u3", Username: "carol@example.com", TeamName: "frontend", IsActive: true},
+		models.User{UserID: "u4", Username: "dave@example.com", IsActive: true},
+	)
+}
+
+func newSCIMRouter(t *testing.T, r *roster) http.Handler {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	if err := validation.Register(); err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := api.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appMetrics := metrics.New()
+
+	engine, err := router.New(router.Config{Spec: doc, Metrics: appMetrics, SCIMToken: scimToken}, router.Services{
+		SCIM: service.NewSCIMService(rosterUsers{roster: r}, rosterTeams{roster: r}, appMetrics),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return engine
+}
+
+// serveSCIM sends the recorded request in testdata/scim/fixture, if any,
+// with the SCIM bearer token.
+func serveSCIM(t *testing.T, h http.Handler, method, target, fixture string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, target, nil)
+	if fixture != "" {
+		body, err := os.ReadFile(filepath.Join("testdata", "scim", fixture))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req = httptest.NewRequest(method, target, strings.NewReader(string(body)))
+		req.Header.Set("Content-Type", "application/scim+json")
+	}
+	req.Header.Set("Authorization", "Bearer "+scimToken)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func decode[T any](t *testing.T, rec *httptest.ResponseRecorder) T {
+	t.Helper()
+
+	var body T
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode %s: %v", rec.Body.String(), err)
+	}
+
+	return body
+}
+
+func TestSCIMCreateUser(t *testing.T) {
+	tests := []struct {
+		name       string
+		fixture    string
+		existing   []models.User
+		wantStatus int
+		wantID     string
+		scimType   string
+	}{
+		{
+			name:       "Okta keeps externalId as the user ID",
+			fixture:    "okta_create_user.json",
+			wantStatus: http.StatusCreated,
+			wantID:     "00u1a2b3c4d5e6f7g8h9",
+		},
+		{
+			name:       "Azure without externalId gets a generated ID",
+			fixture:    "azure_create_user.json",
+			wantStatus: http.StatusCreated,
+			wantID:     "scim-",
+		},
+		{
+			name:       "userName taken in another case",
+			fixture:    "okta_create_user.json",
+			existing:   []models.User{{UserID: "u1", Username: "Alice@Example.com", IsActive: true}},
+			wantStatus: http.StatusConflict,
+			scimType:   "uniqueness",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRoster(nil, tt.existing...)
+
+			rec := serveSCIM(t, newSCIMRouter(t, r), http.MethodPost, "/scim/v2/Users", tt.fixture)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+
+			if tt.scimType != "" {
+				if got := decode[scim.Error](t, rec).ScimType; got != tt.scimType {
+					t.Errorf("scimType = %q, want %q", got, tt.scimType)
+				}
+				return
+			}
+
+			user := decode[scim.User](t, rec)
+			if !strings.HasPrefix(user.ID, tt.wantID) || !user.Active {
+				t.Errorf("user = %+v, want active with ID %s", user, tt.wantID)
+			}
+			if got := rec.Header().Get("Location"); got != "/scim/v2/Users/"+user.ID {
+				t.Errorf("Location = %q", got)
+			}
+			if stored := r.users[user.ID]; stored == nil || stored.Username != user.UserName || stored.TeamName != "" {
+				t.Errorf("stored user = %+v, want %s without a team", stored, user.UserName)
+			}
+		})
+	}
+}
+
+func TestSCIMUpdateUser(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		fixture    string
+		wantStatus int
+		// check inspects the roster after the request.
+		check func(t *testing.T, r *roster)
+	}{
+		{
+			name:       "Okta PATCH deactivates with a value object",
+			method:     http.MethodPatch,
+			target:     "/scim/v2/Users/u1",
+			fixture:    "okta_patch_user_deactivate.json",
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, r *roster) {
+				if user := r.users["u1"]; user.IsActive || user.TeamName != "backend" {
+					t.Errorf("u1 = %+v, want inactive and still in backend", user)
+				}
+			},
+		},
+		{
+			name:       "Azure PATCH deactivates with a \"False\" string",
+			method:     http.MethodPatch,
+			target:     "/scim/v2/Users/u1",
+			fixture:    "azure_patch_user_deactivate.json",
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, r *roster) {
+				if r.users["u1"].IsActive {
+					t.Error("u1 is still active")
+				}
+			},
+		},
+		{
+			name:       "Azure PATCH renames and ignores other attributes",
+			method:     http.MethodPatch,
+			target:     "/scim/v2/Users/u1",
+			fixture:    "azure_patch_user_rename.json",
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, r *roster) {
+				if user := r.users["u1"]; user.Username != "alice.smith@example.com" || !user.IsActive {
+					t.Errorf("u1 = %+v, want active alice.smith@example.com", user)
+				}
+			},
+		},
+		{
+			name:       "PATCH of an unknown user",
+			method:     http.MethodPatch,
+			target:     "/scim/v2/Users/u9",
+			fixture:    "okta_patch_user_deactivate.json",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "DELETE deactivates",
+			method:     http.MethodDelete,
+			target:     "/scim/v2/Users/u2",
+			wantStatus: http.StatusNoContent,
+			check: func(t *testing.T, r *roster) {
+				if user := r.users["u2"]; user == nil || user.IsActive {
+					t.Errorf("u2 = %+v, want kept as inactive", user)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := seed()
+
+			rec := serveSCIM(t, newSCIMRouter(t, r), tt.method, tt.target, tt.fixture)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.check != nil {
+				tt.check(t, r)
+			}
+		})
+	}
+}
+
+func TestSCIMUserFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		filter     string
+		wantStatus int
+		wantIDs    []string
+	}{
+		{name: "no filter", wantStatus: http.StatusOK, wantIDs: []string{"u1", "u2", "u3", "u4"}},
+		{name: "userName eq", filter: `userName eq "bob@example.com"`, wantStatus: http.StatusOK, wantIDs: []string{"u2"}},
+		{name: "case-insensitive", filter: `UserName EQ "BOB@example.com"`, wantStatus: http.StatusOK, wantIDs: []string{"u2"}},
+		{name: "no match", filter: `userName eq "erin@example.com"`, wantStatus: http.StatusOK, wantIDs: []string{}},
+		{name: "other attribute", filter: `emails eq "bob@example.com"`, wantStatus: http.StatusBadRequest},
+		{name: "other operator", filter: `userName sw "bob"`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := "/scim/v2/Users"
+			if tt.filter != "" {
+				target += "?filter=" + url.QueryEscape(tt.filter)
+			}
+
+			rec := serveSCIM(t, newSCIMRouter(t, seed()), http.MethodGet, target, "")
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+
+			if tt.wantIDs == nil {
+				if got := decode[scim.Error](t, rec).ScimType; got != "invalidFilter" {
+					t.Errorf("scimType = %q, want invalidFilter", got)
+				}
+				return
+			}
+
+			list := decode[scim.UserList](t, rec)
+			ids := make([]string, len(list.Resources))
+			for i, user := range list.Resources {
+				ids[i] = user.ID
+			}
+			if !slices.Equal(ids, tt.wantIDs) || list.TotalResults != len(tt.wantIDs) {
+				t.Errorf("got %v (total %d), want %v", ids, list.TotalResults, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestSCIMGroupMembership(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		fixture    string
+		wantStatus int
+		// wantTeams is the team of each listed user afterwards.
+		wantTeams map[string]string
+	}{
+		{
+			name:       "Okta create moves members out of their teams",
+			method:     http.MethodPost,
+			target:     "/scim/v2/Groups",
+			fixture:    "okta_create_group.json",
+			wantStatus: http.StatusCreated,
+			wantTeams:  map[string]string{"u1": "platform", "u2": "backend", "u3": "platform"},
+		},
+		{
+			name:       "Azure PATCH add moves the user",
+			method:     http.MethodPatch,
+			target:     "/scim/v2/Groups/backend",
+			fixture:    "azure_patch_group_add.json",
+			wantStatus: http.StatusOK,
+			wantTeams:  map[string]string{"u1": "backend", "u2": "backend", "u3": "backend"},
+		},
+		{
+			name:       "Okta PATCH remove by path filter",
+			method:     http.MethodPatch,
+			target:     "/scim/v2/Groups/backend",
+			fixture:    "okta_patch_group_remove.json",
+			wantStatus: http.StatusOK,
+			wantTeams:  map[string]string{"u1": "backend", "u2": ""},
+		},
+		{
+			name:       "Azure PATCH remove by value",
+			method:     http.MethodPatch,
+			target:     "/scim/v2/Groups/backend",
+			fixture:    "azure_patch_group_remove.json",
+			wantStatus: http.StatusOK,
+			wantTeams:  map[string]string{"u1": "backend", "u2": ""},
+		},
+		{
+			name:       "Okta PATCH rename keeps the members",
+			method:     http.MethodPatch,
+			target:     "/scim/v2/Groups/backend",
+			fixture:    "okta_patch_group_rename.json",
+			wantStatus: http.StatusOK,
+			wantTeams:  map[string]string{"u1": "platform", "u2": "platform"},
+		},
+		{
+			name:       "Okta PUT sets the exact members",
+			method:     http.MethodPut,
+			target:     "/scim/v2/Groups/backend",
+			fixture:    "okta_replace_group.json",
+			wantStatus: http.StatusOK,
+			wantTeams:  map[string]string{"u1": "backend", "u2": "", "u3": "backend"},
+		},
+		{
+			name:       "PATCH of an unknown group",
+			method:     http.MethodPatch,
+			target:     "/scim/v2/Groups/design",
+			fixture:    "azure_patch_group_add.json",
+			wantStatus: http.StatusNotFound,
+			wantTeams:  map[string]string{"u3": "frontend"},
+		},
+		{
+			name:       "DELETE empties and deletes the group",
+			method:     http.MethodDelete,
+			target:     "/scim/v2/Groups/backend",
+			wantStatus: http.StatusNoContent,
+			wantTeams:  map[string]string{"u1": "", "u2": ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := seed()
+
+			rec := serveSCIM(t, newSCIMRouter(t, r), tt.method, tt.target, tt.fixture)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+
+			for userID, want := range tt.wantTeams {
+				if got := r.teamOf(userID); got != want {
+					t.Errorf("team of %s = %q, want %q", userID, got, want)
+				}
+			}
+			for userID := range r.users {
+				if !r.users[userID].IsActive {
+					t.Errorf("%s was deactivated", userID)
+				}
+			}
+		})
+	}
+}
+
+func TestSCIMAuthentication(t *testing.T) {
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+	}{
+		{name: "no header", wantStatus: http.StatusUnauthorized},
+		{name: "wrong token", authorization: "Bearer other-token", wantStatus: http.StatusUnauthorized},
+		{name: "token prefix", authorization: "Bearer scim", wantStatus: http.StatusUnauthorized},
+		{name: "basic scheme", authorization: "Basic " + scimToken, wantStatus: http.StatusUnauthorized},
+		{name: "bearer token", authorization: "Bearer " + scimToken, wantStatus: http.StatusOK},
+	}
+
+	h := newSCIMRouter(t, seed())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/scim/v2/Users", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantStatus != http.StatusUnauthorized {
+				return
+			}
+
+			if got := rec.Header().Get("WWW-Authenticate"); got != `Bearer realm="scim"` {
+				t.Errorf("WWW-Authenticate = %q", got)
+			}
+			if body := decode[scim.Error](t, rec); body.Status != "401" || !slices.Equal(body.Schemas, []string{scim.ErrorSchema}) {
+				t.Errorf("body = %+v, want a SCIM 401 error", body)
+			}
+		})
+	}
+}
@@ -2,16 +2,33 @@ package handler
 
 import (
 	"context"
-	"strings"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
 
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/common"
 	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/teams"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/users"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/models"
 	"github.com/gin-gonic/gin"
 )
 
 type TeamService interface {
 	CreateTeam(ctx context.Context, req teams.CreateRequest) (*teams.CreateResponse, error)
 	GetTeam(ctx context.Context, teamName string) (*teams.TeamResponse, error)
+	ListTeams(ctx context.Context) (*teams.ListResponse, error)
 	BulkDeactivateUsers(ctx context.Context, teamName string) error
+	AddMembers(ctx context.Context, req teams.AddMembersRequest) (*teams.TeamResponse, error)
+	RemoveMembers(ctx context.Context, req teams.RemoveMembersRequest) (*teams.RemoveMembersResponse, error)
+	MoveUser(ctx context.Context, req users.MoveRequest) (*users.MoveResponse, error)
+	RenameTeam(ctx context.Context, req teams.RenameRequest) (*teams.TeamResponse, error)
+	DeleteTeam(ctx context.Context, teamName string) error
+	SyncTeams(ctx context.Context, req teams.SyncRequest) (*teams.SyncResponse, error)
+	ImportTeams(ctx context.Context, req teams.ImportRequest) (*teams.ImportResponse, error)
+	ExportTeams(ctx context.Context) (*teams.ExportResponse, error)
+	SetReviewLimits(ctx context.Context, req teams.ReviewLimitsRequest) (*teams.ReviewLimitsResponse, error)
+	GetReviewLoad(ctx context.Context, teamName string) (*teams.ReviewLoadResponse, error)
 }
 
 type teamHandler struct {
@@ -27,24 +44,13 @@ func NewTeamHandler(teamService TeamService) *teamHandler {
 
 func (h *teamHandler) AddTeam(c *gin.Context) {
 	var req teams.CreateRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		h.BadRequest(c, "INVALID_REQUEST", "Invalid request body")
-		return
-	}
-
-	if req.TeamName == "" {
-		h.BadRequest(c, "INVALID_REQUEST", "TeamName is required")
-		return
-	}
-
-	if len(req.Members) == 0 {
-		h.BadRequest(c, "INVALID_REQUEST", "Team must have at least one member")
+	if !h.BindJSON(c, &req) {
 		return
 	}
 
 	response, err := h.teamService.CreateTeam(c.Request.Context(), req)
 	if err != nil {
-		h.Conflict(c, "TEAM_EXISTS", "Team already exists")
+		h.membershipError(c, err, "Team not found", memberIDs(req.Members))
 		return
 	}
 
@@ -52,15 +58,16 @@ func (h *teamHandler) AddTeam(c *gin.Context) {
 }
 
 func (h *teamHandler) GetTeam(c *gin.Context) {
-	teamName := c.Query("team_name")
-	if teamName == "" {
-		h.BadRequest(c, "INVALID_REQUEST", "team_name is required")
+	var params teams.GetParams
+	if !h.BindQuery(c, &params) {
 		return
 	}
 
-	response, err := h.teamService.GetTeam(c.Request.Context(), teamName)
+	response, err := h.teamService.GetTeam(c.Request.Context(), params.TeamName)
 	if err != nil {
-		h.NotFound(c, "NOT_FOUND", "resource not found")
+		slog.WarnContext(c.Request.Context(), "get team failed", "error", err)
+
+		h.NotFoundOrInternal(c, err, "resource not found", "Failed to get team")
 		return
 	}
 
@@ -69,28 +76,217 @@ func (h *teamHandler) GetTeam(c *gin.Context) {
 
 func (h *teamHandler) BulkDeactivateUsers(c *gin.Context) {
     var req teams.BulkDeactivateRequest
-    if err := c.ShouldBindJSON(&req); err != nil {
-        h.BadRequest(c, "INVALID_REQUEST", "Invalid request body")
-        return
-    }
-
-    if req.TeamName == "" {
-        h.BadRequest(c, "INVALID_REQUEST", "TeamName is required")
+    if !h.BindJSON(c, &req) {
         return
     }
 
     err := h.teamService.BulkDeactivateUsers(c.Request.Context(), req.TeamName)
     if err != nil {
-        if strings.Contains(err.Error(), "team not found") {
-            h.NotFound(c, "NOT_FOUND", "Team not found")
-        } else {
-            h.InternalError(c, "Failed to deactivate users")
-        }
+        h.deactivateError(c, err)
         return
     }
 
-    h.Success(c, gin.H{
-        "message": "Deactivated",
-        "team":    req.TeamName,
+    h.Success(c, teams.BulkDeactivateResponse{
+        Message: "Deactivated",
+        Team:    req.TeamName,
     })
-}
\ No newline at end of file
+}
+
+func (h *teamHandler) deactivateError(c *gin.Context, err error) {
+	slog.WarnContext(c.Request.Context(), "bulk deactivate failed", "error", err)
+
+	if errors.Is(err, models.ErrNotFound) {
+		h.NotFound(c, "NOT_FOUND", "Team not found")
+	} else {
+		h.InternalError(c, "Failed to deactivate users")
+	}
+}
+
+func (h *teamHandler) ListTeams(c *gin.Context) {
+	response, err := h.teamService.ListTeams(c.Request.Context())
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "list teams failed", "error", err)
+
+		h.InternalError(c, "Failed to list teams")
+		return
+	}
+
+	h.Success(c, response)
+}
+
+func (h *teamHandler) AddMembers(c *gin.Context) {
+	var req teams.AddMembersRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.teamService.AddMembers(c.Request.Context(), req)
+	if err != nil {
+		h.membershipError(c, err, "Team not found", memberIDs(req.Members))
+		return
+	}
+
+	h.Success(c, response)
+}
+
+func (h *teamHandler) RemoveMembers(c *gin.Context) {
+	var req teams.RemoveMembersRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.teamService.RemoveMembers(c.Request.Context(), req)
+	if err != nil {
+		h.membershipError(c, err, "Team or member not found", nil)
+		return
+	}
+
+	h.Success(c, response)
+}
+
+func (h *teamHandler) MoveUser(c *gin.Context) {
+	var req users.MoveRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.teamService.MoveUser(c.Request.Context(), req)
+	if err != nil {
+		h.membershipError(c, err, "User or team not found", nil)
+		return
+	}
+
+	h.Success(c, response)
+}
+
+func (h *teamHandler) RenameTeam(c *gin.Context) {
+	var req teams.RenameRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.teamService.RenameTeam(c.Request.Context(), req)
+	if err != nil {
+		h.membershipError(c, err, "Team not found", nil)
+		return
+	}
+
+	h.Success(c, response)
+}
+
+func (h *teamHandler) DeleteTeam(c *gin.Context) {
+	var req teams.DeleteRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	if err := h.teamService.DeleteTeam(c.Request.Context(), req.TeamName); err != nil {
+		h.membershipError(c, err, "Team not found", nil)
+		return
+	}
+
+	h.Success(c, teams.DeleteResponse{
+		Message: "Deleted",
+		Team:    req.TeamName,
+	})
+}
+
+// SyncTeams serves both POST /team/sync and PUT /api/v2/roster; the body is
+// the same full roster document.
+func (h *teamHandler) SyncTeams(c *gin.Context) {
+	var req teams.SyncRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.teamService.SyncTeams(c.Request.Context(), req)
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "roster sync failed", "error", err)
+
+		h.InternalError(c, "Failed to sync teams")
+		return
+	}
+
+	h.Success(c, response)
+}
+
+// membershipError maps errors of the team management endpoints. userIDs are
+// the request's member IDs in order, used to point conflicts at fields.
+func (h *teamHandler) SetReviewLimits(c *gin.Context) {
+	var req teams.ReviewLimitsRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.teamService.SetReviewLimits(c.Request.Context(), req)
+	if err != nil {
+		h.membershipError(c, err, "Team not found", nil)
+		return
+	}
+
+	h.Success(c, response)
+}
+
+func (h *teamHandler) GetReviewLoad(c *gin.Context) {
+	var params teams.GetParams
+	if !h.BindQuery(c, &params) {
+		return
+	}
+
+	response, err := h.teamService.GetReviewLoad(c.Request.Context(), params.TeamName)
+	if err != nil {
+		h.membershipError(c, err, "Team not found", nil)
+		return
+	}
+
+	h.Success(c, response)
+}
+
+func (h *teamHandler) membershipError(c *gin.Context, err error, notFoundMessage string, userIDs []string) {
+	slog.WarnContext(c.Request.Context(), "team membership change failed", "error", err)
+
+	var conflictErr *models.MemberConflictError
+
+	switch {
+	case errors.As(err, &conflictErr):
+		h.ErrorWithDetails(c, http.StatusConflict, "MEMBER_CONFLICT",
+			"Users already belong to another team", conflictDetails(conflictErr, userIDs))
+	case errors.Is(err, models.ErrNotFound):
+		h.NotFound(c, "NOT_FOUND", notFoundMessage)
+	case errors.Is(err, models.ErrTeamExists):
+		h.Conflict(c, "TEAM_EXISTS", "Team already exists")
+	case errors.Is(err, models.ErrTeamNotEmpty):
+		h.Conflict(c, "TEAM_NOT_EMPTY", "Team still has members")
+	default:
+		h.InternalError(c, "Failed to update team")
+	}
+}
+
+func conflictDetails(err *models.MemberConflictError, userIDs []string) []common.FieldError {
+	details := make([]common.FieldError, len(err.Conflicts))
+	for i, conflict := range err.Conflicts {
+		field := "user_id"
+		for j, userID := range userIDs {
+			if userID == conflict.UserID {
+				field = fmt.Sprintf("members[%d].user_id", j)
+				break
+			}
+		}
+
+		details[i] = common.FieldError{
+			Field:  field,
+			Reason: fmt.Sprintf("user %s already belongs to team %s", conflict.UserID, conflict.TeamName),
+		}
+	}
+
+	return details
+}
+
+func memberIDs(members []teams.MemberCreate) []string {
+	ids := make([]string, len(members))
+	for i, member := range members {
+		ids[i] = member.UserID
+	}
+
+	return ids
+}
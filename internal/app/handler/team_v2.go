@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"log/slog"
+
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/common"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/teams"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/users"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *teamHandler) ListTeamsV2(c *gin.Context) {
+	response, err := h.teamService.ListTeams(c.Request.Context())
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "list teams failed", "error", err)
+
+		h.InternalError(c, "Failed to list teams")
+		return
+	}
+
+	h.Success(c, response)
+}
+
+func (h *teamHandler) CreateTeamV2(c *gin.Context) {
+	var req teams.CreateRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.teamService.CreateTeam(c.Request.Context(), req)
+	if err != nil {
+		h.membershipError(c, err, "Team not found", memberIDs(req.Members))
+		return
+	}
+
+	h.Created(c, response.Team)
+}
+
+func (h *teamHandler) GetTeamV2(c *gin.Context) {
+	var params teams.PathParams
+	if !h.BindURI(c, &params) {
+		return
+	}
+
+	response, err := h.teamService.GetTeam(c.Request.Context(), params.TeamName)
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "get team failed", "error", err)
+
+		h.NotFoundOrInternal(c, err, "Team not found", "Failed to get team")
+		return
+	}
+
+	h.Success(c, response)
+}
+
+func (h *teamHandler) DeactivateTeamV2(c *gin.Context) {
+	var params teams.PathParams
+	if !h.BindURI(c, &params) {
+		return
+	}
+
+	if err := h.teamService.BulkDeactivateUsers(c.Request.Context(), params.TeamName); err != nil {
+		h.deactivateError(c, err)
+		return
+	}
+
+	h.Success(c, teams.BulkDeactivateResponse{
+		Message: "Deactivated",
+		Team:    params.TeamName,
+	})
+}
+
+func (h *teamHandler) RenameTeamV2(c *gin.Context) {
+	var params teams.PathParams
+	if !h.BindURI(c, &params) {
+		return
+	}
+
+	var req teams.RenameV2Request
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.teamService.RenameTeam(c.Request.Context(), teams.RenameRequest{
+		TeamName:    params.TeamName,
+		NewTeamName: req.TeamName,
+	})
+	if err != nil {
+		h.membershipError(c, err, "Team not found", nil)
+		return
+	}
+
+	h.Success(c, response)
+}
+
+func (h *teamHandler) DeleteTeamV2(c *gin.Context) {
+	var params teams.PathParams
+	if !h.BindURI(c, &params) {
+		return
+	}
+
+	if err := h.teamService.DeleteTeam(c.Request.Context(), params.TeamName); err != nil {
+		h.membershipError(c, err, "Team not found", nil)
+		return
+	}
+
+	h.NoContent(c)
+}
+
+func (h *teamHandler) AddMembersV2(c *gin.Context) {
+	var params teams.PathParams
+	if !h.BindURI(c, &params) {
+		return
+	}
+
+	var req teams.MembersV2Request
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.teamService.AddMembers(c.Request.Context(), teams.AddMembersRequest{
+		TeamName: params.TeamName,
+		Members:  req.Members,
+	})
+	if err != nil {
+		h.membershipError(c, err, "Team not found", memberIDs(req.Members))
+		return
+	}
+
+	h.Success(c, response)
+}
+
+func (h *teamHandler) RemoveMemberV2(c *gin.Context) {
+	var params teams.MemberPathParams
+	if !h.BindURI(c, &params) {
+		return
+	}
+
+	response, err := h.teamService.RemoveMembers(c.Request.Context(), teams.RemoveMembersRequest{
+		TeamName: params.TeamName,
+		UserIDs:  []string{params.UserID},
+	})
+	if err != nil {
+		h.membershipError(c, err, "Team or member not found", nil)
+		return
+	}
+
+	h.Success(c, response)
+}
+
+func (h *teamHandler) MoveUserV2(c *gin.Context) {
+	var params users.PathParams
+	if !h.BindURI(c, &params) {
+		return
+	}
+
+	var req users.MoveV2Request
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.teamService.MoveUser(c.Request.Context(), users.MoveRequest{
+		UserID:   params.UserID,
+		TeamName: req.TeamName,
+	})
+	if err != nil {
+		h.membershipError(c, err, "User or team not found", nil)
+		return
+	}
+
+	h.Success(c, response)
+}
+
+func (h *teamHandler) SetReviewLimitsV2(c *gin.Context) {
+	var params teams.PathParams
+	if !h.BindURI(c, &params) {
+		return
+	}
+
+	var req common.ReviewLimits
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.teamService.SetReviewLimits(c.Request.Context(), teams.ReviewLimitsRequest{
+		TeamName:     params.TeamName,
+		ReviewLimits: req,
+	})
+	if err != nil {
+		h.membershipError(c, err, "Team not found", nil)
+		return
+	}
+
+	h.Success(c, response)
+}
+
+func (h *teamHandler) GetReviewLoadV2(c *gin.Context) {
+	var params teams.PathParams
+	if !h.BindURI(c, &params) {
+		return
+	}
+
+	response, err := h.teamService.GetReviewLoad(c.Request.Context(), params.TeamName)
+	if err != nil {
+		h.membershipError(c, err, "Team not found", nil)
+		return
+	}
+
+	h.Success(c, response)
+}
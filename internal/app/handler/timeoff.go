@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/common"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/timeoff"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+type TimeOffService interface {
+	CreateTimeOff(ctx context.Context, req timeoff.CreateRequest) (*timeoff.CreateResponse, error)
+	ListTimeOff(ctx context.Context, userID string) (*timeoff.ListResponse, error)
+	CancelTimeOff(ctx context.Context, req timeoff.CancelRequest) error
+	ListAway(ctx context.Context, teamName string) (*timeoff.AwayResponse, error)
+}
+
+type timeOffHandler struct {
+	BaseHandler
+	timeOffService TimeOffService
+}
+
+func NewTimeOffHandler(timeOffService TimeOffService) *timeOffHandler {
+	return &timeOffHandler{
+		timeOffService: timeOffService,
+	}
+}
+
+func (h *timeOffHandler) CreateTimeOff(c *gin.Context) {
+	var req timeoff.CreateRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.timeOffService.CreateTimeOff(c.Request.Context(), req)
+	if err != nil {
+		h.timeOffError(c, err, "User not found")
+		return
+	}
+
+	h.Created(c, response)
+}
+
+func (h *timeOffHandler) ListTimeOff(c *gin.Context) {
+	var params timeoff.ListParams
+	if !h.BindQuery(c, &params) {
+		return
+	}
+
+	response, err := h.timeOffService.ListTimeOff(c.Request.Context(), params.UserID)
+	if err != nil {
+		h.timeOffError(c, err, "User not found")
+		return
+	}
+
+	h.Success(c, response)
+}
+
+func (h *timeOffHandler) CancelTimeOff(c *gin.Context) {
+	var req timeoff.CancelRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	if err := h.timeOffService.CancelTimeOff(c.Request.Context(), req); err != nil {
+		h.timeOffError(c, err, "Time off not found")
+		return
+	}
+
+	h.Success(c, timeoff.CancelResponse{
+		Message:   "Cancelled",
+		TimeOffID: req.TimeOffID,
+	})
+}
+
+func (h *timeOffHandler) ListAway(c *gin.Context) {
+	var params timeoff.AwayParams
+	if !h.BindQuery(c, &params) {
+		return
+	}
+
+	response, err := h.timeOffService.ListAway(c.Request.Context(), params.TeamName)
+	if err != nil {
+		h.timeOffError(c, err, "Team not found")
+		return
+	}
+
+	h.Success(c, response)
+}
+
+func (h *timeOffHandler) timeOffError(c *gin.Context, err error, notFoundMessage string) {
+	slog.WarnContext(c.Request.Context(), "time off request failed", "error", err)
+
+	switch {
+	case errors.Is(err, models.ErrNotFound):
+		h.NotFound(c, "NOT_FOUND", notFoundMessage)
+	case errors.Is(err, models.ErrTimeOffOverlap):
+		h.Conflict(c, "TIME_OFF_OVERLAP", "Time off overlaps an existing period")
+	case errors.Is(err, models.ErrInvalidValue):
+		h.ErrorWithDetails(c, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request", []common.FieldError{
+			{Field: "ends_at", Reason: "must be in the future"},
+		})
+	default:
+		h.InternalError(c, "Failed to manage time off")
+	}
+}
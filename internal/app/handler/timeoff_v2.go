@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/teams"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/timeoff"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/users"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *timeOffHandler) CreateTimeOffV2(c *gin.Context) {
+	var params users.PathParams
+	if !h.BindURI(c, &params) {
+		return
+	}
+
+	var req timeoff.CreateV2Request
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.timeOffService.CreateTimeOff(c.Request.Context(), timeoff.CreateRequest{
+		UserID:          params.UserID,
+		StartsAt:        req.StartsAt,
+		EndsAt:          req.EndsAt,
+		Reason:          req.Reason,
+		ReassignReviews: req.ReassignReviews,
+	})
+	if err != nil {
+		h.timeOffError(c, err, "User not found")
+		return
+	}
+
+	h.Created(c, response.TimeOff)
+}
+
+func (h *timeOffHandler) ListTimeOffV2(c *gin.Context) {
+	var params users.PathParams
+	if !h.BindURI(c, &params) {
+		return
+	}
+
+	response, err := h.timeOffService.ListTimeOff(c.Request.Context(), params.UserID)
+	if err != nil {
+		h.timeOffError(c, err, "User not found")
+		return
+	}
+
+	h.Success(c, response)
+}
+
+func (h *timeOffHandler) CancelTimeOffV2(c *gin.Context) {
+	var params timeoff.PathParams
+	if !h.BindURI(c, &params) {
+		return
+	}
+
+	err := h.timeOffService.CancelTimeOff(c.Request.Context(), timeoff.CancelRequest{
+		UserID:    params.UserID,
+		TimeOffID: params.TimeOffID,
+	})
+	if err != nil {
+		h.timeOffError(c, err, "Time off not found")
+		return
+	}
+
+	h.NoContent(c)
+}
+
+func (h *timeOffHandler) ListAwayV2(c *gin.Context) {
+	var params teams.PathParams
+	if !h.BindURI(c, &params) {
+		return
+	}
+
+	response, err := h.timeOffService.ListAway(c.Request.Context(), params.TeamName)
+	if err != nil {
+		h.timeOffError(c, err, "Team not found")
+		return
+	}
+
+	h.Success(c, response)
+}
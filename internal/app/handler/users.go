@@ -2,8 +2,11 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"log/slog"
 
 	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/users"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/models"
 	"github.com/gin-gonic/gin"
 )
 
@@ -11,6 +14,10 @@ type UserService interface {
 	SetUserActive(ctx context.Context, req users.SetActiveRequest) (*users.SetActiveResponse, error)
 	GetUserReviewPRs(ctx context.Context, userID string) (*users.ReviewResponse, error)
 	GetUser(ctx context.Context, userID string) (*users.UserResponse, error)
+	ExportUsers(ctx context.Context) (*users.ExportResponse, error)
+	SetReviewLimits(ctx context.Context, req users.ReviewLimitsRequest) (*users.ReviewLimitsResponse, error)
+	GetSkills(ctx context.Context, userID string) (*users.SkillsResponse, error)
+	SetSkills(ctx context.Context, req users.SkillsRequest) (*users.SkillsResponse, error)
 }
 
 type userHandler struct {
@@ -26,37 +33,101 @@ func NewUserHandler(userService UserService) *userHandler {
 
 func (h *userHandler) SetIsActive(c *gin.Context) {
 	var req users.SetActiveRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		h.BadRequest(c, "INVALID_REQUEST", "Invalid request body")
+	if !h.BindJSON(c, &req) {
 		return
 	}
 
-	if req.UserID == "" {
-		h.BadRequest(c, "INVALID_REQUEST", "UserID is required")
+	response, err := h.userService.SetUserActive(c.Request.Context(), req)
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "set user active failed", "error", err)
+
+		h.NotFoundOrInternal(c, err, "resource not found", "Failed to update user")
 		return
 	}
 
-	response, err := h.userService.SetUserActive(c.Request.Context(), req)
+	h.Success(c, response)
+}
+
+func (h *userHandler) GetReview(c *gin.Context) {
+	var params users.ReviewParams
+	if !h.BindQuery(c, &params) {
+		return
+	}
+
+	response, err := h.userService.GetUserReviewPRs(c.Request.Context(), params.UserID)
 	if err != nil {
-		h.NotFound(c, "NOT_FOUND", "resource not found")
+		slog.WarnContext(c.Request.Context(), "get user reviews failed", "error", err)
+
+		h.NotFoundOrInternal(c, err, "resource not found", "Failed to get user reviews")
 		return
 	}
 
 	h.Success(c, response)
 }
 
-func (h *userHandler) GetReview(c *gin.Context) {
-	userID := c.Query("user_id")
-	if userID == "" {
-		h.BadRequest(c, "INVALID_REQUEST", "user_id is required")
+func (h *userHandler) SetReviewLimits(c *gin.Context) {
+	var req users.ReviewLimitsRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.userService.SetReviewLimits(c.Request.Context(), req)
+	if err != nil {
+		h.reviewLimitsError(c, err)
+		return
+	}
+
+	h.Success(c, response)
+}
+
+func (h *userHandler) GetSkills(c *gin.Context) {
+	var params users.SkillsParams
+	if !h.BindQuery(c, &params) {
 		return
 	}
 
-	response, err := h.userService.GetUserReviewPRs(c.Request.Context(), userID)
+	response, err := h.userService.GetSkills(c.Request.Context(), params.UserID)
 	if err != nil {
-		h.NotFound(c, "NOT_FOUND", "resource not found")
+		h.skillsError(c, err)
 		return
 	}
 
 	h.Success(c, response)
 }
+
+func (h *userHandler) SetSkills(c *gin.Context) {
+	var req users.SkillsRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.userService.SetSkills(c.Request.Context(), req)
+	if err != nil {
+		h.skillsError(c, err)
+		return
+	}
+
+	h.Success(c, response)
+}
+
+func (h *userHandler) skillsError(c *gin.Context, err error) {
+	slog.WarnContext(c.Request.Context(), "user skills request failed", "error", err)
+
+	if errors.Is(err, models.ErrNotFound) {
+		h.NotFound(c, "NOT_FOUND", "User not found")
+		return
+	}
+
+	h.InternalError(c, "Failed to process user skills")
+}
+
+func (h *userHandler) reviewLimitsError(c *gin.Context, err error) {
+	slog.WarnContext(c.Request.Context(), "set review limits failed", "error", err)
+
+	if errors.Is(err, models.ErrNotFound) {
+		h.NotFound(c, "NOT_FOUND", "User not found")
+		return
+	}
+
+	h.InternalError(c, "Failed to set review limits")
+}
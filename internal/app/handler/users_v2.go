@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"log/slog"
+
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/common"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/users"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *userHandler) GetUserV2(c *gin.Context) {
+	var params users.PathParams
+	if !h.BindURI(c, &params) {
+		return
+	}
+
+	response, err := h.userService.GetUser(c.Request.Context(), params.UserID)
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "get user failed", "error", err)
+
+		h.NotFoundOrInternal(c, err, "User not found", "Failed to get user")
+		return
+	}
+
+	h.Success(c, response)
+}
+
+func (h *userHandler) PatchUserV2(c *gin.Context) {
+	var params users.PathParams
+	if !h.BindURI(c, &params) {
+		return
+	}
+
+	var req users.PatchRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.userService.SetUserActive(c.Request.Context(), users.SetActiveRequest{
+		UserID:   params.UserID,
+		IsActive: *req.IsActive,
+	})
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "set user active failed", "error", err)
+
+		h.NotFoundOrInternal(c, err, "User not found", "Failed to update user")
+		return
+	}
+
+	h.Success(c, response.User)
+}
+
+func (h *userHandler) GetReviewsV2(c *gin.Context) {
+	var params users.PathParams
+	if !h.BindURI(c, &params) {
+		return
+	}
+
+	response, err := h.userService.GetUserReviewPRs(c.Request.Context(), params.UserID)
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "get user reviews failed", "error", err)
+
+		h.NotFoundOrInternal(c, err, "User not found", "Failed to get user reviews")
+		return
+	}
+
+	h.Success(c, response)
+}
+
+func (h *userHandler) SetReviewLimitsV2(c *gin.Context) {
+	var params users.PathParams
+	if !h.BindURI(c, &params) {
+		return
+	}
+
+	var req common.ReviewLimits
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.userService.SetReviewLimits(c.Request.Context(), users.ReviewLimitsRequest{
+		UserID:       params.UserID,
+		ReviewLimits: req,
+	})
+	if err != nil {
+		h.reviewLimitsError(c, err)
+		return
+	}
+
+	h.Success(c, response)
+}
+
+func (h *userHandler) GetSkillsV2(c *gin.Context) {
+	var params users.PathParams
+	if !h.BindURI(c, &params) {
+		return
+	}
+
+	response, err := h.userService.GetSkills(c.Request.Context(), params.UserID)
+	if err != nil {
+		h.skillsError(c, err)
+		return
+	}
+
+	h.Success(c, response)
+}
+
+func (h *userHandler) SetSkillsV2(c *gin.Context) {
+	var params users.PathParams
+	if !h.BindURI(c, &params) {
+		return
+	}
+
+	var req users.SkillsV2Request
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.userService.SetSkills(c.Request.Context(), users.SkillsRequest{
+		UserID: params.UserID,
+		Skills: req.Skills,
+	})
+	if err != nil {
+		h.skillsError(c, err)
+		return
+	}
+
+	h.Success(c, response)
+}
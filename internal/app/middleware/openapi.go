@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/common"
+	"github.com/IlyaAGL/avito_autumn_2025/pkg/logger"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gin-gonic/gin"
+)
+
+// OpenAPIValidator rejects requests whose parameters or body do not match
+// the spec with 400 INVALID_REQUEST listing every invalid field. Requests to
+// paths the spec does not describe are passed through to gin's own routing.
+func OpenAPIValidator(doc *openapi3.T) (gin.HandlerFunc, error) {
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	// CSV uploads are checked row by row by their handlers; kin-openapi's own
+	// CSV decoder would reject a whole file over one malformed row.
+	openapi3filter.RegisterBodyDecoder("text/csv", openapi3filter.PlainBodyDecoder)
+	openapi3filter.RegisterBodyDecoder("application/scim+json", openapi3filter.JSONBodyDecoder)
+
+	options := &openapi3filter.Options{
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+		MultiError:         true,
+	}
+
+	return func(c *gin.Context) {
+		route, pathParams, err := router.FindRoute(c.Request)
+		if err != nil {
+			if !errors.Is(err, routers.ErrPathNotFound) && !errors.Is(err, routers.ErrMethodNotAllowed) {
+				slog.WarnContext(c.Request.Context(), "openapi route lookup failed", "error", err)
+			}
+
+			c.Next()
+			return
+		}
+
+		err = openapi3filter.ValidateRequest(c.Request.Context(), &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    options,
+		})
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, common.ErrorResponse{
+				Error: common.ErrorDetail{
+					Code:      "INVALID_REQUEST",
+					Message:   "Invalid request",
+					RequestID: logger.RequestID(c.Request.Context()),
+					Details:   validationDetails(err, nil),
+				},
+			})
+			return
+		}
+
+		c.Next()
+	}, nil
+}
+
+// validationDetails flattens the nested errors kin-openapi returns in
+// multi-error mode into one entry per invalid field.
+func validationDetails(err error, requestErr *openapi3filter.RequestError) []common.FieldError {
+	// Match the wrapper types directly: errors.As sees through a
+	// RequestError to its nested MultiError (losing the parameter it names)
+	// and stops at the first match inside a MultiError.
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var details []common.FieldError
+		for _, child := range e {
+			details = append(details, validationDetails(child, requestErr)...)
+		}
+		return details
+	case *openapi3filter.RequestError:
+		if e.Err != nil {
+			return validationDetails(e.Err, e)
+		}
+		requestErr = e
+	}
+
+	field := "body"
+	if requestErr != nil && requestErr.Parameter != nil {
+		field = requestErr.Parameter.Name
+	}
+
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err, &schemaErr) {
+		if pointer := schemaErr.JSONPointer(); len(pointer) > 0 && field == "body" {
+			field = jsonPath(pointer)
+		}
+
+		return []common.FieldError{{Field: field, Reason: schemaReason(schemaErr)}}
+	}
+
+	if errors.Is(err, openapi3filter.ErrInvalidRequired) {
+		return []common.FieldError{{Field: field, Reason: "is required"}}
+	}
+
+	if requestErr != nil && requestErr.Reason != "" && field == "body" {
+		return []common.FieldError{{Field: field, Reason: requestErr.Reason}}
+	}
+
+	return []common.FieldError{{Field: field, Reason: err.Error()}}
+}
+
+// jsonPath renders a JSON pointer the way binding errors name fields,
+// e.g. members[0].user_id.
+func jsonPath(pointer []string) string {
+	var b strings.Builder
+	for _, part := range pointer {
+		if part != "" && strings.Trim(part, "0123456789") == "" {
+			b.WriteString("[" + part + "]")
+			continue
+		}
+
+		if b.Len() > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(part)
+	}
+
+	return b.String()
+}
+
+func schemaReason(err *openapi3.SchemaError) string {
+	switch err.SchemaField {
+	case "required":
+		return "is required"
+	case "pattern":
+		return "has an invalid format"
+	default:
+		return err.Reason
+	}
+}
@@ -0,0 +1,212 @@
+// Package router wires the HTTP handlers, middleware and routes of the API
+// into a gin engine.
+package router
+
+import (
+	"net/http"
+
+	"github.com/IlyaAGL/avito_autumn_2025/internal/app/handler"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/app/middleware"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+)
+
+type Metrics interface {
+	middleware.HTTPMetrics
+	Handler() http.Handler
+}
+
+// Services are what the handlers call.
+type Services struct {
+	Users        handler.UserService
+	PullRequests handler.PullRequestService
+	Teams        handler.TeamService
+	TimeOff      handler.TimeOffService
+	CodeOwners   handler.CodeOwnersService
+	Repositories handler.RepositoryService
+	Health       handler.HealthService
+	SCIM         handler.SCIMService
+}
+
+type Config struct {
+	// Spec validates the requests and is served at /openapi.json.
+	Spec        *openapi3.T
+	Metrics     Metrics
+	ServiceName string
+	// SCIMToken enables the /scim/v2 routes; they are left out without it.
+	SCIMToken string
+}
+
+// New builds the engine serving every route of the API.
+func New(cfg Config, services Services) (*gin.Engine, error) {
+	specValidator, err := middleware.OpenAPIValidator(cfg.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	docsHandler, err := handler.NewDocsHandler(cfg.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	userHandler := handler.NewUserHandler(services.Users)
+	prHandler := handler.NewpullRequestHandler(services.PullRequests)
+	teamHandler := handler.NewTeamHandler(services.Teams)
+	timeOffHandler := handler.NewTimeOffHandler(services.TimeOff)
+	codeOwnersHandler := handler.NewCodeOwnersHandler(services.CodeOwners)
+	repositoryHandler := handler.NewRepositoryHandler(services.Repositories)
+	healthHandler := handler.NewHealthHandler(services.Health)
+
+	r := gin.New()
+	r.Use(
+		otelgin.Middleware(cfg.ServiceName, otelgin.WithFilter(func(req *http.Request) bool {
+			switch req.URL.Path {
+			case "/metrics", "/healthz", "/readyz":
+				return false
+			default:
+				return true
+			}
+		})),
+		middleware.RequestID(),
+		middleware.Logger(),
+		middleware.Metrics(cfg.Metrics),
+		middleware.Recovery(),
+		specValidator,
+	)
+
+	r.GET("/metrics", gin.WrapH(cfg.Metrics.Handler()))
+	r.GET("/healthz", healthHandler.Liveness)
+	r.GET("/readyz", healthHandler.Readiness)
+	r.GET("/openapi.json", docsHandler.Spec)
+	r.GET("/docs", docsHandler.SwaggerUI)
+
+	teams := r.Group("/team")
+	{
+		teams.GET("/get", teamHandler.GetTeam)
+		teams.POST("/add", teamHandler.AddTeam)
+		teams.POST("/bulk", teamHandler.BulkDeactivateUsers)
+		teams.GET("/list", teamHandler.ListTeams)
+		teams.POST("/addMembers", teamHandler.AddMembers)
+		teams.POST("/removeMembers", teamHandler.RemoveMembers)
+		teams.POST("/moveUser", teamHandler.MoveUser)
+		teams.POST("/rename", teamHandler.RenameTeam)
+		teams.POST("/delete", teamHandler.DeleteTeam)
+		teams.POST("/sync", teamHandler.SyncTeams)
+		teams.POST("/import", teamHandler.ImportTeams)
+		teams.GET("/export", teamHandler.ExportTeams)
+		teams.GET("/away", timeOffHandler.ListAway)
+		teams.POST("/setReviewLimits", teamHandler.SetReviewLimits)
+		teams.GET("/reviewLoad", teamHandler.GetReviewLoad)
+	}
+
+	users := r.Group("/users")
+	{
+		users.POST("/setIsActive", userHandler.SetIsActive)
+		users.GET("/getReview", userHandler.GetReview)
+		users.GET("/export", userHandler.ExportUsers)
+		users.POST("/timeOff", timeOffHandler.CreateTimeOff)
+		users.GET("/timeOff", timeOffHandler.ListTimeOff)
+		users.POST("/timeOff/cancel", timeOffHandler.CancelTimeOff)
+		users.POST("/setReviewLimits", userHandler.SetReviewLimits)
+		users.GET("/skills", userHandler.GetSkills)
+		users.POST("/setSkills", userHandler.SetSkills)
+	}
+
+	prs := r.Group("/pullRequest")
+	{
+		prs.POST("/create", prHandler.CreatePR)
+		prs.POST("/merge", prHandler.MergePR)
+		prs.POST("/reassign", prHandler.ReassignReviewer)
+		prs.GET("/get", prHandler.GetPR)
+		prs.GET("/list", prHandler.ListPRs)
+		prs.GET("/statistics", prHandler.GetStats)
+		prs.GET("/export", prHandler.ExportPRs)
+		prs.GET("/understaffed", prHandler.ListUnderstaffed)
+	}
+
+	repos := r.Group("/repository")
+	{
+		repos.POST("/add", repositoryHandler.CreateRepository)
+		repos.GET("/get", repositoryHandler.GetRepository)
+		repos.GET("/list", repositoryHandler.ListRepositories)
+		repos.POST("/update", repositoryHandler.UpdateRepository)
+		repos.POST("/delete", repositoryHandler.DeleteRepository)
+	}
+
+	codeOwners := r.Group("/codeOwners")
+	{
+		codeOwners.POST("/upload", codeOwnersHandler.UploadCodeOwners)
+		codeOwners.GET("/get", codeOwnersHandler.GetCodeOwners)
+	}
+
+	v2 := r.Group("/api/v2")
+	{
+		v2.GET("/teams", teamHandler.ListTeamsV2)
+		v2.POST("/teams", teamHandler.CreateTeamV2)
+		v2.GET("/teams/:name", teamHandler.GetTeamV2)
+		v2.PATCH("/teams/:name", teamHandler.RenameTeamV2)
+		v2.DELETE("/teams/:name", teamHandler.DeleteTeamV2)
+		v2.POST("/teams/:name/deactivate", teamHandler.DeactivateTeamV2)
+		v2.POST("/teams/:name/members", teamHandler.AddMembersV2)
+		v2.DELETE("/teams/:name/members/:user_id", teamHandler.RemoveMemberV2)
+		v2.GET("/teams/:name/away", timeOffHandler.ListAwayV2)
+		v2.PUT("/teams/:name/review-limits", teamHandler.SetReviewLimitsV2)
+		v2.GET("/teams/:name/review-load", teamHandler.GetReviewLoadV2)
+
+		v2.PUT("/roster", teamHandler.SyncTeams)
+
+		v2.GET("/users/:id", userHandler.GetUserV2)
+		v2.PATCH("/users/:id", userHandler.PatchUserV2)
+		v2.GET("/users/:id/reviews", userHandler.GetReviewsV2)
+		v2.PUT("/users/:id/team", teamHandler.MoveUserV2)
+		v2.GET("/users/:id/time-off", timeOffHandler.ListTimeOffV2)
+		v2.POST("/users/:id/time-off", timeOffHandler.CreateTimeOffV2)
+		v2.DELETE("/users/:id/time-off/:time_off_id", timeOffHandler.CancelTimeOffV2)
+		v2.PUT("/users/:id/review-limits", userHandler.SetReviewLimitsV2)
+		v2.GET("/users/:id/skills", userHandler.GetSkillsV2)
+		v2.PUT("/users/:id/skills", userHandler.SetSkillsV2)
+
+		v2.GET("/pull-requests", prHandler.ListPRsV2)
+		v2.POST("/pull-requests", prHandler.CreatePRV2)
+		v2.GET("/pull-requests/:id", prHandler.GetPRV2)
+		v2.POST("/pull-requests/:id/merge", prHandler.MergePRV2)
+		v2.POST("/pull-requests/:id/reviewers/:action", prHandler.ReviewerActionV2)
+
+		v2.GET("/repositories", repositoryHandler.ListRepositories)
+		v2.POST("/repositories", repositoryHandler.CreateRepository)
+		v2.GET("/repositories/:repository", repositoryHandler.GetRepositoryV2)
+		v2.PUT("/repositories/:repository", repositoryHandler.UpdateRepositoryV2)
+		v2.DELETE("/repositories/:repository", repositoryHandler.DeleteRepositoryV2)
+		v2.GET("/repositories/:repository/pull-requests/:number", prHandler.GetRepositoryPRV2)
+		v2.GET("/repositories/:repository/codeowners", codeOwnersHandler.GetCodeOwnersV2)
+		v2.PUT("/repositories/:repository/codeowners", codeOwnersHandler.SetCodeOwnersV2)
+
+		v2.GET("/stats", prHandler.GetStats)
+	}
+
+	if cfg.SCIMToken != "" {
+		scimHandler := handler.NewSCIMHandler(services.SCIM, cfg.SCIMToken)
+
+		scimRoutes := r.Group("/scim/v2", scimHandler.Authenticate)
+		{
+			scimRoutes.GET("/ServiceProviderConfig", scimHandler.ServiceProviderConfig)
+
+			scimRoutes.GET("/Users", scimHandler.ListUsers)
+			scimRoutes.POST("/Users", scimHandler.CreateUser)
+			scimRoutes.GET("/Users/:id", scimHandler.GetUser)
+			scimRoutes.PUT("/Users/:id", scimHandler.ReplaceUser)
+			scimRoutes.PATCH("/Users/:id", scimHandler.PatchUser)
+			scimRoutes.DELETE("/Users/:id", scimHandler.DeleteUser)
+
+			scimRoutes.GET("/Groups", scimHandler.ListGroups)
+			scimRoutes.POST("/Groups", scimHandler.CreateGroup)
+			scimRoutes.GET("/Groups/:id", scimHandler.GetGroup)
+			scimRoutes.PUT("/Groups/:id", scimHandler.ReplaceGroup)
+			scimRoutes.PATCH("/Groups/:id", scimHandler.PatchGroup)
+			scimRoutes.DELETE("/Groups/:id", scimHandler.DeleteGroup)
+		}
+	}
+
+	return r, nil
+}
@@ -0,0 +1,243 @@
+// Package validation configures gin's validator for the DTO binding tags and
+// turns binding failures into per-field error details.
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/common"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/teams"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// idPattern matches user, team member and pull request IDs.
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._:-]*$`)
+
+// skillPattern matches user skills and PR tags such as "go", "c++" or "k8s".
+var skillPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9.+#_-]*$`)
+
+// Register adds the custom "id", "name" and "skill" tags and the roster checks to
+// gin's validator and makes it report fields by their JSON or query names.
+func Register() error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return errors.New("validation: gin validator is not go-playground/validator")
+	}
+
+	v.RegisterTagNameFunc(fieldName)
+
+	if err := v.RegisterValidation("id", validateID); err != nil {
+		return err
+	}
+
+	if err := v.RegisterValidation("name", validateName); err != nil {
+		return err
+	}
+
+	if err := v.RegisterValidation("skill", validateSkill); err != nil {
+		return err
+	}
+
+	v.RegisterStructValidation(validateSyncRequest, teams.SyncRequest{})
+
+	return nil
+}
+
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form", "uri"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+
+	return field.Name
+}
+
+func validateID(fl validator.FieldLevel) bool {
+	return idPattern.MatchString(fl.Field().String())
+}
+
+func validateSkill(fl validator.FieldLevel) bool {
+	return skillPattern.MatchString(fl.Field().String())
+}
+
+// validateName accepts free-form names without surrounding whitespace or
+// control characters.
+func validateName(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	if value != strings.TrimSpace(value) {
+		return false
+	}
+
+	return !strings.ContainsFunc(value, unicode.IsControl)
+}
+
+// validateSyncRequest rejects rosters that list a user in more than one team.
+func validateSyncRequest(sl validator.StructLevel) {
+	req := sl.Current().Interface().(teams.SyncRequest)
+
+	seen := make(map[string]bool)
+	for i, team := range req.Teams {
+		for j, member := range team.Members {
+			if seen[member.UserID] {
+				sl.ReportError(member.UserID, fmt.Sprintf("teams[%d].members[%d].user_id", i, j), "UserID", "single_team", "")
+			}
+			seen[member.UserID] = true
+		}
+	}
+}
+
+// Details describes every problem in a binding error. Errors that are not
+// about a particular field are reported against "body".
+func Details(err error) []common.FieldError {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		details := make([]common.FieldError, len(validationErrs))
+		for i, fe := range validationErrs {
+			details[i] = common.FieldError{
+				Field:  fieldPath(fe.Namespace()),
+				Reason: reason(fe),
+			}
+		}
+		return details
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return []common.FieldError{{
+			Field:  typeErr.Field,
+			Reason: "must be " + article(typeErr.Type.Kind().String()),
+		}}
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return []common.FieldError{{Field: "body", Reason: "must be a valid JSON object"}}
+	}
+
+	return []common.FieldError{{Field: "body", Reason: err.Error()}}
+}
+
+// fieldPath drops the struct name validator puts in front of the JSON path.
+func fieldPath(namespace string) string {
+	_, path, found := strings.Cut(namespace, ".")
+	if !found {
+		return namespace
+	}
+
+	return path
+}
+
+func reason(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "max":
+		switch fe.Kind() {
+		case reflect.Slice:
+			return fmt.Sprintf("must have at most %s items", fe.Param())
+		case reflect.Int, reflect.Int32, reflect.Int64:
+			return "must be at most " + fe.Param()
+		}
+		return fmt.Sprintf("must be at most %s characters", fe.Param())
+	case "min":
+		switch fe.Kind() {
+		case reflect.Slice:
+			return fmt.Sprintf("must have at least %s items", fe.Param())
+		case reflect.Int, reflect.Int32, reflect.Int64:
+			return "must be at least " + fe.Param()
+		}
+		return fmt.Sprintf("must be at least %s characters", fe.Param())
+	case "required_with":
+		return "is required with " + snakeCaseList(fe.Param())
+	case "required_without":
+		return "is required without " + snakeCaseList(fe.Param())
+	case "excluded_with":
+		return "must be omitted with " + snakeCaseList(fe.Param())
+	case "gtfield":
+		return "must be after " + snakeCase(fe.Param())
+	case "excludes":
+		return fmt.Sprintf("must not contain '%s'", fe.Param())
+	case "oneof":
+		return "must be one of " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "unique":
+		return "contains duplicate " + elemFieldName(fe.Type(), fe.Param())
+	case "id":
+		return "must start with a letter or digit and contain only letters, digits, '.', '_', ':' and '-'"
+	case "name":
+		return "must not have leading or trailing spaces or control characters"
+	case "skill":
+		return "must start with a lowercase letter or digit and contain only lowercase letters, digits, '.', '+', '#', '_' and '-'"
+	case "single_team":
+		return "user is already listed in another team"
+	default:
+		return "failed the " + fe.Tag() + " check"
+	}
+}
+
+// elemFieldName returns the JSON name of a field of the slice element type.
+func elemFieldName(sliceType reflect.Type, field string) string {
+	elem := sliceType.Elem()
+	if elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+
+	if elem.Kind() == reflect.Struct {
+		if f, ok := elem.FieldByName(field); ok {
+			return fieldName(f)
+		}
+	}
+
+	return "values"
+}
+
+// snakeCase turns a Go field name such as StartsAt into its JSON name.
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// snakeCaseList turns a space-separated list of Go field names into their
+// JSON names joined with "or".
+func snakeCaseList(names string) string {
+	fields := strings.Fields(names)
+	for i, field := range fields {
+		fields[i] = snakeCase(field)
+	}
+
+	return strings.Join(fields, " or ")
+}
+
+func article(kind string) string {
+	switch kind {
+	case "int", "int32", "int64", "float64":
+		return "a number"
+	case "bool":
+		return "a boolean"
+	case "slice":
+		return "an array"
+	case "struct", "map":
+		return "an object"
+	default:
+		return "a " + kind
+	}
+}
@@ -0,0 +1,22 @@
+package codeowners
+
+// Rule is a parsed line of an uploaded CODEOWNERS file.
+type Rule struct {
+	Line    int
+	Pattern string
+	Owners  []string
+}
+
+// SetRequest replaces the rules of a repository with the parsed file.
+type SetRequest struct {
+	Repository string
+	Rules      []Rule
+}
+
+type Params struct {
+	Repository string `form:"repository" binding:"required,max=255,id"`
+}
+
+type PathParams struct {
+	Repository string `uri:"repository" binding:"required,max=255,id"`
+}
@@ -0,0 +1,20 @@
+package codeowners
+
+// SetResponse summarizes an upload. UnknownOwners lists the owners that name
+// no user or team; their rules still apply to the other owners.
+type SetResponse struct {
+	Repository    string   `json:"repository"`
+	Rules         int      `json:"rules"`
+	UnknownOwners []string `json:"unknown_owners"`
+}
+
+type RulesResponse struct {
+	Repository string         `json:"repository"`
+	Rules      []RuleResponse `json:"rules"`
+}
+
+type RuleResponse struct {
+	Line    int      `json:"line"`
+	Pattern string   `json:"pattern"`
+	Owners  []string `json:"owners"`
+}
@@ -5,16 +5,63 @@ type ErrorResponse struct {
 }
 
 type ErrorDetail struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	Code      string       `json:"code"`
+	Message   string       `json:"message"`
+	RequestID string       `json:"request_id,omitempty"`
+	Details   []FieldError `json:"details,omitempty"`
+}
+
+type FieldError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
 }
 
 type StatsResponse struct {
 	UserStats []ReviewStats `json:"user_stats"`
+	// PullRequests counts the PRs by size and priority.
+	PullRequests []PullRequestStats `json:"pull_requests"`
 }
 
 type ReviewStats struct {
 	UserID   string `json:"user_id"`
 	OpenPRs  int    `json:"open_prs"`
 	TotalPRs int    `json:"total_prs"`
+	// OpenLoad weighs the open reviews by PR size.
+	OpenLoad int `json:"open_load"`
+}
+
+// PullRequestStats counts the PRs of a size and priority; an empty size
+// stands for PRs created without size metadata.
+type PullRequestStats struct {
+	Size     string `json:"size"`
+	Priority string `json:"priority"`
+	OpenPRs  int    `json:"open_prs"`
+	TotalPRs int    `json:"total_prs"`
+}
+
+// ReviewerChange describes an open review handed over because its reviewer
+// left the author's team; an empty new_reviewer_id means it was dropped.
+type ReviewerChange struct {
+	PullRequestID string `json:"pull_request_id"`
+	OldReviewerID string `json:"old_reviewer_id"`
+	NewReviewerID string `json:"new_reviewer_id,omitempty"`
+}
+
+// ReviewLimits are the capacity settings of a user or a team. A null field
+// of a user falls back to the team; a null field of a team means no limit.
+type ReviewLimits struct {
+	MaxOpenReviews        *int `json:"max_open_reviews" binding:"omitempty,min=1,max=1000"`
+	ReviewCooldownMinutes *int `json:"review_cooldown_minutes" binding:"omitempty,min=0,max=10080"`
+}
+
+// Export formats accepted by the export endpoints.
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+// ExportParams are the query parameters of the export endpoints; the format
+// defaults to CSV.
+type ExportParams struct {
+	Format string `form:"format" binding:"omitempty,oneof=csv json"`
 }
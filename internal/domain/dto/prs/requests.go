@@ -1,16 +1,60 @@
 package pullrequests
 
 type CreateRequest struct {
-	PullRequestID   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
-	AuthorID        string `json:"author_id"`
+	// PullRequestID names a PR without a repository. A PR of a repository
+	// is always "<repository>:<number>", so its ID is left out and may not
+	// contain ':' here.
+	PullRequestID   string `json:"pull_request_id,omitempty" binding:"required_without=Repository,excluded_with=Repository,omitempty,max=255,id,excludes=:"`
+	PullRequestName string `json:"pull_request_name" binding:"required,max=255,name"`
+	AuthorID        string `json:"author_id" binding:"required,max=255,id"`
+	// Tags are matched against the skills of the candidates.
+	Tags []string `json:"tags,omitempty" binding:"omitempty,max=10,unique,dive,required,max=64,skill"`
+	// Repository owns the PR and decides its review policy and the
+	// CODEOWNERS rules applied to ChangedFiles.
+	Repository string `json:"repository,omitempty" binding:"required_with=ChangedFiles Number,omitempty,max=255,id"`
+	// Number is the PR number in the repository, the next free one if left
+	// out.
+	Number       int      `json:"number,omitempty" binding:"omitempty,min=1"`
+	ChangedFiles []string `json:"changed_files,omitempty" binding:"omitempty,max=3000,dive,required,max=1024"`
+	// LinesAdded, LinesRemoved and FilesChanged size the PR; larger PRs get
+	// more reviewers and weigh more on their load. FilesChanged defaults to
+	// the number of ChangedFiles.
+	LinesAdded   *int `json:"lines_added,omitempty" binding:"omitempty,min=0,max=10000000"`
+	LinesRemoved *int `json:"lines_removed,omitempty" binding:"omitempty,min=0,max=10000000"`
+	FilesChanged *int `json:"files_changed,omitempty" binding:"omitempty,min=0,max=1000000"`
+	// Priority defaults to normal; urgent PRs go to the least loaded
+	// candidates.
+	Priority string `json:"priority,omitempty" binding:"omitempty,oneof=low normal urgent"`
 }
 
 type MergeRequest struct {
-	PullRequestID string `json:"pull_request_id"`
+	PullRequestID string `json:"pull_request_id" binding:"required,max=255,id"`
+}
+
+type GetParams struct {
+	PullRequestID string `form:"pull_request_id" binding:"required,max=255,id"`
+}
+
+type ListParams struct {
+	Status     string `form:"status" binding:"omitempty,oneof=OPEN MERGED"`
+	AuthorID   string `form:"author_id" binding:"omitempty,max=255,id"`
+	Repository string `form:"repository" binding:"omitempty,max=255,id"`
+	// Understaffed keeps only open PRs with fewer reviewers than required.
+	Understaffed bool `form:"understaffed"`
 }
 
 type ReassignRequest struct {
-	PullRequestID string `json:"pull_request_id"`
-	OldUserID     string `json:"old_reviewer_id"`
+	PullRequestID string `json:"pull_request_id" binding:"required,max=255,id"`
+	OldUserID     string `json:"old_reviewer_id" binding:"required,max=255,id"`
+}
+
+type PathParams struct {
+	PullRequestID string `uri:"id" binding:"required,max=255,id"`
+}
+
+// ReassignPathParams binds /pull-requests/:id/reviewers/:action, where the
+// action segment is "<user_id>:reassign".
+type ReassignPathParams struct {
+	PullRequestID string `uri:"id" binding:"required,max=255,id"`
+	Action        string `uri:"action" binding:"required"`
 }
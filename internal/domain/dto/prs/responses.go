@@ -8,16 +8,74 @@ type MergeResponse struct {
 	PR PullRequestResponse `json:"pr"`
 }
 
+type GetResponse struct {
+	PR PullRequestResponse `json:"pr"`
+}
+
+type ListResponse struct {
+	PullRequests []PullRequestResponse `json:"pull_requests"`
+}
+
 type ReassignResponse struct {
 	PR         PullRequestResponse `json:"pr"`
 	ReplacedBy string              `json:"replaced_by"`
 }
 
+// PullRequestV2 is the /api/v2 representation of a pull request. It is
+// PullRequestResponse with every field snake_case: MergedAt is renamed and
+// the camelCase name is masked.
+type PullRequestV2 struct {
+	PullRequestResponse
+	// V1MergedAt outranks the embedded mergedAt field and is never set, so
+	// that name is left out.
+	V1MergedAt *struct{} `json:"mergedAt,omitempty"`
+	MergedAt   *string   `json:"merged_at,omitempty"`
+}
+
+type ListV2Response struct {
+	PullRequests []PullRequestV2 `json:"pull_requests"`
+}
+
+type ReassignV2Response struct {
+	PR         PullRequestV2 `json:"pr"`
+	ReplacedBy string        `json:"replaced_by"`
+}
+
 type PullRequestResponse struct {
 	PullRequestID     string   `json:"pull_request_id"`
 	PullRequestName   string   `json:"pull_request_name"`
 	AuthorID          string   `json:"author_id"`
 	Status            string   `json:"status"`
 	AssignedReviewers []string `json:"assigned_reviewers"`
-	MergedAt          *string  `json:"mergedAt,omitempty"`
+	Tags              []string `json:"tags"`
+	Repository        string   `json:"repository,omitempty"`
+	Number            int      `json:"number,omitempty"`
+	// LinesAdded, LinesRemoved and FilesChanged are left out when the PR
+	// was created without them; Size is their bucket.
+	LinesAdded   *int   `json:"lines_added,omitempty"`
+	LinesRemoved *int   `json:"lines_removed,omitempty"`
+	FilesChanged *int   `json:"files_changed,omitempty"`
+	Size         string `json:"size,omitempty"`
+	Priority     string `json:"priority"`
+	// OwnerRules maps reviewers assigned as code owners to the CODEOWNERS
+	// pattern that assigned them.
+	OwnerRules map[string]string `json:"owner_rules,omitempty"`
+	// Understaffed marks an open PR that has fewer reviewers than the policy
+	// asks for because no more eligible candidates were available.
+	Understaffed bool    `json:"understaffed"`
+	MergedAt     *string `json:"mergedAt,omitempty"`
+	// ReviewerMatches explains the reviewers assigned by this call; it is
+	// left out when the call assigned none.
+	ReviewerMatches []ReviewerMatch `json:"reviewer_matches,omitempty"`
+}
+
+// ReviewerMatch tells why a reviewer was picked: "owner" when they own a
+// changed path by the CODEOWNERS rule in Rule, "skill" when they have a skill
+// among the PR tags, "fallback" when the PR has tags but they do not, and
+// "random" when the PR has no tags.
+type ReviewerMatch struct {
+	UserID string   `json:"user_id"`
+	Reason string   `json:"reason"`
+	Skills []string `json:"skills"`
+	Rule   string   `json:"rule,omitempty"`
 }
@@ -0,0 +1,35 @@
+package pullrequests
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPullRequestV2JSON(t *testing.T) {
+	mergedAt := "2025-11-01T10:00:00Z"
+	pr := PullRequestResponse{
+		PullRequestID: "pr-1",
+		Status:        "MERGED",
+		MergedAt:      &mergedAt,
+	}
+
+	out, err := json.Marshal(PullRequestV2{PullRequestResponse: pr, MergedAt: pr.MergedAt})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(out, &fields); err != nil {
+		t.Fatal(err)
+	}
+
+	if fields["merged_at"] != mergedAt {
+		t.Errorf("merged_at = %v, want %s", fields["merged_at"], mergedAt)
+	}
+	if _, ok := fields["mergedAt"]; ok {
+		t.Errorf("mergedAt is present in %s", out)
+	}
+	if fields["pull_request_id"] != "pr-1" || fields["status"] != "MERGED" {
+		t.Errorf("embedded fields missing from %s", out)
+	}
+}
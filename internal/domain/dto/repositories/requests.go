@@ -0,0 +1,46 @@
+package repositories
+
+// Policy overrides the service defaults for the pull requests of a
+// repository; a null field keeps the default.
+type Policy struct {
+	Reviewers *int    `json:"reviewers" binding:"omitempty,min=1,max=10"`
+	Strategy  *string `json:"strategy" binding:"omitempty,oneof=random least_loaded"`
+}
+
+type CreateRequest struct {
+	Repository string `json:"repository" binding:"required,max=255,id"`
+	// TeamName is the owning team; its members review the repository's PRs.
+	TeamName string `json:"team_name,omitempty" binding:"omitempty,max=255,name"`
+	Policy
+}
+
+// UpdateRequest replaces the owning team and the policy of a repository.
+type UpdateRequest struct {
+	Repository string `json:"repository" binding:"required,max=255,id"`
+	TeamName   string `json:"team_name,omitempty" binding:"omitempty,max=255,name"`
+	Policy
+}
+
+// UpdateV2Request is the body of PUT /api/v2/repositories/:repository.
+type UpdateV2Request struct {
+	TeamName string `json:"team_name,omitempty" binding:"omitempty,max=255,name"`
+	Policy
+}
+
+type DeleteRequest struct {
+	Repository string `json:"repository" binding:"required,max=255,id"`
+}
+
+type GetParams struct {
+	Repository string `form:"repository" binding:"required,max=255,id"`
+}
+
+type PathParams struct {
+	Repository string `uri:"repository" binding:"required,max=255,id"`
+}
+
+// PullRequestPathParams binds /repositories/:repository/pull-requests/:number.
+type PullRequestPathParams struct {
+	Repository string `uri:"repository" binding:"required,max=255,id"`
+	Number     int    `uri:"number" binding:"required,min=1"`
+}
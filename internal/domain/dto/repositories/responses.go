@@ -0,0 +1,21 @@
+package repositories
+
+// RepositoryResponse lists the overrides of a repository next to the policy
+// its pull requests actually get.
+type RepositoryResponse struct {
+	Repository string `json:"repository"`
+	TeamName   string `json:"team_name,omitempty"`
+	Policy
+	EffectiveReviewers int    `json:"effective_reviewers"`
+	EffectiveStrategy  string `json:"effective_strategy"`
+	PullRequests       int    `json:"pull_requests"`
+}
+
+type ListResponse struct {
+	Repositories []RepositoryResponse `json:"repositories"`
+}
+
+type DeleteResponse struct {
+	Message    string `json:"message"`
+	Repository string `json:"repository"`
+}
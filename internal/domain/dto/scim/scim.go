@@ -0,0 +1,143 @@
+// Package scim holds the SCIM 2.0 (RFC 7643, RFC 7644) resources served
+// under /scim/v2. SCIM Users map to users and Groups to teams.
+package scim
+
+import "encoding/json"
+
+const (
+	UserSchema         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	GroupSchema        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	ListResponseSchema = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	PatchOpSchema      = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	ErrorSchema        = "urn:ietf:params:scim:api:messages:2.0:Error"
+	ConfigSchema       = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+)
+
+// MaxResults caps the count of a list request.
+const MaxResults = 200
+
+// UserRequest is the body of POST and PUT /Users. ExternalID becomes the
+// user_id of a new user; without it an ID is generated.
+type UserRequest struct {
+	UserName   string `json:"userName" binding:"required,max=255,name"`
+	ExternalID string `json:"externalId" binding:"omitempty,max=255,id"`
+	// Active defaults to true when omitted.
+	Active *bool `json:"active"`
+}
+
+type User struct {
+	Schemas  []string   `json:"schemas"`
+	ID       string     `json:"id"`
+	UserName string     `json:"userName"`
+	Active   bool       `json:"active"`
+	Groups   []GroupRef `json:"groups,omitempty"`
+	Meta     Meta       `json:"meta"`
+}
+
+// GroupRef is the team a user belongs to.
+type GroupRef struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+// GroupRequest is the body of POST and PUT /Groups.
+type GroupRequest struct {
+	DisplayName string      `json:"displayName" binding:"required,max=255,name"`
+	Members     []MemberRef `json:"members" binding:"unique=Value,dive"`
+}
+
+type Group struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id"`
+	DisplayName string      `json:"displayName"`
+	Members     []MemberRef `json:"members"`
+	Meta        Meta        `json:"meta"`
+}
+
+// MemberRef is a user in a group; Value is the user's id.
+type MemberRef struct {
+	Value   string `json:"value" binding:"required,max=255,id"`
+	Display string `json:"display,omitempty"`
+}
+
+type Meta struct {
+	ResourceType string `json:"resourceType"`
+	Location     string `json:"location"`
+}
+
+// PatchRequest is the body of PATCH requests. Operation names are matched
+// case-insensitively since identity providers differ in spelling.
+type PatchRequest struct {
+	Operations []PatchOperation `json:"Operations" binding:"required,min=1,dive"`
+}
+
+type PatchOperation struct {
+	Op    string          `json:"op" binding:"required"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// ListParams are the query parameters of list requests. Only "eq" filters
+// on userName (Users) and displayName (Groups) are supported.
+type ListParams struct {
+	Filter     string `form:"filter"`
+	StartIndex int    `form:"startIndex"`
+	Count      *int   `form:"count"`
+}
+
+type UserList struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []User   `json:"Resources"`
+}
+
+type GroupList struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []Group  `json:"Resources"`
+}
+
+// Error is the SCIM error body; Status is the HTTP status as a string.
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+}
+
+// ServiceProviderConfig advertises the supported SCIM features.
+type ServiceProviderConfig struct {
+	Schemas               []string               `json:"schemas"`
+	Patch                 Supported              `json:"patch"`
+	Bulk                  BulkConfig             `json:"bulk"`
+	Filter                FilterConfig           `json:"filter"`
+	ChangePassword        Supported              `json:"changePassword"`
+	Sort                  Supported              `json:"sort"`
+	ETag                  Supported              `json:"etag"`
+	AuthenticationSchemes []AuthenticationScheme `json:"authenticationSchemes"`
+}
+
+type Supported struct {
+	Supported bool `json:"supported"`
+}
+
+type BulkConfig struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+type FilterConfig struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+type AuthenticationScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
@@ -1,20 +1,124 @@
 package teams
 
+import "github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/common"
+
 type CreateRequest struct {
-	TeamName string         `json:"team_name"`
-	Members  []MemberCreate `json:"members"`
+	TeamName string         `json:"team_name" binding:"required,max=255,name"`
+	Members  []MemberCreate `json:"members" binding:"required,min=1,unique=UserID,dive"`
+	// Upsert updates an existing team instead of failing with TEAM_EXISTS.
+	Upsert bool `json:"upsert"`
+	// AllowMove moves members that belong to another team instead of
+	// failing with MEMBER_CONFLICT.
+	AllowMove bool `json:"allow_move"`
 }
 
 type MemberCreate struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
+	UserID   string `json:"user_id" binding:"required,max=255,id"`
+	Username string `json:"username" binding:"required,max=255,name"`
 	IsActive bool   `json:"is_active"`
 }
 
 type GetParams struct {
-	TeamName string `form:"team_name"`
+	TeamName string `form:"team_name" binding:"required,max=255,name"`
 }
 
 type BulkDeactivateRequest struct {
-    TeamName string `json:"team_name"`
-}
\ No newline at end of file
+    TeamName string `json:"team_name" binding:"required,max=255,name"`
+}
+
+type PathParams struct {
+	TeamName string `uri:"name" binding:"required,max=255,name"`
+}
+
+type AddMembersRequest struct {
+	TeamName string         `json:"team_name" binding:"required,max=255,name"`
+	Members  []MemberCreate `json:"members" binding:"required,min=1,unique=UserID,dive"`
+}
+
+type RemoveMembersRequest struct {
+	TeamName string   `json:"team_name" binding:"required,max=255,name"`
+	UserIDs  []string `json:"user_ids" binding:"required,min=1,unique,dive,required,max=255,id"`
+}
+
+type RenameRequest struct {
+	TeamName    string `json:"team_name" binding:"required,max=255,name"`
+	NewTeamName string `json:"new_team_name" binding:"required,max=255,name"`
+}
+
+type DeleteRequest struct {
+	TeamName string `json:"team_name" binding:"required,max=255,name"`
+}
+
+// MembersV2Request is the body of POST /api/v2/teams/:name/members.
+type MembersV2Request struct {
+	Members []MemberCreate `json:"members" binding:"required,min=1,unique=UserID,dive"`
+}
+
+// RenameV2Request is the body of PATCH /api/v2/teams/:name.
+type RenameV2Request struct {
+	TeamName string `json:"team_name" binding:"required,max=255,name"`
+}
+
+type MemberPathParams struct {
+	TeamName string `uri:"name" binding:"required,max=255,name"`
+	UserID   string `uri:"user_id" binding:"required,max=255,id"`
+}
+
+// SyncRequest is the full desired state of the roster: teams and members
+// missing from it are removed.
+type SyncRequest struct {
+	Teams  []SyncTeam `json:"teams" binding:"required,unique=TeamName,dive"`
+	DryRun bool       `json:"dry_run"`
+}
+
+// SyncTeam is one team of the roster. Its review limits replace the team
+// defaults; omitted limits mean no limit.
+type SyncTeam struct {
+	TeamName string `json:"team_name" binding:"required,max=255,name"`
+	common.ReviewLimits
+	Members []SyncMember `json:"members" binding:"unique=UserID,dive"`
+}
+
+type SyncMember struct {
+	UserID   string `json:"user_id" binding:"required,max=255,id"`
+	Username string `json:"username" binding:"required,max=255,name"`
+	// IsActive defaults to true when omitted.
+	IsActive *bool `json:"is_active"`
+}
+
+// Import modes: an atomic import applies nothing if any row is rejected, a
+// best-effort import skips rejected rows and applies the rest.
+const (
+	ImportAtomic     = "atomic"
+	ImportBestEffort = "best_effort"
+)
+
+// ImportParams are the query parameters of POST /team/import; the body is
+// the CSV file itself.
+type ImportParams struct {
+	Mode      string `form:"mode" binding:"omitempty,oneof=atomic best_effort"`
+	AllowMove bool   `form:"allow_move"`
+}
+
+// ImportRow is one data row of an import file. Line is its line number in
+// the file, the header being line 1.
+type ImportRow struct {
+	Line     int    `json:"line"`
+	TeamName string `json:"team_name" binding:"required,max=255,name"`
+	UserID   string `json:"user_id" binding:"required,max=255,id"`
+	Username string `json:"username" binding:"required,max=255,name"`
+	IsActive bool   `json:"is_active"`
+}
+
+// ImportRequest is a parsed import file whose rows passed field validation.
+type ImportRequest struct {
+	Rows      []ImportRow
+	Mode      string
+	AllowMove bool
+}
+
+// ReviewLimitsRequest replaces the team defaults.
+type ReviewLimitsRequest struct {
+	TeamName string `json:"team_name" binding:"required,max=255,name"`
+	common.ReviewLimits
+}
@@ -1,7 +1,16 @@
 package teams
 
+import "github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/common"
+
 type CreateResponse struct {
 	Team TeamResponse `json:"team"`
+	// Reassignments lists open reviews released by members moved with allow_move.
+	Reassignments []common.ReviewerChange `json:"reassignments,omitempty"`
+}
+
+type BulkDeactivateResponse struct {
+	Message string `json:"message"`
+	Team    string `json:"team"`
 }
 
 type TeamResponse struct {
@@ -9,8 +18,101 @@ type TeamResponse struct {
 	Members  []MemberResponse `json:"members"`
 }
 
+type ListResponse struct {
+	Teams []TeamSummary `json:"teams"`
+}
+
+type TeamSummary struct {
+	TeamName      string `json:"team_name"`
+	Members       int    `json:"members"`
+	ActiveMembers int    `json:"active_members"`
+}
+
 type MemberResponse struct {
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
 	IsActive bool   `json:"is_active"`
 }
+
+type RemoveMembersResponse struct {
+	Team          TeamResponse            `json:"team"`
+	Reassignments []common.ReviewerChange `json:"reassignments"`
+}
+
+type DeleteResponse struct {
+	Message string `json:"message"`
+	Team    string `json:"team"`
+}
+
+// SyncResponse is the plan computed for a SyncRequest. TeamsUpdated are
+// existing teams whose review limits change. Reassignments are only known
+// once the plan is applied.
+type SyncResponse struct {
+	DryRun        bool                    `json:"dry_run"`
+	TeamsCreated  []string                `json:"teams_created"`
+	TeamsUpdated  []string                `json:"teams_updated"`
+	TeamsRemoved  []string                `json:"teams_removed"`
+	UsersAdded    []SyncUserChange        `json:"users_added"`
+	UsersMoved    []SyncUserChange        `json:"users_moved"`
+	UsersUpdated  []SyncUserChange        `json:"users_updated"`
+	UsersRemoved  []SyncUserChange        `json:"users_removed"`
+	Reassignments []common.ReviewerChange `json:"reassignments"`
+}
+
+// SyncUserChange is one user in a sync plan. TeamName is the team the user
+// ends up in, or the team they leave for removed users.
+type SyncUserChange struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	TeamName string `json:"team_name"`
+	FromTeam string `json:"from_team,omitempty"`
+	IsActive bool   `json:"is_active"`
+}
+
+// ImportResponse summarizes an import. Teams are the teams created or
+// updated and Imported counts the rows applied.
+type ImportResponse struct {
+	Mode          string                  `json:"mode"`
+	Teams         []string                `json:"teams"`
+	Imported      int                     `json:"imported"`
+	Skipped       []ImportError           `json:"skipped"`
+	Reassignments []common.ReviewerChange `json:"reassignments"`
+}
+
+// ImportError explains why a row of an import file was rejected; Field is
+// empty when the row as a whole is at fault.
+type ImportError struct {
+	Line   int    `json:"line"`
+	Field  string `json:"field,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// ExportResponse is the JSON form of GET /team/export.
+type ExportResponse struct {
+	Teams []TeamResponse `json:"teams"`
+}
+
+type ReviewLimitsResponse struct {
+	TeamName string `json:"team_name"`
+	common.ReviewLimits
+}
+
+// ReviewLoadResponse lists the team defaults and every member's open reviews
+// against their effective limits.
+type ReviewLoadResponse struct {
+	TeamName string `json:"team_name"`
+	common.ReviewLimits
+	Members []MemberLoad `json:"members"`
+}
+
+type MemberLoad struct {
+	UserID      string `json:"user_id"`
+	Username    string `json:"username"`
+	IsActive    bool   `json:"is_active"`
+	OpenReviews int    `json:"open_reviews"`
+	common.ReviewLimits
+	CooldownUntil *string `json:"cooldown_until,omitempty"`
+	Away          bool    `json:"away"`
+	// Available reports whether the member can be picked as a reviewer now.
+	Available bool `json:"available"`
+}
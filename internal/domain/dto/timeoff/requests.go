@@ -0,0 +1,37 @@
+package timeoff
+
+import "time"
+
+type CreateRequest struct {
+	UserID          string    `json:"user_id" binding:"required,max=255,id"`
+	StartsAt        time.Time `json:"starts_at" binding:"required"`
+	EndsAt          time.Time `json:"ends_at" binding:"required,gtfield=StartsAt"`
+	Reason          string    `json:"reason" binding:"max=255"`
+	ReassignReviews bool      `json:"reassign_reviews"`
+}
+
+// CreateV2Request is the body of POST /api/v2/users/:id/time-off.
+type CreateV2Request struct {
+	StartsAt        time.Time `json:"starts_at" binding:"required"`
+	EndsAt          time.Time `json:"ends_at" binding:"required,gtfield=StartsAt"`
+	Reason          string    `json:"reason" binding:"max=255"`
+	ReassignReviews bool      `json:"reassign_reviews"`
+}
+
+type ListParams struct {
+	UserID string `form:"user_id" binding:"required,max=255,id"`
+}
+
+type CancelRequest struct {
+	UserID    string `json:"user_id" binding:"required,max=255,id"`
+	TimeOffID int64  `json:"time_off_id" binding:"required,min=1"`
+}
+
+type PathParams struct {
+	UserID    string `uri:"id" binding:"required,max=255,id"`
+	TimeOffID int64  `uri:"time_off_id" binding:"required,min=1"`
+}
+
+type AwayParams struct {
+	TeamName string `form:"team_name" binding:"required,max=255,name"`
+}
@@ -0,0 +1,39 @@
+package timeoff
+
+type TimeOffResponse struct {
+	TimeOffID       int64  `json:"time_off_id"`
+	UserID          string `json:"user_id"`
+	StartsAt        string `json:"starts_at"`
+	EndsAt          string `json:"ends_at"`
+	Reason          string `json:"reason,omitempty"`
+	ReassignReviews bool   `json:"reassign_reviews"`
+}
+
+type CreateResponse struct {
+	TimeOff TimeOffResponse `json:"time_off"`
+}
+
+// ListResponse holds the current and upcoming periods of a user.
+type ListResponse struct {
+	UserID  string            `json:"user_id"`
+	TimeOff []TimeOffResponse `json:"time_off"`
+}
+
+type AwayResponse struct {
+	TeamName string       `json:"team_name"`
+	Away     []AwayMember `json:"away"`
+}
+
+// AwayMember is a team member on time off until EndsAt.
+type AwayMember struct {
+	UserID    string `json:"user_id"`
+	Username  string `json:"username"`
+	TimeOffID int64  `json:"time_off_id"`
+	EndsAt    string `json:"ends_at"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+type CancelResponse struct {
+	Message   string `json:"message"`
+	TimeOffID int64  `json:"time_off_id"`
+}
@@ -1,15 +1,56 @@
 package users
 
+import "github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/common"
+
 type SetActiveRequest struct {
-	UserID   string `json:"user_id"`
+	UserID   string `json:"user_id" binding:"required,max=255,id"`
 	IsActive bool   `json:"is_active"`
 }
 
 type ReviewParams struct {
-	UserID string `form:"user_id"`
+	UserID string `form:"user_id" binding:"required,max=255,id"`
 }
 
 type DeactivateRequest struct {
-	TeamName string   `json:"team_name"`
-	UserIDs  []string `json:"user_ids"`
+	TeamName string   `json:"team_name" binding:"required,max=255,name"`
+	UserIDs  []string `json:"user_ids" binding:"required,min=1,unique,dive,required,max=255,id"`
+}
+
+type PathParams struct {
+	UserID string `uri:"id" binding:"required,max=255,id"`
+}
+
+type PatchRequest struct {
+	IsActive *bool `json:"is_active" binding:"required"`
+}
+
+type MoveRequest struct {
+	UserID   string `json:"user_id" binding:"required,max=255,id"`
+	TeamName string `json:"team_name" binding:"required,max=255,name"`
+}
+
+// ReviewLimitsRequest replaces the user's own limits.
+type ReviewLimitsRequest struct {
+	UserID string `json:"user_id" binding:"required,max=255,id"`
+	common.ReviewLimits
+}
+
+// SkillsRequest replaces the user's skills; an empty list clears them.
+type SkillsRequest struct {
+	UserID string   `json:"user_id" binding:"required,max=255,id"`
+	Skills []string `json:"skills" binding:"required,max=50,unique,dive,required,max=64,skill"`
+}
+
+type SkillsParams struct {
+	UserID string `form:"user_id" binding:"required,max=255,id"`
+}
+
+// SkillsV2Request is the body of PUT /api/v2/users/:id/skills.
+type SkillsV2Request struct {
+	Skills []string `json:"skills" binding:"required,max=50,unique,dive,required,max=64,skill"`
+}
+
+// MoveV2Request is the body of PUT /api/v2/users/:id/team.
+type MoveV2Request struct {
+	TeamName string `json:"team_name" binding:"required,max=255,name"`
 }
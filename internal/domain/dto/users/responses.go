@@ -1,5 +1,7 @@
 package users
 
+import "github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/common"
+
 type SetActiveResponse struct {
 	User UserResponse `json:"user"`
 }
@@ -22,3 +24,23 @@ type PullRequestShort struct {
 	AuthorID        string `json:"author_id"`
 	Status          string `json:"status"`
 }
+
+type MoveResponse struct {
+	User          UserResponse            `json:"user"`
+	Reassignments []common.ReviewerChange `json:"reassignments"`
+}
+
+type ReviewLimitsResponse struct {
+	UserID string `json:"user_id"`
+	common.ReviewLimits
+}
+
+type SkillsResponse struct {
+	UserID string   `json:"user_id"`
+	Skills []string `json:"skills"`
+}
+
+// ExportResponse is the JSON form of GET /users/export.
+type ExportResponse struct {
+	Users []UserResponse `json:"users"`
+}
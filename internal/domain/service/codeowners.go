@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/codeowners"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/models"
+	"github.com/IlyaAGL/avito_autumn_2025/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type CodeOwnersRepository interface {
+	ReplaceRules(ctx context.Context, repository string, rules []models.CodeOwnerRule) error
+	ListRules(ctx context.Context, repository string) ([]models.CodeOwnerRule, error)
+	MissingOwners(ctx context.Context, userIDs, teamNames []string) ([]string, []string, error)
+}
+
+// CodeOwnersService stores the CODEOWNERS rules of each repository. Reviewer
+// selection reads them through the pull request service.
+type CodeOwnersService struct {
+	ownersRepo CodeOwnersRepository
+}
+
+func NewCodeOwnersService(ownersRepo CodeOwnersRepository) *CodeOwnersService {
+	return &CodeOwnersService{ownersRepo: ownersRepo}
+}
+
+// SetRules replaces the rules of the repository. Owners that name no user or
+// team are reported but kept, so the file can be uploaded before everyone is
+// provisioned.
+func (s *CodeOwnersService) SetRules(ctx context.Context, req codeowners.SetRequest) (_ *codeowners.SetResponse, err error) {
+	ctx, span := tracer.Start(ctx, "CodeOwnersService.SetRules", trace.WithAttributes(
+		attribute.String("repository", req.Repository),
+		attribute.Int("rules", len(req.Rules)),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	rules := make([]models.CodeOwnerRule, len(req.Rules))
+	var owners []string
+
+	for i, rule := range req.Rules {
+		if _, err := compileOwnerPattern(rule.Pattern); err != nil {
+			return nil, fmt.Errorf("line %d: pattern %q: %w", rule.Line, rule.Pattern, models.ErrInvalidValue)
+		}
+
+		rules[i] = models.CodeOwnerRule{
+			Line:    rule.Line,
+			Pattern: rule.Pattern,
+			Owners:  rule.Owners,
+		}
+		owners = append(owners, rule.Owners...)
+	}
+
+	slices.Sort(owners)
+	owners = slices.Compact(owners)
+
+	userIDs, teamNames, unresolved := splitOwners(owners)
+
+	missingUsers, missingTeams, err := s.ownersRepo.MissingOwners(ctx, userIDs, teamNames)
+	if err != nil {
+		return nil, err
+	}
+
+	unknown := unresolved
+	for _, owner := range owners {
+		name := strings.TrimPrefix(owner, "@")
+		if _, team, ok := strings.Cut(name, "/"); ok {
+			if slices.Contains(missingTeams, team) {
+				unknown = append(unknown, owner)
+			}
+		} else if slices.Contains(missingUsers, name) {
+			unknown = append(unknown, owner)
+		}
+	}
+
+	slices.Sort(unknown)
+
+	if err := s.ownersRepo.ReplaceRules(ctx, req.Repository, rules); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "code owners replaced",
+		"repository", req.Repository,
+		"rules", len(rules),
+		"unknown_owners", len(unknown),
+	)
+
+	if unknown == nil {
+		unknown = []string{}
+	}
+
+	return &codeowners.SetResponse{
+		Repository:    req.Repository,
+		Rules:         len(rules),
+		UnknownOwners: unknown,
+	}, nil
+}
+
+// GetRules returns the rules of the repository; a repository without an
+// uploaded file has none.
+func (s *CodeOwnersService) GetRules(ctx context.Context, repository string) (_ *codeowners.RulesResponse, err error) {
+	ctx, span := tracer.Start(ctx, "CodeOwnersService.GetRules", trace.WithAttributes(
+		attribute.String("repository", repository),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	rules, err := s.ownersRepo.ListRules(ctx, repository)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]codeowners.RuleResponse, len(rules))
+	for i, rule := range rules {
+		responses[i] = codeowners.RuleResponse{
+			Line:    rule.Line,
+			Pattern: rule.Pattern,
+			Owners:  rule.Owners,
+		}
+	}
+
+	return &codeowners.RulesResponse{
+		Repository: repository,
+		Rules:      responses,
+	}, nil
+}
+
+// triggeredRules returns, in file order, the rules that decide the owners of
+// files. As in CODEOWNERS, the last matching rule wins; paths it leaves
+// without owners trigger nothing.
+func triggeredRules(rules []models.CodeOwnerRule, files []string) ([]models.CodeOwnerRule, error) {
+	patterns := make([]*regexp.Regexp, len(rules))
+	for i, rule := range rules {
+		pattern, err := compileOwnerPattern(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", rule.Line, err)
+		}
+		patterns[i] = pattern
+	}
+
+	triggered := make([]bool, len(rules))
+	for _, file := range files {
+		file = strings.TrimPrefix(path.Clean("/"+file), "/")
+
+		for i := len(patterns) - 1; i >= 0; i-- {
+			if patterns[i].MatchString(file) {
+				triggered[i] = len(rules[i].Owners) > 0
+				break
+			}
+		}
+	}
+
+	var result []models.CodeOwnerRule
+	for i, rule := range rules {
+		if triggered[i] {
+			result = append(result, rule)
+		}
+	}
+
+	return result, nil
+}
+
+// splitOwners sorts CODEOWNERS owners into user IDs ("@user_id"), team names
+// ("@org/team_name") and owners that name neither, such as emails.
+func splitOwners(owners []string) (userIDs, teamNames, unresolved []string) {
+	userIDs, teamNames = []string{}, []string{}
+
+	for _, owner := range owners {
+		name, ok := strings.CutPrefix(owner, "@")
+		if !ok || name == "" {
+			unresolved = append(unresolved, owner)
+			continue
+		}
+
+		if _, team, ok := strings.Cut(name, "/"); ok {
+			teamNames = append(teamNames, team)
+			continue
+		}
+
+		userIDs = append(userIDs, name)
+	}
+
+	return userIDs, teamNames, unresolved
+}
+
+// compileOwnerPattern turns a CODEOWNERS pattern into a regexp over paths
+// relative to the repository root. A pattern with a slash before its end is
+// anchored to the root, otherwise it matches at any depth. "*" and "?" stay
+// within a directory and "**" crosses them. A pattern naming a directory
+// matches everything below it, except that a trailing "dir/*" only matches
+// the files directly in dir.
+func compileOwnerPattern(pattern string) (*regexp.Regexp, error) {
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+
+	p := strings.TrimPrefix(pattern, "/")
+	dirOnly := strings.HasSuffix(p, "/")
+	p = strings.TrimSuffix(p, "/")
+	if p == "" {
+		return nil, fmt.Errorf("pattern %q matches nothing", pattern)
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(?:.*/)?")
+	}
+
+	for i := 0; i < len(p); {
+		switch {
+		case strings.HasPrefix(p[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 3
+		case strings.HasPrefix(p[i:], "**"):
+			b.WriteString(".*")
+			i += 2
+		case p[i] == '*':
+			b.WriteString("[^/]*")
+			i++
+		case p[i] == '?':
+			b.WriteString("[^/]")
+			i++
+		default:
+			r, size := utf8.DecodeRuneInString(p[i:])
+			b.WriteString(regexp.QuoteMeta(string(r)))
+			i += size
+		}
+	}
+
+	last := p[strings.LastIndex(p, "/")+1:]
+	switch {
+	case dirOnly:
+		b.WriteString("/.*$")
+	case strings.Contains(last, "*") && last != "**":
+		b.WriteString("$")
+	default:
+		b.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.Compile(b.String())
+}
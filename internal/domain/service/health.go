@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/health"
+)
+
+type HealthRepository interface {
+	Ping(ctx context.Context) error
+	SchemaVersion(ctx context.Context) (version uint, dirty bool, err error)
+}
+
+const (
+	statusOK   = "ok"
+	statusFail = "fail"
+
+	readinessCheckTimeout = 2 * time.Second
+)
+
+type HealthService struct {
+	healthRepo      HealthRepository
+	expectedVersion uint
+	shuttingDown    atomic.Bool
+}
+
+func NewHealthService(healthRepo HealthRepository, expectedVersion uint) *HealthService {
+	return &HealthService{
+		healthRepo:      healthRepo,
+		expectedVersion: expectedVersion,
+	}
+}
+
+// MarkShuttingDown makes every following readiness check fail so load
+// balancers stop routing traffic before the server shuts down.
+func (s *HealthService) MarkShuttingDown() {
+	s.shuttingDown.Store(true)
+}
+
+func (s *HealthService) Liveness() *health.LivenessResponse {
+	return &health.LivenessResponse{Status: statusOK}
+}
+
+func (s *HealthService) Readiness(ctx context.Context) (*health.ReadinessResponse, bool) {
+	ctx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+	defer cancel()
+
+	checks := map[string]health.Check{
+		"shutdown": toCheck(s.checkShutdown()),
+		"database": toCheck(s.healthRepo.Ping(ctx)),
+		"schema":   toCheck(s.checkSchema(ctx)),
+	}
+
+	ready := true
+	for _, check := range checks {
+		if check.Status != statusOK {
+			ready = false
+		}
+	}
+
+	status := statusOK
+	if !ready {
+		status = statusFail
+	}
+
+	return &health.ReadinessResponse{
+		Status: status,
+		Checks: checks,
+	}, ready
+}
+
+func (s *HealthService) checkShutdown() error {
+	if s.shuttingDown.Load() {
+		return errors.New("server is shutting down")
+	}
+
+	return nil
+}
+
+func (s *HealthService) checkSchema(ctx context.Context) error {
+	version, dirty, err := s.healthRepo.SchemaVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	if dirty {
+		return fmt.Errorf("schema version %d is dirty", version)
+	}
+
+	if version < s.expectedVersion {
+		return fmt.Errorf("schema version %d is behind the expected %d", version, s.expectedVersion)
+	}
+
+	return nil
+}
+
+func toCheck(err error) health.Check {
+	if err != nil {
+		return health.Check{Status: statusFail, Error: err.Error()}
+	}
+
+	return health.Check{Status: statusOK}
+}
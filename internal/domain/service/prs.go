@@ -1,80 +1,254 @@
 package service
 
 import (
+	"cmp"
 	"context"
+	"crypto/rand"
 	"errors"
 	"fmt"
+	"log/slog"
 	"math/big"
-	"crypto/rand"
 	"slices"
 	"time"
 
 	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/common"
 	pullrequests "github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/prs"
 	"github.com/IlyaAGL/avito_autumn_2025/internal/models"
+	"github.com/IlyaAGL/avito_autumn_2025/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type PullRequestRepository interface {
 	CreatePR(ctx context.Context, pr *models.PullRequest) error
 	GetPR(ctx context.Context, prID string) (*models.PullRequest, error)
+	GetPRByNumber(ctx context.Context, repository string, number int) (*models.PullRequest, error)
+	ListPRs(ctx context.Context, filter models.PullRequestFilter) ([]models.PullRequest, error)
 	PRExists(ctx context.Context, prID string) (bool, error)
 	MergePR(ctx context.Context, prID string) error
-	UpdatePRReviewers(ctx context.Context, prID string, reviewerIDs []string) error
+	UpdatePRReviewers(ctx context.Context, prID string, reviewerIDs []string, ownerRules map[string]string) error
 	GetReviewStats(ctx context.Context) ([]models.ReviewStats, error)
+	GetGroupStats(ctx context.Context) ([]models.PRGroupStats, error)
+}
+
+// ReassignmentMetrics counts reviews assigned and handed over, including those
+// the repositories reassign when reviewers leave a team or go on time off.
+type ReassignmentMetrics interface {
+	ReviewAssigned(userID string)
+	Reassignment(outcome string)
 }
 
+type PullRequestMetrics interface {
+	ReassignmentMetrics
+	PRUnderstaffed()
+	PRMerged(timeToMerge time.Duration)
+}
+
+const (
+	reassignmentSuccess     = "success"
+	reassignmentNoCandidate = "no_candidate"
+)
+
+// createAttempts is how many times CreatePR picks reviewers when the ones
+// it picked fill up before the PR is inserted.
+const createAttempts = 3
+
 type pullRequestService struct {
-	prRepo   PullRequestRepository
-	userRepo UserRepository
-	teamRepo TeamRepository
+	prRepo     PullRequestRepository
+	userRepo   UserRepository
+	teamRepo   TeamRepository
+	ownersRepo CodeOwnersRepository
+	reposRepo  RepositoryRepository
+	metrics    PullRequestMetrics
+	// policy is the review policy of PRs outside repositories and the
+	// defaults of repositories without overrides.
+	policy models.ReviewPolicy
 }
 
-func NewPullRequestService(prRepo PullRequestRepository, userRepo UserRepository, teamRepo TeamRepository) *pullRequestService {
+func NewPullRequestService(prRepo PullRequestRepository, userRepo UserRepository, teamRepo TeamRepository, ownersRepo CodeOwnersRepository, reposRepo RepositoryRepository, metrics PullRequestMetrics, policy models.ReviewPolicy) *pullRequestService {
 	return &pullRequestService{
-		prRepo:   prRepo,
-		userRepo: userRepo,
-		teamRepo: teamRepo,
+		prRepo:     prRepo,
+		userRepo:   userRepo,
+		teamRepo:   teamRepo,
+		ownersRepo: ownersRepo,
+		reposRepo:  reposRepo,
+		metrics:    metrics,
+		policy:     policy,
 	}
 }
 
-func (s *pullRequestService) CreatePR(ctx context.Context, req pullrequests.CreateRequest) (*pullrequests.CreateResponse, error) {
-	author, err := s.userRepo.GetUser(ctx, req.AuthorID)
-	if err != nil {
-		return nil, fmt.Errorf("author not found: %w", err)
+func (s *pullRequestService) CreatePR(ctx context.Context, req pullrequests.CreateRequest) (_ *pullrequests.CreateResponse, err error) {
+	ctx, span := tracer.Start(ctx, "pullRequestService.CreatePR", trace.WithAttributes(
+		attribute.String("pull_request_id", req.PullRequestID),
+		attribute.String("author_id", req.AuthorID),
+		attribute.String("priority", req.Priority),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	priority := req.Priority
+	if priority == "" {
+		priority = models.PriorityNormal
 	}
 
-	teamMembers, err := s.userRepo.GetActiveTeamMembers(ctx, author.TeamName, []string{req.AuthorID})
-	if err != nil {
-		return nil, fmt.Errorf("failed to get team members: %w", err)
+	filesChanged := req.FilesChanged
+	if filesChanged == nil && len(req.ChangedFiles) > 0 {
+		files := len(req.ChangedFiles)
+		filesChanged = &files
 	}
 
-	reviewers := s.selectRandomReviewers(teamMembers, 2)
+	size := models.PRSize(linesChanged(req.LinesAdded, req.LinesRemoved), filesChanged)
 
-	reviewerIDs := make([]string, len(reviewers))
-	for i, reviewer := range reviewers {
-		reviewerIDs[i] = reviewer.UserID
+	author, err := s.userRepo.GetUser(ctx, req.AuthorID)
+	if err != nil {
+		return nil, fmt.Errorf("author %s: %w", req.AuthorID, err)
 	}
 
-	pr := &models.PullRequest{
-		ID:                req.PullRequestID,
-		Name:              req.PullRequestName,
-		AuthorID:          req.AuthorID,
-		Status:            "OPEN",
-		AssignedReviewers: reviewerIDs,
-		CreatedAt:         time.Now(),
+	// Reviewers come from the owning team of the repository, if it has one,
+	// and from the author's team otherwise.
+	policy := s.policy
+	reviewTeam := author.TeamName
+	if req.Repository != "" {
+		repository, err := s.reposRepo.GetRepository(ctx, req.Repository)
+		if err != nil {
+			return nil, err
+		}
+
+		policy = s.policy.For(repository)
+		if repository.TeamName != "" {
+			reviewTeam = repository.TeamName
+		}
 	}
 
-	err = s.prRepo.CreatePR(ctx, pr)
+	policy = policy.ForPR(size, priority)
+
+	// The repository checks the reviewers' capacity again under a lock when
+	// it inserts the PR. A reviewer who filled up since they were picked
+	// fails the insert, and the reviewers are picked again without them.
+	var (
+		matches     []models.ReviewerMatch
+		reviewerIDs []string
+		pr          *models.PullRequest
+	)
+	for attempt := 1; ; attempt++ {
+		matches, err = s.pickReviewers(ctx, req, reviewTeam, policy)
+		if err != nil {
+			return nil, err
+		}
+
+		reviewerIDs = make([]string, len(matches))
+		ownerRules := make(map[string]string)
+		for i, match := range matches {
+			reviewerIDs[i] = match.UserID
+			if match.Reason == models.MatchOwner {
+				ownerRules[match.UserID] = match.Rule
+			}
+		}
+
+		pr = &models.PullRequest{
+			ID:                req.PullRequestID,
+			Name:              req.PullRequestName,
+			AuthorID:          req.AuthorID,
+			Status:            "OPEN",
+			AssignedReviewers: reviewerIDs,
+			Tags:              req.Tags,
+			Repository:        req.Repository,
+			Number:            req.Number,
+			RequiredReviewers: policy.Reviewers,
+			LinesAdded:        req.LinesAdded,
+			LinesRemoved:      req.LinesRemoved,
+			FilesChanged:      filesChanged,
+			Size:              size,
+			Priority:          priority,
+			OwnerRules:        ownerRules,
+			CreatedAt:         time.Now(),
+		}
+
+		err = s.prRepo.CreatePR(ctx, pr)
+		if !errors.Is(err, models.ErrReviewerBusy) || attempt == createAttempts {
+			break
+		}
+
+		slog.InfoContext(ctx, "reviewer filled up, picking reviewers again",
+			"pull_request_id", req.PullRequestID,
+			"attempt", attempt,
+			"error", err,
+		)
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	span.SetAttributes(attribute.StringSlice("reviewer_ids", reviewerIDs))
+
+	for _, reviewerID := range reviewerIDs {
+		s.metrics.ReviewAssigned(reviewerID)
+	}
+
+	if len(reviewerIDs) < pr.RequiredReviewers {
+		s.metrics.PRUnderstaffed()
+
+		slog.WarnContext(ctx, "pull request understaffed",
+			"pull_request_id", pr.ID,
+			"reviewers", len(reviewerIDs),
+			"required", pr.RequiredReviewers,
+		)
+	}
+
+	slog.InfoContext(ctx, "pull request created",
+		"pull_request_id", pr.ID,
+		"author_id", pr.AuthorID,
+		"repository", pr.Repository,
+		"team_name", reviewTeam,
+		"strategy", policy.Strategy,
+		"size", pr.Size,
+		"priority", pr.Priority,
+		"reviewers", reviewerIDs,
+		"code_owners", len(pr.OwnerRules),
+		"tags", pr.Tags,
+	)
+
+	response := s.prToResponse(pr)
+	response.ReviewerMatches = matchesToResponse(matches)
+
 	return &pullrequests.CreateResponse{
-		PR: s.prToResponse(pr),
+		PR: response,
 	}, nil
 }
 
-func (s *pullRequestService) MergePR(ctx context.Context, req pullrequests.MergeRequest) (*pullrequests.MergeResponse, error) {
+// pickReviewers picks the code owners of the changed files and fills the
+// seats they leave from the review team by the policy.
+func (s *pullRequestService) pickReviewers(ctx context.Context, req pullrequests.CreateRequest, reviewTeam string, policy models.ReviewPolicy) ([]models.ReviewerMatch, error) {
+	owners, err := s.selectOwners(ctx, req.Repository, req.ChangedFiles, req.AuthorID, policy.Strategy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select code owners: %w", err)
+	}
+
+	excludeIDs := []string{req.AuthorID}
+	for _, owner := range owners {
+		excludeIDs = append(excludeIDs, owner.UserID)
+	}
+
+	teamMembers, err := s.userRepo.GetActiveTeamMembers(ctx, reviewTeam, excludeIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team members: %w", err)
+	}
+
+	// Owners may take more seats than the policy asks for; the team
+	// strategy only fills the seats left.
+	others, err := s.selectReviewers(ctx, teamMembers, req.Tags, max(policy.Reviewers-len(owners), 0), policy.MinExperts, policy.Strategy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select reviewers: %w", err)
+	}
+
+	return append(owners, others...), nil
+}
+
+func (s *pullRequestService) MergePR(ctx context.Context, req pullrequests.MergeRequest) (_ *pullrequests.MergeResponse, err error) {
+	ctx, span := tracer.Start(ctx, "pullRequestService.MergePR", trace.WithAttributes(
+		attribute.String("pull_request_id", req.PullRequestID),
+	))
+	defer func() { tracing.End(span, err) }()
+
 	pr, err := s.prRepo.GetPR(ctx, req.PullRequestID)
 	if err != nil {
 		return nil, err
@@ -96,23 +270,35 @@ func (s *pullRequestService) MergePR(ctx context.Context, req pullrequests.Merge
 		return nil, err
 	}
 
+	if mergedPR.MergedAt != nil {
+		s.metrics.PRMerged(mergedPR.MergedAt.Sub(mergedPR.CreatedAt))
+	}
+
+	slog.InfoContext(ctx, "pull request merged", "pull_request_id", mergedPR.ID)
+
 	return &pullrequests.MergeResponse{
 		PR: s.prToResponse(mergedPR),
 	}, nil
 }
 
-func (s *pullRequestService) ReassignReviewer(ctx context.Context, req pullrequests.ReassignRequest) (*pullrequests.ReassignResponse, error) {
+func (s *pullRequestService) ReassignReviewer(ctx context.Context, req pullrequests.ReassignRequest) (_ *pullrequests.ReassignResponse, err error) {
+	ctx, span := tracer.Start(ctx, "pullRequestService.ReassignReviewer", trace.WithAttributes(
+		attribute.String("pull_request_id", req.PullRequestID),
+		attribute.String("old_reviewer_id", req.OldUserID),
+	))
+	defer func() { tracing.End(span, err) }()
+
 	pr, err := s.prRepo.GetPR(ctx, req.PullRequestID)
 	if err != nil {
 		return nil, err
 	}
 
 	if pr.Status == "MERGED" {
-		return nil, errors.New("cannot reassign on merged PR")
+		return nil, models.ErrPRMerged
 	}
 
 	if !slices.Contains(pr.AssignedReviewers, req.OldUserID) {
-		return nil, errors.New("reviewer is not assigned to this PR")
+		return nil, models.ErrNotAssigned
 	}
 
 	oldReviewer, err := s.userRepo.GetUser(ctx, req.OldUserID)
@@ -123,23 +309,77 @@ func (s *pullRequestService) ReassignReviewer(ctx context.Context, req pullreque
 	excludeIDs := pr.AssignedReviewers
 	excludeIDs = append(excludeIDs, pr.AuthorID)
 
-	teamMembers, err := s.userRepo.GetActiveTeamMembers(ctx, oldReviewer.TeamName, excludeIDs)
-	if err != nil {
-		return nil, err
-	}
+	// The replacement comes from the owning team of the repository, as the
+	// reviewers of CreatePR do, and from the old reviewer's team otherwise.
+	policy := s.policy
+	reviewTeam := oldReviewer.TeamName
+	if pr.Repository != "" {
+		repository, err := s.reposRepo.GetRepository(ctx, pr.Repository)
+		if err != nil {
+			return nil, err
+		}
 
-	if len(teamMembers) == 0 {
-		return nil, errors.New("no active replacement candidate in team")
+		policy = s.policy.For(repository)
+		if repository.TeamName != "" {
+			reviewTeam = repository.TeamName
+		}
 	}
 
-	max := big.NewInt(int64(len(teamMembers)))
+	policy = policy.ForPR(pr.Size, pr.Priority)
 
-	n, err := rand.Int(rand.Reader, max)
+	// A code owner is replaced by another owner of the same rule when one is
+	// available, everyone else by the team strategy.
+	matches, err := s.replaceOwner(ctx, pr, req.OldUserID, excludeIDs, policy.Strategy)
 	if err != nil {
 		return nil, err
 	}
 
-	newReviewer := teamMembers[n.Int64()]
+	if len(matches) == 0 {
+		teamMembers, err := s.userRepo.GetActiveTeamMembers(ctx, reviewTeam, excludeIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(teamMembers) == 0 {
+			s.metrics.Reassignment(reassignmentNoCandidate)
+
+			slog.WarnContext(ctx, "no replacement candidate for reviewer",
+				"pull_request_id", pr.ID,
+				"old_reviewer_id", req.OldUserID,
+				"team_name", reviewTeam,
+			)
+
+			return nil, models.ErrNoCandidate
+		}
+
+		// The replacement takes an expert seat only if the reviewers who
+		// stay do not fill them already.
+		expertSeats := policy.MinExperts
+		if len(pr.Tags) > 0 {
+			remaining := slices.DeleteFunc(slices.Clone(pr.AssignedReviewers), func(id string) bool {
+				return id == req.OldUserID
+			})
+
+			skills, err := s.userRepo.GetSkills(ctx, remaining)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, reviewerID := range remaining {
+				if len(matchingSkills(skills[reviewerID], pr.Tags)) > 0 {
+					expertSeats--
+				}
+			}
+		}
+
+		matches, err = s.selectReviewers(ctx, teamMembers, pr.Tags, 1, expertSeats, policy.Strategy)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	newReviewer := matches[0]
+	span.SetAttributes(attribute.String("new_reviewer_id", newReviewer.UserID))
 
 	newReviewers := make([]string, len(pr.AssignedReviewers))
 	for i, reviewer := range pr.AssignedReviewers {
@@ -150,7 +390,7 @@ func (s *pullRequestService) ReassignReviewer(ctx context.Context, req pullreque
 		}
 	}
 
-	err = s.prRepo.UpdatePRReviewers(ctx, pr.ID, newReviewers)
+	err = s.prRepo.UpdatePRReviewers(ctx, pr.ID, newReviewers, map[string]string{newReviewer.UserID: newReviewer.Rule})
 	if err != nil {
 		return nil, err
 	}
@@ -160,13 +400,30 @@ func (s *pullRequestService) ReassignReviewer(ctx context.Context, req pullreque
 		return nil, err
 	}
 
+	s.metrics.Reassignment(reassignmentSuccess)
+	s.metrics.ReviewAssigned(newReviewer.UserID)
+
+	slog.InfoContext(ctx, "reviewer reassigned",
+		"pull_request_id", pr.ID,
+		"old_reviewer_id", req.OldUserID,
+		"new_reviewer_id", newReviewer.UserID,
+	)
+
+	response := s.prToResponse(updatedPR)
+	response.ReviewerMatches = matchesToResponse(matches)
+
 	return &pullrequests.ReassignResponse{
-		PR:         s.prToResponse(updatedPR),
+		PR:         response,
 		ReplacedBy: newReviewer.UserID,
 	}, nil
 }
 
-func (s *pullRequestService) GetPR(ctx context.Context, prID string) (*pullrequests.PullRequestResponse, error) {
+func (s *pullRequestService) GetPR(ctx context.Context, prID string) (_ *pullrequests.PullRequestResponse, err error) {
+	ctx, span := tracer.Start(ctx, "pullRequestService.GetPR", trace.WithAttributes(
+		attribute.String("pull_request_id", prID),
+	))
+	defer func() { tracing.End(span, err) }()
+
 	pr, err := s.prRepo.GetPR(ctx, prID)
 	if err != nil {
 		return nil, err
@@ -177,26 +434,120 @@ func (s *pullRequestService) GetPR(ctx context.Context, prID string) (*pullreque
 	return &response, nil
 }
 
-func (s *pullRequestService) GetStats(ctx context.Context) (*common.StatsResponse, error) {
+func (s *pullRequestService) GetPRByNumber(ctx context.Context, repository string, number int) (_ *pullrequests.PullRequestResponse, err error) {
+	ctx, span := tracer.Start(ctx, "pullRequestService.GetPRByNumber", trace.WithAttributes(
+		attribute.String("repository", repository),
+		attribute.Int("number", number),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	pr, err := s.prRepo.GetPRByNumber(ctx, repository, number)
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.prToResponse(pr)
+
+	return &response, nil
+}
+
+func (s *pullRequestService) ListPRs(ctx context.Context, params pullrequests.ListParams) (_ *pullrequests.ListResponse, err error) {
+	ctx, span := tracer.Start(ctx, "pullRequestService.ListPRs", trace.WithAttributes(
+		attribute.String("status", params.Status),
+		attribute.String("author_id", params.AuthorID),
+		attribute.String("repository", params.Repository),
+		attribute.Bool("understaffed", params.Understaffed),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	filter := models.PullRequestFilter{
+		Status:     params.Status,
+		AuthorID:   params.AuthorID,
+		Repository: params.Repository,
+	}
+
+	if params.Understaffed {
+		if params.Status == "MERGED" {
+			return &pullrequests.ListResponse{PullRequests: []pullrequests.PullRequestResponse{}}, nil
+		}
+
+		filter.Status = "OPEN"
+		filter.Understaffed = true
+	}
+
+	prs, err := s.prRepo.ListPRs(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]pullrequests.PullRequestResponse, len(prs))
+	for i := range prs {
+		responses[i] = s.prToResponse(&prs[i])
+	}
+
+	return &pullrequests.ListResponse{
+		PullRequests: responses,
+	}, nil
+}
+
+func (s *pullRequestService) GetStats(ctx context.Context) (_ *common.StatsResponse, err error) {
+	ctx, span := tracer.Start(ctx, "pullRequestService.GetStats")
+	defer func() { tracing.End(span, err) }()
+
     reviewStats, err := s.prRepo.GetReviewStats(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	userIDs := make([]string, len(reviewStats))
+	for i, stat := range reviewStats {
+		userIDs[i] = stat.UserID
+	}
+
+	openReviews, err := s.userRepo.GetOpenReviews(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	resultStats := make([]common.ReviewStats, 0, len(reviewStats))
 
 	for _, stat := range reviewStats {
 		resultStat := common.ReviewStats{
 			UserID: stat.UserID,
-			OpenPRs: stat.OpenPRs,
+			OpenPRs: openReviews[stat.UserID].Count(),
 			TotalPRs: stat.TotalReviews,
+			OpenLoad: openReviews[stat.UserID].Load(),
 		}
 
 		resultStats = append(resultStats, resultStat)
 	}
 
+	groupStats, err := s.prRepo.GetGroupStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sizes, priorities := models.Sizes(), models.Priorities()
+	slices.SortFunc(groupStats, func(a, b models.PRGroupStats) int {
+		return cmp.Or(
+			cmp.Compare(slices.Index(sizes, a.Size), slices.Index(sizes, b.Size)),
+			cmp.Compare(slices.Index(priorities, a.Priority), slices.Index(priorities, b.Priority)),
+		)
+	})
+
+	prStats := make([]common.PullRequestStats, len(groupStats))
+	for i, stat := range groupStats {
+		prStats[i] = common.PullRequestStats{
+			Size:     stat.Size,
+			Priority: stat.Priority,
+			OpenPRs:  stat.OpenPRs,
+			TotalPRs: stat.TotalPRs,
+		}
+	}
+
 	response := &common.StatsResponse{
-		UserStats: resultStats,
+		UserStats:    resultStats,
+		PullRequests: prStats,
 	}
 
 	return response, nil
@@ -228,9 +579,271 @@ func (s *pullRequestService) selectRandomReviewers(users []models.User, max int)
     return shuffled
 }
 
+// selectOwners picks an available owner by the strategy for every CODEOWNERS
+// rule that decides the owners of a changed path, unless a reviewer picked
+// for an earlier rule owns it already. Rules without available owners are
+// skipped.
+func (s *pullRequestService) selectOwners(ctx context.Context, repository string, files []string, authorID, strategy string) ([]models.ReviewerMatch, error) {
+	if repository == "" || len(files) == 0 {
+		return nil, nil
+	}
+
+	rules, err := s.ownersRepo.ListRules(ctx, repository)
+	if err != nil {
+		return nil, err
+	}
+
+	triggered, err := triggeredRules(rules, files)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []models.ReviewerMatch
+	var selectedUsers []models.User
+
+	for _, rule := range triggered {
+		userIDs, teamNames, _ := splitOwners(rule.Owners)
+
+		owned := slices.ContainsFunc(selectedUsers, func(user models.User) bool {
+			return slices.Contains(userIDs, user.UserID) || slices.Contains(teamNames, user.TeamName)
+		})
+		if owned {
+			continue
+		}
+
+		excludeIDs := []string{authorID}
+		for _, match := range selected {
+			excludeIDs = append(excludeIDs, match.UserID)
+		}
+
+		candidates, err := s.userRepo.GetAvailableUsers(ctx, userIDs, teamNames, excludeIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		owner, err := s.pickOwner(ctx, candidates, strategy)
+		if err != nil {
+			return nil, err
+		}
+		if len(owner) == 0 {
+			slog.WarnContext(ctx, "no available code owner",
+				"repository", repository,
+				"rule", rule.Pattern,
+				"line", rule.Line,
+			)
+			continue
+		}
+
+		selected = append(selected, models.ReviewerMatch{
+			UserID: owner[0].UserID,
+			Reason: models.MatchOwner,
+			Rule:   rule.Pattern,
+		})
+		selectedUsers = append(selectedUsers, owner[0])
+	}
+
+	return selected, nil
+}
+
+// replaceOwner picks another available owner by the rule that assigned
+// oldUserID. It returns nothing when oldUserID is not a code owner of the PR,
+// the rule is gone or no other owner is available.
+func (s *pullRequestService) replaceOwner(ctx context.Context, pr *models.PullRequest, oldUserID string, excludeIDs []string, strategy string) ([]models.ReviewerMatch, error) {
+	pattern := pr.OwnerRules[oldUserID]
+	if pattern == "" || pr.Repository == "" {
+		return nil, nil
+	}
+
+	rules, err := s.ownersRepo.ListRules(ctx, pr.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	i := slices.IndexFunc(rules, func(rule models.CodeOwnerRule) bool {
+		return rule.Pattern == pattern
+	})
+	if i < 0 {
+		return nil, nil
+	}
+
+	userIDs, teamNames, _ := splitOwners(rules[i].Owners)
+
+	candidates, err := s.userRepo.GetAvailableUsers(ctx, userIDs, teamNames, excludeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := s.pickOwner(ctx, candidates, strategy)
+	if err != nil {
+		return nil, err
+	}
+	if len(owner) == 0 {
+		return nil, nil
+	}
+
+	return []models.ReviewerMatch{{
+		UserID: owner[0].UserID,
+		Reason: models.MatchOwner,
+		Rule:   pattern,
+	}}, nil
+}
+
+// pickOwner picks one of candidates by the strategy; it returns none when
+// there are no candidates.
+func (s *pullRequestService) pickOwner(ctx context.Context, candidates []models.User, strategy string) ([]models.User, error) {
+	shuffled := s.selectRandomReviewers(candidates, len(candidates))
+
+	if strategy == models.StrategyLeastLoaded {
+		if err := s.sortByLoad(ctx, shuffled); err != nil {
+			return nil, err
+		}
+	}
+
+	return shuffled[:min(1, len(shuffled))], nil
+}
+
+// selectReviewers picks up to max of candidates by the strategy. For a
+// tagged PR the first expertSeats picks go to candidates with a skill among
+// the tags, as far as there are any; the other picks are drawn from everyone
+// left.
+func (s *pullRequestService) selectReviewers(ctx context.Context, candidates []models.User, tags []string, max, expertSeats int, strategy string) ([]models.ReviewerMatch, error) {
+	shuffled := s.selectRandomReviewers(candidates, len(candidates))
+
+	if strategy == models.StrategyLeastLoaded {
+		if err := s.sortByLoad(ctx, shuffled); err != nil {
+			return nil, err
+		}
+	}
+
+	all := make([]models.ReviewerMatch, len(shuffled))
+	for i, user := range shuffled {
+		all[i] = models.ReviewerMatch{UserID: user.UserID, Reason: models.MatchRandom}
+	}
+
+	if len(tags) == 0 {
+		return all[:min(max, len(all))], nil
+	}
+
+	userIDs := make([]string, len(shuffled))
+	for i, user := range shuffled {
+		userIDs[i] = user.UserID
+	}
+
+	skills, err := s.userRepo.GetSkills(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range all {
+		all[i].Skills = matchingSkills(skills[all[i].UserID], tags)
+		all[i].Reason = models.MatchFallback
+		if len(all[i].Skills) > 0 {
+			all[i].Reason = models.MatchSkill
+		}
+	}
+
+	selected := make([]models.ReviewerMatch, 0, max)
+	picked := make(map[string]bool)
+
+	for _, match := range all {
+		if len(selected) >= min(expertSeats, max) {
+			break
+		}
+		if match.Reason == models.MatchSkill {
+			selected = append(selected, match)
+			picked[match.UserID] = true
+		}
+	}
+
+	for _, match := range all {
+		if len(selected) >= max {
+			break
+		}
+		if !picked[match.UserID] {
+			selected = append(selected, match)
+		}
+	}
+
+	return selected, nil
+}
+
+// sortByLoad orders users by the load of their open reviews weighted by PR
+// size, keeping the order of users with as much.
+func (s *pullRequestService) sortByLoad(ctx context.Context, users []models.User) error {
+	userIDs := make([]string, len(users))
+	for i, user := range users {
+		userIDs[i] = user.UserID
+	}
+
+	reviews, err := s.userRepo.GetOpenReviews(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+
+	slices.SortStableFunc(users, func(a, b models.User) int {
+		return cmp.Compare(reviews[a.UserID].Load(), reviews[b.UserID].Load())
+	})
+
+	return nil
+}
+
+// linesChanged sums the lines added and removed; it returns nil when both
+// are unknown.
+func linesChanged(added, removed *int) *int {
+	if added == nil && removed == nil {
+		return nil
+	}
+
+	lines := 0
+	if added != nil {
+		lines += *added
+	}
+	if removed != nil {
+		lines += *removed
+	}
+
+	return &lines
+}
+
+// matchingSkills returns the skills that are among tags.
+func matchingSkills(skills, tags []string) []string {
+	var matched []string
+	for _, skill := range skills {
+		if slices.Contains(tags, skill) {
+			matched = append(matched, skill)
+		}
+	}
+
+	return matched
+}
+
+func matchesToResponse(matches []models.ReviewerMatch) []pullrequests.ReviewerMatch {
+	responses := make([]pullrequests.ReviewerMatch, len(matches))
+	for i, match := range matches {
+		skills := match.Skills
+		if skills == nil {
+			skills = []string{}
+		}
+
+		responses[i] = pullrequests.ReviewerMatch{
+			UserID: match.UserID,
+			Reason: match.Reason,
+			Skills: skills,
+			Rule:   match.Rule,
+		}
+	}
+
+	return responses
+}
+
 func (s *pullRequestService) prToResponse(pr *models.PullRequest) pullrequests.PullRequestResponse {
 	var mergedAtStr *string
 
+	tags := pr.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
 	if pr.MergedAt != nil {
 		mergedAt := pr.MergedAt.Format(time.RFC3339)
 		mergedAtStr = &mergedAt
@@ -242,6 +855,16 @@ func (s *pullRequestService) prToResponse(pr *models.PullRequest) pullrequests.P
 		AuthorID:          pr.AuthorID,
 		Status:            pr.Status,
 		AssignedReviewers: pr.AssignedReviewers,
+		Tags:              tags,
+		Repository:        pr.Repository,
+		Number:            pr.Number,
+		LinesAdded:        pr.LinesAdded,
+		LinesRemoved:      pr.LinesRemoved,
+		FilesChanged:      pr.FilesChanged,
+		Size:              pr.Size,
+		Priority:          pr.Priority,
+		OwnerRules:        pr.OwnerRules,
+		Understaffed:      pr.Status == "OPEN" && len(pr.AssignedReviewers) < pr.RequiredReviewers,
 		MergedAt:          mergedAtStr,
 	}
 }
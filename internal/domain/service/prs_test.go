@@ -0,0 +1,654 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	pullrequests "github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/prs"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/models"
+)
+
+// The fakes embed the repository interfaces and implement only the methods
+// reviewer selection reaches.
+
+type fakePRRepo struct {
+	PullRequestRepository
+	prs map[string]*models.PullRequest
+	// create, if set, decides whether CreatePR succeeds.
+	create func(pr *models.PullRequest) error
+}
+
+func (r *fakePRRepo) CreatePR(_ context.Context, pr *models.PullRequest) error {
+	if r.create != nil {
+		if err := r.create(pr); err != nil {
+			return err
+		}
+	}
+
+	r.prs[pr.ID] = pr
+	return nil
+}
+
+func (r *fakePRRepo) GetPR(_ context.Context, prID string) (*models.PullRequest, error) {
+	pr, ok := r.prs[prID]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+
+	return pr, nil
+}
+
+func (r *fakePRRepo) UpdatePRReviewers(_ context.Context, prID string, reviewerIDs []string, _ map[string]string) error {
+	r.prs[prID].AssignedReviewers = reviewerIDs
+	return nil
+}
+
+type fakeUserRepo struct {
+	UserRepository
+	// users are returned in this order, which keeps the least_loaded
+	// strategy deterministic for users with equal load.
+	users  []models.User
+	skills map[string][]string
+	open   map[string]models.OpenReviews
+}
+
+func (r *fakeUserRepo) GetUser(_ context.Context, userID string) (*models.User, error) {
+	for _, user := range r.users {
+		if user.UserID == userID {
+			return &user, nil
+		}
+	}
+
+	return nil, models.ErrNotFound
+}
+
+func (r *fakeUserRepo) GetActiveTeamMembers(_ context.Context, teamName string, excludeUserIDs []string) ([]models.User, error) {
+	var members []models.User
+	for _, user := range r.users {
+		if user.TeamName == teamName && user.IsActive && !slices.Contains(excludeUserIDs, user.UserID) {
+			members = append(members, user)
+		}
+	}
+
+	return members, nil
+}
+
+func (r *fakeUserRepo) GetAvailableUsers(_ context.Context, userIDs, teamNames, excludeUserIDs []string) ([]models.User, error) {
+	var available []models.User
+	for _, user := range r.users {
+		owner := slices.Contains(userIDs, user.UserID) || slices.Contains(teamNames, user.TeamName)
+		if owner && user.IsActive && !slices.Contains(excludeUserIDs, user.UserID) {
+			available = append(available, user)
+		}
+	}
+
+	return available, nil
+}
+
+func (r *fakeUserRepo) GetSkills(_ context.Context, userIDs []string) (map[string][]string, error) {
+	skills := make(map[string][]string)
+	for _, userID := range userIDs {
+		skills[userID] = r.skills[userID]
+	}
+
+	return skills, nil
+}
+
+func (r *fakeUserRepo) GetOpenReviews(_ context.Context, userIDs []string) (map[string]models.OpenReviews, error) {
+	open := make(map[string]models.OpenReviews)
+	for _, userID := range userIDs {
+		open[userID] = r.open[userID]
+	}
+
+	return open, nil
+}
+
+type fakeRepositoryRepo struct {
+	RepositoryRepository
+	repositories map[string]*models.Repository
+}
+
+func (r *fakeRepositoryRepo) GetRepository(_ context.Context, name string) (*models.Repository, error) {
+	repository, ok := r.repositories[name]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+
+	return repository, nil
+}
+
+type fakeCodeOwnersRepo struct {
+	CodeOwnersRepository
+	rules []models.CodeOwnerRule
+}
+
+func (r *fakeCodeOwnersRepo) ListRules(context.Context, string) ([]models.CodeOwnerRule, error) {
+	return r.rules, nil
+}
+
+type fakeMetrics struct {
+	assigned      []string
+	reassignments []string
+}
+
+func (m *fakeMetrics) ReviewAssigned(userID string) {
+	m.assigned = append(m.assigned, userID)
+}
+
+func (m *fakeMetrics) Reassignment(outcome string) {
+	m.reassignments = append(m.reassignments, outcome)
+}
+
+func (m *fakeMetrics) PRUnderstaffed() {}
+
+func (m *fakeMetrics) PRMerged(time.Duration) {}
+
+func activeUser(userID, teamName string) models.User {
+	return models.User{UserID: userID, Username: userID, TeamName: teamName, IsActive: true}
+}
+
+func TestReassignReviewerFromRepositoryTeam(t *testing.T) {
+	tests := []struct {
+		name     string
+		teamName string
+		want     string
+	}{
+		{name: "owning team", teamName: "platform", want: "p1"},
+		{name: "no owning team", want: "b2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prRepo := &fakePRRepo{prs: map[string]*models.PullRequest{
+				"backend-api:1": {
+					ID:                "backend-api:1",
+					AuthorID:          "author",
+					Status:            "OPEN",
+					Repository:        "backend-api",
+					AssignedReviewers: []string{"b1"},
+				},
+			}}
+			userRepo := &fakeUserRepo{users: []models.User{
+				activeUser("author", "backend"),
+				activeUser("b1", "backend"),
+				activeUser("b2", "backend"),
+				activeUser("p1", "platform"),
+			}}
+			reposRepo := &fakeRepositoryRepo{repositories: map[string]*models.Repository{
+				"backend-api": {Name: "backend-api", TeamName: tt.teamName},
+			}}
+			policy := models.ReviewPolicy{Reviewers: 1, Strategy: models.StrategyRandom}
+			s := NewPullRequestService(prRepo, userRepo, nil, &fakeCodeOwnersRepo{}, reposRepo, &fakeMetrics{}, policy)
+
+			response, err := s.ReassignReviewer(context.Background(), pullrequests.ReassignRequest{
+				PullRequestID: "backend-api:1",
+				OldUserID:     "b1",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if response.ReplacedBy != tt.want {
+				t.Errorf("replaced by %s, want %s", response.ReplacedBy, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreatePRPicksAgainWhenReviewerFillsUp(t *testing.T) {
+	tests := []struct {
+		name      string
+		fillsUp   []string
+		wantErr   error
+		want      []string
+		wantCalls int
+	}{
+		{name: "picked reviewer fills up", fillsUp: []string{"b1"}, want: []string{"b2"}, wantCalls: 2},
+		{name: "every reviewer fills up", fillsUp: []string{"b1", "b2", "b3"}, wantErr: models.ErrReviewerBusy, wantCalls: createAttempts},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// b1 is the least loaded, then b2 and b3.
+			userRepo := &fakeUserRepo{
+				users: []models.User{
+					activeUser("author", "backend"),
+					activeUser("b1", "backend"),
+					activeUser("b2", "backend"),
+					activeUser("b3", "backend"),
+				},
+				open: map[string]models.OpenReviews{"b2": {models.SizeSmall: 1}, "b3": {models.SizeSmall: 2}},
+			}
+
+			// A reviewer that fills up is busy on insert and no longer a
+			// candidate afterwards.
+			calls := 0
+			prRepo := &fakePRRepo{prs: map[string]*models.PullRequest{}, create: func(pr *models.PullRequest) error {
+				calls++
+				for _, reviewerID := range pr.AssignedReviewers {
+					if slices.Contains(tt.fillsUp, reviewerID) {
+						for i := range userRepo.users {
+							if userRepo.users[i].UserID == reviewerID {
+								userRepo.users[i].IsActive = false
+							}
+						}
+						return models.ErrReviewerBusy
+					}
+				}
+				return nil
+			}}
+
+			policy := models.ReviewPolicy{Reviewers: 1, Strategy: models.StrategyLeastLoaded}
+			s := NewPullRequestService(prRepo, userRepo, nil, &fakeCodeOwnersRepo{}, &fakeRepositoryRepo{}, &fakeMetrics{}, policy)
+
+			response, err := s.CreatePR(context.Background(), pullrequests.CreateRequest{
+				PullRequestID:   "pr-1",
+				PullRequestName: "Add search",
+				AuthorID:        "author",
+			})
+			if calls != tt.wantCalls {
+				t.Errorf("inserts = %d, want %d", calls, tt.wantCalls)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(response.PR.AssignedReviewers, tt.want) {
+				t.Errorf("reviewers = %v, want %v", response.PR.AssignedReviewers, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectReviewers(t *testing.T) {
+	// least_loaded keeps the order deterministic: n1, n2, e1, e2. The e
+	// users have a skill matching the "go" tag.
+	candidates := []models.User{
+		activeUser("e2", "backend"),
+		activeUser("n2", "backend"),
+		activeUser("e1", "backend"),
+		activeUser("n1", "backend"),
+	}
+	userRepo := &fakeUserRepo{
+		skills: map[string][]string{"e1": {"go", "sql"}, "e2": {"go"}},
+		open: map[string]models.OpenReviews{
+			"n2": {models.SizeSmall: 1},
+			"e1": {models.SizeSmall: 2},
+			"e2": {models.SizeSmall: 3},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		candidates  []models.User
+		tags        []string
+		max         int
+		expertSeats int
+		want        []models.ReviewerMatch
+	}{
+		{
+			name:        "no tags",
+			candidates:  candidates,
+			max:         2,
+			expertSeats: 1,
+			want: []models.ReviewerMatch{
+				{UserID: "n1", Reason: models.MatchRandom},
+				{UserID: "n2", Reason: models.MatchRandom},
+			},
+		},
+		{
+			name:        "one expert seat",
+			candidates:  candidates,
+			tags:        []string{"go"},
+			max:         2,
+			expertSeats: 1,
+			want: []models.ReviewerMatch{
+				{UserID: "e1", Reason: models.MatchSkill, Skills: []string{"go"}},
+				{UserID: "n1", Reason: models.MatchFallback},
+			},
+		},
+		{
+			name:        "every seat an expert seat",
+			candidates:  candidates,
+			tags:        []string{"go"},
+			max:         2,
+			expertSeats: 2,
+			want: []models.ReviewerMatch{
+				{UserID: "e1", Reason: models.MatchSkill, Skills: []string{"go"}},
+				{UserID: "e2", Reason: models.MatchSkill, Skills: []string{"go"}},
+			},
+		},
+		{
+			name:        "more expert seats than seats",
+			candidates:  candidates,
+			tags:        []string{"go"},
+			max:         1,
+			expertSeats: 3,
+			want: []models.ReviewerMatch{
+				{UserID: "e1", Reason: models.MatchSkill, Skills: []string{"go"}},
+			},
+		},
+		{
+			name:        "no expert seats",
+			candidates:  candidates,
+			tags:        []string{"go"},
+			max:         2,
+			expertSeats: 0,
+			want: []models.ReviewerMatch{
+				{UserID: "n1", Reason: models.MatchFallback},
+				{UserID: "n2", Reason: models.MatchFallback},
+			},
+		},
+		{
+			name:        "no experts among the candidates",
+			candidates:  []models.User{activeUser("n2", "backend"), activeUser("n1", "backend")},
+			tags:        []string{"go"},
+			max:         2,
+			expertSeats: 1,
+			want: []models.ReviewerMatch{
+				{UserID: "n1", Reason: models.MatchFallback},
+				{UserID: "n2", Reason: models.MatchFallback},
+			},
+		},
+		{
+			name:        "fewer candidates than seats",
+			candidates:  []models.User{activeUser("e1", "backend")},
+			tags:        []string{"sql"},
+			max:         3,
+			expertSeats: 1,
+			want: []models.ReviewerMatch{
+				{UserID: "e1", Reason: models.MatchSkill, Skills: []string{"sql"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewPullRequestService(nil, userRepo, nil, nil, nil, &fakeMetrics{}, models.ReviewPolicy{})
+
+			got, err := s.selectReviewers(context.Background(), tt.candidates, tt.tags, tt.max, tt.expertSeats, models.StrategyLeastLoaded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.EqualFunc(got, tt.want, equalMatch) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectReviewersRandom(t *testing.T) {
+	userRepo := &fakeUserRepo{skills: map[string][]string{"e1": {"go"}}}
+	candidates := []models.User{
+		activeUser("n1", "backend"),
+		activeUser("n2", "backend"),
+		activeUser("n3", "backend"),
+		activeUser("e1", "backend"),
+	}
+	s := NewPullRequestService(nil, userRepo, nil, nil, nil, &fakeMetrics{}, models.ReviewPolicy{})
+
+	// Whatever the draw, the only expert takes the expert seat.
+	for range 20 {
+		got, err := s.selectReviewers(context.Background(), candidates, []string{"go"}, 2, 1, models.StrategyRandom)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 2 || got[0].UserID != "e1" || got[1].Reason != models.MatchFallback {
+			t.Fatalf("got %+v, want e1 and a fallback reviewer", got)
+		}
+	}
+}
+
+func equalMatch(a, b models.ReviewerMatch) bool {
+	return a.UserID == b.UserID && a.Reason == b.Reason && a.Rule == b.Rule && slices.Equal(a.Skills, b.Skills)
+}
+
+// ownersFixture is a repository with CODEOWNERS rules and users whose load
+// orders the owners picked by least_loaded: a1 before a2, d2 before d1.
+func ownersFixture() (*fakeUserRepo, *fakeCodeOwnersRepo) {
+	userRepo := &fakeUserRepo{
+		users: []models.User{
+			activeUser("author", "backend"),
+			activeUser("b1", "backend"),
+			activeUser("b2", "backend"),
+			activeUser("a1", "api"),
+			activeUser("a2", "api"),
+			activeUser("d1", "docs"),
+			activeUser("d2", "docs"),
+		},
+		open: map[string]models.OpenReviews{
+			"b2": {models.SizeSmall: 1},
+			"a2": {models.SizeSmall: 1},
+			"d1": {models.SizeSmall: 1},
+		},
+	}
+	ownersRepo := &fakeCodeOwnersRepo{rules: []models.CodeOwnerRule{
+		{Line: 1, Pattern: "*", Owners: []string{"@b1"}},
+		{Line: 2, Pattern: "/api/", Owners: []string{"@acme/api"}},
+		{Line: 3, Pattern: "/docs/", Owners: []string{"@d1", "@d2"}},
+		{Line: 4, Pattern: "/shared/", Owners: []string{"@a1", "@d1"}},
+		{Line: 5, Pattern: "/cmd/", Owners: []string{"@author", "@d1"}},
+		{Line: 6, Pattern: "/ops/", Owners: []string{"@gone"}},
+		{Line: 7, Pattern: "/vendor/"},
+	}}
+
+	return userRepo, ownersRepo
+}
+
+func TestSelectOwners(t *testing.T) {
+	tests := []struct {
+		name       string
+		repository string
+		files      []string
+		want       []models.ReviewerMatch
+	}{
+		{
+			name:  "no repository",
+			files: []string{"api/x.go"},
+		},
+		{
+			name:       "one owner per triggered rule",
+			repository: "backend-api",
+			files:      []string{"api/x.go", "docs/a.md"},
+			want: []models.ReviewerMatch{
+				{UserID: "a1", Reason: models.MatchOwner, Rule: "/api/"},
+				{UserID: "d2", Reason: models.MatchOwner, Rule: "/docs/"},
+			},
+		},
+		{
+			name:       "last matching rule decides",
+			repository: "backend-api",
+			files:      []string{"main.go"},
+			want: []models.ReviewerMatch{
+				{UserID: "b1", Reason: models.MatchOwner, Rule: "*"},
+			},
+		},
+		{
+			name:       "owner of an earlier rule covers a later one",
+			repository: "backend-api",
+			files:      []string{"api/x.go", "shared/y.go"},
+			want: []models.ReviewerMatch{
+				{UserID: "a1", Reason: models.MatchOwner, Rule: "/api/"},
+			},
+		},
+		{
+			name:       "author is not an owner candidate",
+			repository: "backend-api",
+			files:      []string{"cmd/main.go"},
+			want: []models.ReviewerMatch{
+				{UserID: "d1", Reason: models.MatchOwner, Rule: "/cmd/"},
+			},
+		},
+		{
+			name:       "rules without available owners are skipped",
+			repository: "backend-api",
+			files:      []string{"ops/deploy.yaml", "vendor/lib.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo, ownersRepo := ownersFixture()
+			s := NewPullRequestService(nil, userRepo, nil, ownersRepo, nil, &fakeMetrics{}, models.ReviewPolicy{})
+
+			got, err := s.selectOwners(context.Background(), tt.repository, tt.files, "author", models.StrategyLeastLoaded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.EqualFunc(got, tt.want, equalMatch) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplaceOwner(t *testing.T) {
+	tests := []struct {
+		name       string
+		ownerRules map[string]string
+		oldUserID  string
+		want       []models.ReviewerMatch
+	}{
+		{
+			name:      "not a code owner",
+			oldUserID: "a1",
+		},
+		{
+			name:       "another owner of the rule",
+			ownerRules: map[string]string{"a1": "/api/"},
+			oldUserID:  "a1",
+			want:       []models.ReviewerMatch{{UserID: "a2", Reason: models.MatchOwner, Rule: "/api/"}},
+		},
+		{
+			name:       "rule is gone",
+			ownerRules: map[string]string{"a1": "/legacy/"},
+			oldUserID:  "a1",
+		},
+		{
+			name:       "no other owner available",
+			ownerRules: map[string]string{"b1": "*"},
+			oldUserID:  "b1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo, ownersRepo := ownersFixture()
+			s := NewPullRequestService(nil, userRepo, nil, ownersRepo, nil, &fakeMetrics{}, models.ReviewPolicy{})
+			pr := &models.PullRequest{
+				ID:                "backend-api:1",
+				AuthorID:          "author",
+				Repository:        "backend-api",
+				AssignedReviewers: []string{tt.oldUserID},
+				OwnerRules:        tt.ownerRules,
+			}
+
+			got, err := s.replaceOwner(context.Background(), pr, tt.oldUserID, []string{tt.oldUserID, "author"}, models.StrategyLeastLoaded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.EqualFunc(got, tt.want, equalMatch) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreatePROwnerSeats(t *testing.T) {
+	tests := []struct {
+		name      string
+		reviewers int
+		files     []string
+		want      []string
+	}{
+		{name: "team fills the seats owners leave", reviewers: 3, files: []string{"api/x.go"}, want: []string{"a1", "b1", "b2"}},
+		{name: "owners exceed the policy", reviewers: 1, files: []string{"api/x.go", "docs/a.md"}, want: []string{"a1", "d2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo, ownersRepo := ownersFixture()
+			prRepo := &fakePRRepo{prs: map[string]*models.PullRequest{}}
+			reposRepo := &fakeRepositoryRepo{repositories: map[string]*models.Repository{
+				"backend-api": {Name: "backend-api"},
+			}}
+			policy := models.ReviewPolicy{Reviewers: tt.reviewers, Strategy: models.StrategyLeastLoaded}
+			s := NewPullRequestService(prRepo, userRepo, nil, ownersRepo, reposRepo, &fakeMetrics{}, policy)
+
+			response, err := s.CreatePR(context.Background(), pullrequests.CreateRequest{
+				PullRequestName: "Add search",
+				AuthorID:        "author",
+				Repository:      "backend-api",
+				ChangedFiles:    tt.files,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(response.PR.AssignedReviewers, tt.want) {
+				t.Errorf("reviewers = %v, want %v", response.PR.AssignedReviewers, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreatePRSizeAndPriority(t *testing.T) {
+	huge := 2000
+
+	tests := []struct {
+		name         string
+		linesAdded   *int
+		priority     string
+		want         []string
+		wantRequired int
+	}{
+		{name: "urgent goes to the least loaded", priority: models.PriorityUrgent, want: []string{"b3"}, wantRequired: 1},
+		{name: "huge adds reviewers", linesAdded: &huge, priority: models.PriorityUrgent, want: []string{"b3", "b2", "b1"}, wantRequired: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Load is weighted by size: b1's one huge review outweighs b2's
+			// three small ones, so least_loaded orders them b3, b2, b1.
+			userRepo := &fakeUserRepo{
+				users: []models.User{
+					activeUser("author", "backend"),
+					activeUser("b1", "backend"),
+					activeUser("b2", "backend"),
+					activeUser("b3", "backend"),
+				},
+				open: map[string]models.OpenReviews{
+					"b1": {models.SizeHuge: 1},
+					"b2": {models.SizeSmall: 3},
+					"b3": {models.SizeMedium: 1},
+				},
+			}
+			prRepo := &fakePRRepo{prs: map[string]*models.PullRequest{}}
+			policy := models.ReviewPolicy{Reviewers: 1, Strategy: models.StrategyRandom}
+			s := NewPullRequestService(prRepo, userRepo, nil, &fakeCodeOwnersRepo{}, &fakeRepositoryRepo{}, &fakeMetrics{}, policy)
+
+			response, err := s.CreatePR(context.Background(), pullrequests.CreateRequest{
+				PullRequestID:   "pr-1",
+				PullRequestName: "Add search",
+				AuthorID:        "author",
+				LinesAdded:      tt.linesAdded,
+				Priority:        tt.priority,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(response.PR.AssignedReviewers, tt.want) {
+				t.Errorf("reviewers = %v, want %v", response.PR.AssignedReviewers, tt.want)
+			}
+			if got := prRepo.prs["pr-1"].RequiredReviewers; got != tt.wantRequired {
+				t.Errorf("required reviewers = %d, want %d", got, tt.wantRequired)
+			}
+		})
+	}
+}
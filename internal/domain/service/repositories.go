@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/repositories"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/models"
+	"github.com/IlyaAGL/avito_autumn_2025/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type RepositoryRepository interface {
+	CreateRepository(ctx context.Context, repository *models.Repository) error
+	GetRepository(ctx context.Context, name string) (*models.Repository, error)
+	ListRepositories(ctx context.Context) ([]models.Repository, error)
+	UpdateRepository(ctx context.Context, repository *models.Repository) error
+	DeleteRepository(ctx context.Context, name string) error
+}
+
+// RepositoryService manages the repositories that own pull requests and their
+// review policy overrides.
+type RepositoryService struct {
+	reposRepo RepositoryRepository
+	// policy holds the service defaults the overrides apply to.
+	policy models.ReviewPolicy
+}
+
+func NewRepositoryService(reposRepo RepositoryRepository, policy models.ReviewPolicy) *RepositoryService {
+	return &RepositoryService{
+		reposRepo: reposRepo,
+		policy:    policy,
+	}
+}
+
+func (s *RepositoryService) CreateRepository(ctx context.Context, req repositories.CreateRequest) (_ *repositories.RepositoryResponse, err error) {
+	ctx, span := tracer.Start(ctx, "RepositoryService.CreateRepository", trace.WithAttributes(
+		attribute.String("repository", req.Repository),
+		attribute.String("team_name", req.TeamName),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	repository := &models.Repository{
+		Name:      req.Repository,
+		TeamName:  req.TeamName,
+		Reviewers: req.Reviewers,
+		Strategy:  req.Strategy,
+	}
+
+	if err := s.reposRepo.CreateRepository(ctx, repository); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "repository created", "repository", repository.Name, "team_name", repository.TeamName)
+
+	response := s.repositoryToResponse(repository)
+
+	return &response, nil
+}
+
+func (s *RepositoryService) GetRepository(ctx context.Context, name string) (_ *repositories.RepositoryResponse, err error) {
+	ctx, span := tracer.Start(ctx, "RepositoryService.GetRepository", trace.WithAttributes(
+		attribute.String("repository", name),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	repository, err := s.reposRepo.GetRepository(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.repositoryToResponse(repository)
+
+	return &response, nil
+}
+
+func (s *RepositoryService) ListRepositories(ctx context.Context) (_ *repositories.ListResponse, err error) {
+	ctx, span := tracer.Start(ctx, "RepositoryService.ListRepositories")
+	defer func() { tracing.End(span, err) }()
+
+	list, err := s.reposRepo.ListRepositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]repositories.RepositoryResponse, len(list))
+	for i := range list {
+		responses[i] = s.repositoryToResponse(&list[i])
+	}
+
+	return &repositories.ListResponse{
+		Repositories: responses,
+	}, nil
+}
+
+// UpdateRepository replaces the owning team and the policy overrides. Open
+// pull requests keep the reviewers they have.
+func (s *RepositoryService) UpdateRepository(ctx context.Context, req repositories.UpdateRequest) (_ *repositories.RepositoryResponse, err error) {
+	ctx, span := tracer.Start(ctx, "RepositoryService.UpdateRepository", trace.WithAttributes(
+		attribute.String("repository", req.Repository),
+		attribute.String("team_name", req.TeamName),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	err = s.reposRepo.UpdateRepository(ctx, &models.Repository{
+		Name:      req.Repository,
+		TeamName:  req.TeamName,
+		Reviewers: req.Reviewers,
+		Strategy:  req.Strategy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	repository, err := s.reposRepo.GetRepository(ctx, req.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "repository updated", "repository", repository.Name, "team_name", repository.TeamName)
+
+	response := s.repositoryToResponse(repository)
+
+	return &response, nil
+}
+
+// DeleteRepository deletes a repository without pull requests, along with its
+// CODEOWNERS rules.
+func (s *RepositoryService) DeleteRepository(ctx context.Context, name string) (err error) {
+	ctx, span := tracer.Start(ctx, "RepositoryService.DeleteRepository", trace.WithAttributes(
+		attribute.String("repository", name),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	if err := s.reposRepo.DeleteRepository(ctx, name); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "repository deleted", "repository", name)
+
+	return nil
+}
+
+func (s *RepositoryService) repositoryToResponse(repository *models.Repository) repositories.RepositoryResponse {
+	policy := s.policy.For(repository)
+
+	return repositories.RepositoryResponse{
+		Repository: repository.Name,
+		TeamName:   repository.TeamName,
+		Policy: repositories.Policy{
+			Reviewers: repository.Reviewers,
+			Strategy:  repository.Strategy,
+		},
+		EffectiveReviewers: policy.Reviewers,
+		EffectiveStrategy:  policy.Strategy,
+		PullRequests:       repository.PullRequests,
+	}
+}
@@ -0,0 +1,737 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/scim"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/models"
+	"github.com/IlyaAGL/avito_autumn_2025/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	// filterPattern matches the only filter form identity providers need for
+	// provisioning: `attribute eq "value"`.
+	filterPattern = regexp.MustCompile(`^\s*(\w+)\s+(?i:eq)\s+"((?:[^"\\]|\\.)*)"\s*$`)
+	// memberPathPattern matches the path of a single-member remove operation.
+	memberPathPattern = regexp.MustCompile(`^(?i:members)\[\s*(?i:value)\s+(?i:eq)\s+"((?:[^"\\]|\\.)*)"\s*\]$`)
+)
+
+// SCIMService maps SCIM Users onto users and SCIM Groups onto teams. A user
+// belongs to at most one team, so adding a user to a group moves them out of
+// their previous team. The active flag is left to the identity provider:
+// unlike /team/removeMembers, removing a user from a group does not
+// deactivate them.
+type SCIMService struct {
+	userRepo UserRepository
+	teamRepo TeamRepository
+	metrics  ReassignmentMetrics
+}
+
+func NewSCIMService(userRepo UserRepository, teamRepo TeamRepository, metrics ReassignmentMetrics) *SCIMService {
+	return &SCIMService{
+		userRepo: userRepo,
+		teamRepo: teamRepo,
+		metrics:  metrics,
+	}
+}
+
+func (s *SCIMService) ListUsers(ctx context.Context, params scim.ListParams) (_ *scim.UserList, err error) {
+	ctx, span := tracer.Start(ctx, "SCIMService.ListUsers", trace.WithAttributes(
+		attribute.String("filter", params.Filter),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	userName, filtered, err := parseFilter(params.Filter, "userName")
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := s.userRepo.ListUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []models.User
+	for _, user := range all {
+		if !filtered || strings.EqualFold(user.Username, userName) {
+			matched = append(matched, user)
+		}
+	}
+
+	from, to := page(len(matched), params)
+
+	resources := make([]scim.User, 0, to-from)
+	for _, user := range matched[from:to] {
+		resources = append(resources, toSCIMUser(user))
+	}
+
+	return &scim.UserList{
+		Schemas:      []string{scim.ListResponseSchema},
+		TotalResults: len(matched),
+		StartIndex:   from + 1,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}, nil
+}
+
+func (s *SCIMService) GetUser(ctx context.Context, id string) (_ *scim.User, err error) {
+	ctx, span := tracer.Start(ctx, "SCIMService.GetUser", trace.WithAttributes(
+		attribute.String("user_id", id),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	user, err := s.userRepo.GetUser(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("user %s: %w", id, err)
+	}
+
+	response := toSCIMUser(*user)
+
+	return &response, nil
+}
+
+// CreateUser creates a user without a team. The externalId becomes the
+// user_id when present; otherwise an ID is generated.
+func (s *SCIMService) CreateUser(ctx context.Context, req scim.UserRequest) (_ *scim.User, err error) {
+	ctx, span := tracer.Start(ctx, "SCIMService.CreateUser", trace.WithAttributes(
+		attribute.String("user_name", req.UserName),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	if err := s.checkUserNameFree(ctx, req.UserName, ""); err != nil {
+		return nil, err
+	}
+
+	user := models.User{
+		UserID:   req.ExternalID,
+		Username: req.UserName,
+		IsActive: req.Active == nil || *req.Active,
+	}
+	if user.UserID == "" {
+		user.UserID = newUserID()
+	}
+
+	if err := s.userRepo.CreateUser(ctx, &user); err != nil {
+		return nil, fmt.Errorf("user %s: %w", user.UserID, err)
+	}
+
+	slog.InfoContext(ctx, "scim user created", "user_id", user.UserID)
+
+	response := toSCIMUser(user)
+
+	return &response, nil
+}
+
+// ReplaceUser serves PUT: userName and active are overwritten, an omitted
+// active meaning true.
+func (s *SCIMService) ReplaceUser(ctx context.Context, id string, req scim.UserRequest) (_ *scim.User, err error) {
+	ctx, span := tracer.Start(ctx, "SCIMService.ReplaceUser", trace.WithAttributes(
+		attribute.String("user_id", id),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	return s.updateUser(ctx, id, req.UserName, req.Active == nil || *req.Active)
+}
+
+// PatchUser applies replace and add operations on userName and active.
+// Other attributes are ignored, as identity providers send more than the
+// roster stores.
+func (s *SCIMService) PatchUser(ctx context.Context, id string, req scim.PatchRequest) (_ *scim.User, err error) {
+	ctx, span := tracer.Start(ctx, "SCIMService.PatchUser", trace.WithAttributes(
+		attribute.String("user_id", id),
+		attribute.Int("operations", len(req.Operations)),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	user, err := s.userRepo.GetUser(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("user %s: %w", id, err)
+	}
+
+	userName, active := user.Username, user.IsActive
+
+	for _, op := range req.Operations {
+		switch strings.ToLower(op.Op) {
+		case "add", "replace":
+		case "remove":
+			if op.Path != "" && isUserAttribute(op.Path) {
+				return nil, fmt.Errorf("%w: %s cannot be removed", models.ErrInvalidValue, op.Path)
+			}
+			continue
+		default:
+			return nil, fmt.Errorf("%w: unsupported operation %q", models.ErrInvalidValue, op.Op)
+		}
+
+		values := map[string]json.RawMessage{op.Path: op.Value}
+		if op.Path == "" {
+			if err := json.Unmarshal(op.Value, &values); err != nil {
+				return nil, fmt.Errorf("%w: value must be an object when path is omitted", models.ErrInvalidValue)
+			}
+		}
+
+		for path, value := range values {
+			switch strings.ToLower(path) {
+			case "username":
+				if userName, err = stringValue(path, value); err != nil {
+					return nil, err
+				}
+			case "active":
+				if active, err = boolValue(path, value); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return s.updateUser(ctx, id, userName, active)
+}
+
+// DeactivateUser serves DELETE. Users are referenced by pull requests and
+// review history, so they are deactivated rather than deleted.
+func (s *SCIMService) DeactivateUser(ctx context.Context, id string) (err error) {
+	ctx, span := tracer.Start(ctx, "SCIMService.DeactivateUser", trace.WithAttributes(
+		attribute.String("user_id", id),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	if _, err := s.userRepo.SetUserActive(ctx, id, false); err != nil {
+		return fmt.Errorf("user %s: %w", id, err)
+	}
+
+	slog.InfoContext(ctx, "scim user deactivated", "user_id", id)
+
+	return nil
+}
+
+func (s *SCIMService) ListGroups(ctx context.Context, params scim.ListParams) (_ *scim.GroupList, err error) {
+	ctx, span := tracer.Start(ctx, "SCIMService.ListGroups", trace.WithAttributes(
+		attribute.String("filter", params.Filter),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	displayName, filtered, err := parseFilter(params.Filter, "displayName")
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := loadTeams(ctx, s.teamRepo, s.userRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []models.Team
+	for _, team := range all {
+		if !filtered || strings.EqualFold(team.Name, displayName) {
+			matched = append(matched, team)
+		}
+	}
+
+	from, to := page(len(matched), params)
+
+	resources := make([]scim.Group, 0, to-from)
+	for i := range matched[from:to] {
+		resources = append(resources, toSCIMGroup(&matched[from+i]))
+	}
+
+	return &scim.GroupList{
+		Schemas:      []string{scim.ListResponseSchema},
+		TotalResults: len(matched),
+		StartIndex:   from + 1,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}, nil
+}
+
+func (s *SCIMService) GetGroup(ctx context.Context, id string) (_ *scim.Group, err error) {
+	ctx, span := tracer.Start(ctx, "SCIMService.GetGroup", trace.WithAttributes(
+		attribute.String("team_name", id),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	return s.group(ctx, id)
+}
+
+// CreateGroup creates a team from existing users, moving them out of their
+// current teams.
+func (s *SCIMService) CreateGroup(ctx context.Context, req scim.GroupRequest) (_ *scim.Group, err error) {
+	ctx, span := tracer.Start(ctx, "SCIMService.CreateGroup", trace.WithAttributes(
+		attribute.String("team_name", req.DisplayName),
+		attribute.Int("members", len(req.Members)),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	members, err := s.resolveMembers(ctx, req.Members)
+	if err != nil {
+		return nil, err
+	}
+
+	team := &models.Team{Name: req.DisplayName, Members: members}
+	changes, err := s.teamRepo.CreateTeam(ctx, team, models.CreateTeamOptions{AllowMove: true})
+	if err != nil {
+		return nil, fmt.Errorf("team %s: %w", req.DisplayName, err)
+	}
+
+	recordReviewerChanges(s.metrics, changes)
+
+	slog.InfoContext(ctx, "scim group created", "team_name", req.DisplayName, "members", len(members))
+
+	return s.group(ctx, req.DisplayName)
+}
+
+// ReplaceGroup serves PUT: the team is renamed to displayName and its
+// members are set to exactly the listed users.
+func (s *SCIMService) ReplaceGroup(ctx context.Context, id string, req scim.GroupRequest) (_ *scim.Group, err error) {
+	ctx, span := tracer.Start(ctx, "SCIMService.ReplaceGroup", trace.WithAttributes(
+		attribute.String("team_name", id),
+		attribute.Int("members", len(req.Members)),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	name, err := s.renameGroup(ctx, id, req.DisplayName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.setMembers(ctx, name, memberIDsOf(req.Members)); err != nil {
+		return nil, err
+	}
+
+	return s.group(ctx, name)
+}
+
+// PatchGroup applies displayName and members operations in order. Each
+// operation is applied on its own, so a failing operation leaves the ones
+// before it in place.
+func (s *SCIMService) PatchGroup(ctx context.Context, id string, req scim.PatchRequest) (_ *scim.Group, err error) {
+	ctx, span := tracer.Start(ctx, "SCIMService.PatchGroup", trace.WithAttributes(
+		attribute.String("team_name", id),
+		attribute.Int("operations", len(req.Operations)),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	if _, err := s.teamRepo.GetTeam(ctx, id); err != nil {
+		return nil, fmt.Errorf("team %s: %w", id, err)
+	}
+
+	name := id
+	for _, op := range req.Operations {
+		if name, err = s.applyGroupOperation(ctx, name, op); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.group(ctx, name)
+}
+
+// DeleteGroup removes every member and deletes the team.
+func (s *SCIMService) DeleteGroup(ctx context.Context, id string) (err error) {
+	ctx, span := tracer.Start(ctx, "SCIMService.DeleteGroup", trace.WithAttributes(
+		attribute.String("team_name", id),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	if err := s.setMembers(ctx, id, nil); err != nil {
+		return err
+	}
+
+	if err := s.teamRepo.DeleteTeam(ctx, id); err != nil {
+		return fmt.Errorf("team %s: %w", id, err)
+	}
+
+	slog.InfoContext(ctx, "scim group deleted", "team_name", id)
+
+	return nil
+}
+
+func (s *SCIMService) applyGroupOperation(ctx context.Context, name string, op scim.PatchOperation) (string, error) {
+	path := strings.ToLower(op.Path)
+
+	switch strings.ToLower(op.Op) {
+	case "add", "replace":
+		replace := strings.EqualFold(op.Op, "replace")
+
+		values := map[string]json.RawMessage{op.Path: op.Value}
+		if op.Path == "" {
+			if err := json.Unmarshal(op.Value, &values); err != nil {
+				return "", fmt.Errorf("%w: value must be an object when path is omitted", models.ErrInvalidValue)
+			}
+		}
+
+		for attr, value := range values {
+			switch strings.ToLower(attr) {
+			case "displayname":
+				newName, err := stringValue(attr, value)
+				if err != nil {
+					return "", err
+				}
+
+				if name, err = s.renameGroup(ctx, name, newName); err != nil {
+					return "", err
+				}
+			case "members":
+				var refs []scim.MemberRef
+				if err := json.Unmarshal(value, &refs); err != nil {
+					return "", fmt.Errorf("%w: members must be a list of {\"value\": id}", models.ErrInvalidValue)
+				}
+
+				var err error
+				if replace {
+					err = s.setMembers(ctx, name, memberIDsOf(refs))
+				} else {
+					err = s.addMembers(ctx, name, memberIDsOf(refs))
+				}
+				if err != nil {
+					return "", err
+				}
+			}
+		}
+
+		return name, nil
+	case "remove":
+		if match := memberPathPattern.FindStringSubmatch(op.Path); match != nil {
+			userID, err := unquote(match[1])
+			if err != nil {
+				return "", err
+			}
+
+			return name, s.removeMembers(ctx, name, []string{userID})
+		}
+
+		if path != "members" {
+			return "", fmt.Errorf("%w: unsupported remove path %q", models.ErrInvalidValue, op.Path)
+		}
+
+		if len(op.Value) == 0 {
+			return name, s.setMembers(ctx, name, nil)
+		}
+
+		var refs []scim.MemberRef
+		if err := json.Unmarshal(op.Value, &refs); err != nil {
+			return "", fmt.Errorf("%w: members must be a list of {\"value\": id}", models.ErrInvalidValue)
+		}
+
+		return name, s.removeMembers(ctx, name, memberIDsOf(refs))
+	default:
+		return "", fmt.Errorf("%w: unsupported operation %q", models.ErrInvalidValue, op.Op)
+	}
+}
+
+func (s *SCIMService) updateUser(ctx context.Context, id, userName string, active bool) (*scim.User, error) {
+	if err := checkName("userName", userName); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkUserNameFree(ctx, userName, id); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.UpdateUser(ctx, id, userName, active)
+	if err != nil {
+		return nil, fmt.Errorf("user %s: %w", id, err)
+	}
+
+	response := toSCIMUser(*user)
+
+	return &response, nil
+}
+
+// checkUserNameFree enforces SCIM's case-insensitive uniqueness of userName,
+// which the users table itself does not require.
+func (s *SCIMService) checkUserNameFree(ctx context.Context, userName, exceptID string) error {
+	all, err := s.userRepo.ListUsers(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, user := range all {
+		if user.UserID != exceptID && strings.EqualFold(user.Username, userName) {
+			return fmt.Errorf("userName %s: %w", userName, models.ErrUserExists)
+		}
+	}
+
+	return nil
+}
+
+func (s *SCIMService) renameGroup(ctx context.Context, name, newName string) (string, error) {
+	if err := checkName("displayName", newName); err != nil {
+		return "", err
+	}
+
+	if newName == name {
+		return name, nil
+	}
+
+	if err := s.teamRepo.RenameTeam(ctx, name, newName); err != nil {
+		return "", fmt.Errorf("team %s: %w", name, err)
+	}
+
+	return newName, nil
+}
+
+// setMembers makes userIDs the exact member list of the team.
+func (s *SCIMService) setMembers(ctx context.Context, name string, userIDs []string) error {
+	team, err := s.teamRepo.GetTeam(ctx, name)
+	if err != nil {
+		return fmt.Errorf("team %s: %w", name, err)
+	}
+
+	var removed []string
+	for _, member := range team.Members {
+		if !slices.Contains(userIDs, member.UserID) {
+			removed = append(removed, member.UserID)
+		}
+	}
+
+	if err := s.removeMembers(ctx, name, removed); err != nil {
+		return err
+	}
+
+	return s.addMembers(ctx, name, userIDs)
+}
+
+func (s *SCIMService) addMembers(ctx context.Context, name string, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	members, err := s.resolveMembers(ctx, refsOf(userIDs))
+	if err != nil {
+		return err
+	}
+
+	team := &models.Team{Name: name, Members: members}
+	changes, err := s.teamRepo.CreateTeam(ctx, team, models.CreateTeamOptions{Upsert: true, AllowMove: true})
+	if err != nil {
+		return fmt.Errorf("team %s: %w", name, err)
+	}
+
+	recordReviewerChanges(s.metrics, changes)
+
+	slog.InfoContext(ctx, "scim group members added", "team_name", name, "user_ids", userIDs, "reassignments", len(changes))
+
+	return nil
+}
+
+// removeMembers removes those of userIDs that are members of the team, so
+// repeated remove operations succeed.
+func (s *SCIMService) removeMembers(ctx context.Context, name string, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	team, err := s.teamRepo.GetTeam(ctx, name)
+	if err != nil {
+		return fmt.Errorf("team %s: %w", name, err)
+	}
+
+	var members []string
+	for _, member := range team.Members {
+		if slices.Contains(userIDs, member.UserID) {
+			members = append(members, member.UserID)
+		}
+	}
+
+	if len(members) == 0 {
+		return nil
+	}
+
+	changes, err := s.teamRepo.RemoveMembers(ctx, name, members, models.RemoveMembersOptions{KeepActive: true})
+	if err != nil {
+		return fmt.Errorf("team %s: %w", name, err)
+	}
+
+	recordReviewerChanges(s.metrics, changes)
+
+	slog.InfoContext(ctx, "scim group members removed", "team_name", name, "user_ids", members, "reassignments", len(changes))
+
+	return nil
+}
+
+// resolveMembers looks up the users behind member references, keeping their
+// current username and active flag.
+func (s *SCIMService) resolveMembers(ctx context.Context, refs []scim.MemberRef) ([]models.Member, error) {
+	members := make([]models.Member, 0, len(refs))
+	for _, ref := range refs {
+		user, err := s.userRepo.GetUser(ctx, ref.Value)
+		if errors.Is(err, models.ErrNotFound) {
+			return nil, fmt.Errorf("%w: member %s does not exist", models.ErrInvalidValue, ref.Value)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		members = append(members, models.Member{
+			UserID:   user.UserID,
+			Username: user.Username,
+			IsActive: user.IsActive,
+		})
+	}
+
+	return members, nil
+}
+
+func (s *SCIMService) group(ctx context.Context, name string) (*scim.Group, error) {
+	team, err := s.teamRepo.GetTeam(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("team %s: %w", name, err)
+	}
+
+	response := toSCIMGroup(team)
+
+	return &response, nil
+}
+
+// parseFilter returns the value of an `attribute eq "value"` filter. An
+// empty filter matches everything.
+func parseFilter(filter, attribute string) (value string, filtered bool, err error) {
+	if filter == "" {
+		return "", false, nil
+	}
+
+	match := filterPattern.FindStringSubmatch(filter)
+	if match == nil || !strings.EqualFold(match[1], attribute) {
+		return "", false, fmt.Errorf("%w: only %s eq \"value\" is supported", models.ErrInvalidFilter, attribute)
+	}
+
+	value, err = unquote(match[2])
+	if err != nil {
+		return "", false, fmt.Errorf("%w: %s", models.ErrInvalidFilter, err)
+	}
+
+	return value, true, nil
+}
+
+func unquote(escaped string) (string, error) {
+	value, err := strconv.Unquote(`"` + escaped + `"`)
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid string %q", models.ErrInvalidValue, escaped)
+	}
+
+	return value, nil
+}
+
+// page returns the bounds of the requested page; startIndex is 1-based.
+func page(total int, params scim.ListParams) (from, to int) {
+	count := scim.MaxResults
+	if params.Count != nil {
+		count = min(max(*params.Count, 0), scim.MaxResults)
+	}
+
+	from = min(max(params.StartIndex, 1)-1, total)
+
+	return from, min(from+count, total)
+}
+
+func stringValue(attr string, raw json.RawMessage) (string, error) {
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "", fmt.Errorf("%w: %s must be a string", models.ErrInvalidValue, attr)
+	}
+
+	return value, nil
+}
+
+// boolValue also accepts "True" and "False" strings, which some identity
+// providers send for booleans.
+func boolValue(attr string, raw json.RawMessage) (bool, error) {
+	var value bool
+	if err := json.Unmarshal(raw, &value); err == nil {
+		return value, nil
+	}
+
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		if value, err := strconv.ParseBool(text); err == nil {
+			return value, nil
+		}
+	}
+
+	return false, fmt.Errorf("%w: %s must be a boolean", models.ErrInvalidValue, attr)
+}
+
+func checkName(attr, value string) error {
+	if value == "" || len(value) > 255 || value != strings.TrimSpace(value) {
+		return fmt.Errorf("%w: %s must be 1 to 255 characters without surrounding spaces", models.ErrInvalidValue, attr)
+	}
+
+	return nil
+}
+
+func isUserAttribute(path string) bool {
+	return strings.EqualFold(path, "userName") || strings.EqualFold(path, "active")
+}
+
+func newUserID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+
+	return "scim-" + hex.EncodeToString(b)
+}
+
+func memberIDsOf(refs []scim.MemberRef) []string {
+	userIDs := make([]string, len(refs))
+	for i, ref := range refs {
+		userIDs[i] = ref.Value
+	}
+
+	return userIDs
+}
+
+func refsOf(userIDs []string) []scim.MemberRef {
+	refs := make([]scim.MemberRef, len(userIDs))
+	for i, userID := range userIDs {
+		refs[i] = scim.MemberRef{Value: userID}
+	}
+
+	return refs
+}
+
+func toSCIMUser(user models.User) scim.User {
+	response := scim.User{
+		Schemas:  []string{scim.UserSchema},
+		ID:       user.UserID,
+		UserName: user.Username,
+		Active:   user.IsActive,
+		Meta: scim.Meta{
+			ResourceType: "User",
+			Location:     "/scim/v2/Users/" + url.PathEscape(user.UserID),
+		},
+	}
+
+	if user.TeamName != "" {
+		response.Groups = []scim.GroupRef{{Value: user.TeamName, Display: user.TeamName}}
+	}
+
+	return response
+}
+
+func toSCIMGroup(team *models.Team) scim.Group {
+	members := make([]scim.MemberRef, len(team.Members))
+	for i, member := range team.Members {
+		members[i] = scim.MemberRef{Value: member.UserID, Display: member.Username}
+	}
+
+	return scim.Group{
+		Schemas:     []string{scim.GroupSchema},
+		ID:          team.Name,
+		DisplayName: team.Name,
+		Members:     members,
+		Meta: scim.Meta{
+			ResourceType: "Group",
+			Location:     "/scim/v2/Groups/" + url.PathEscape(team.Name),
+		},
+	}
+}
@@ -2,30 +2,59 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"time"
 
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/common"
 	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/teams"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/users"
 	"github.com/IlyaAGL/avito_autumn_2025/internal/models"
+	"github.com/IlyaAGL/avito_autumn_2025/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type TeamRepository interface {
-	CreateTeam(ctx context.Context, team *models.Team) error
+	CreateTeam(ctx context.Context, team *models.Team, opts models.CreateTeamOptions) ([]models.ReviewerChange, error)
+	CreateTeams(ctx context.Context, teams []models.Team, opts models.CreateTeamOptions) ([]models.ReviewerChange, error)
 	GetTeam(ctx context.Context, teamName string) (*models.Team, error)
+	ListTeams(ctx context.Context) ([]models.TeamSummary, error)
 	TeamExists(ctx context.Context, teamName string) (bool, error)
 	BulkDeactivateUsers(ctx context.Context, teamName string) error
+	AddMembers(ctx context.Context, teamName string, members []models.Member) error
+	RemoveMembers(ctx context.Context, teamName string, userIDs []string, opts models.RemoveMembersOptions) ([]models.ReviewerChange, error)
+	MoveUser(ctx context.Context, userID, teamName string) (*models.User, []models.ReviewerChange, error)
+	RenameTeam(ctx context.Context, teamName, newName string) error
+	DeleteTeam(ctx context.Context, teamName string) error
+	SyncTeams(ctx context.Context, desired []models.SyncTeam, dryRun bool) (*models.SyncPlan, error)
+	SetReviewLimits(ctx context.Context, teamName string, limits models.ReviewLimits) error
+	GetReviewLoad(ctx context.Context, teamName string) (*models.TeamReviewLoad, error)
 }
 
 type TeamService struct {
 	teamRepo TeamRepository
+	userRepo UserRepository
+	metrics  ReassignmentMetrics
 }
 
-func NewTeamService(teamRepo TeamRepository) *TeamService {
+func NewTeamService(teamRepo TeamRepository, userRepo UserRepository, metrics ReassignmentMetrics) *TeamService {
 	return &TeamService{
 		teamRepo: teamRepo,
+		userRepo: userRepo,
+		metrics:  metrics,
 	}
 }
 
-func (s *TeamService) CreateTeam(ctx context.Context, req teams.CreateRequest) (*teams.CreateResponse, error) {
+func (s *TeamService) CreateTeam(ctx context.Context, req teams.CreateRequest) (_ *teams.CreateResponse, err error) {
+	ctx, span := tracer.Start(ctx, "TeamService.CreateTeam", trace.WithAttributes(
+		attribute.String("team_name", req.TeamName),
+		attribute.Bool("upsert", req.Upsert),
+		attribute.Bool("allow_move", req.AllowMove),
+	))
+	defer func() { tracing.End(span, err) }()
+
 	members := make([]models.Member, len(req.Members))
 	for i, member := range req.Members {
 		members[i] = models.Member{
@@ -40,11 +69,18 @@ func (s *TeamService) CreateTeam(ctx context.Context, req teams.CreateRequest) (
 		Members: members,
 	}
 
-	err := s.teamRepo.CreateTeam(ctx, team)
+	changes, err := s.teamRepo.CreateTeam(ctx, team, models.CreateTeamOptions{
+		Upsert:    req.Upsert,
+		AllowMove: req.AllowMove,
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	recordReviewerChanges(s.metrics, changes)
+
+	slog.InfoContext(ctx, "team created", "team_name", team.Name, "members", len(members))
+
 	memberResponses := make([]teams.MemberResponse, len(members))
 	for i, member := range members {
 		memberResponses[i] = teams.MemberResponse{
@@ -59,34 +95,58 @@ func (s *TeamService) CreateTeam(ctx context.Context, req teams.CreateRequest) (
 			TeamName: team.Name,
 			Members:  memberResponses,
 		},
+		Reassignments: reviewerChangesToResponse(changes),
 	}, nil
 }
 
-func (s *TeamService) GetTeam(ctx context.Context, teamName string) (*teams.TeamResponse, error) {
+func (s *TeamService) GetTeam(ctx context.Context, teamName string) (_ *teams.TeamResponse, err error) {
+	ctx, span := tracer.Start(ctx, "TeamService.GetTeam", trace.WithAttributes(
+		attribute.String("team_name", teamName),
+	))
+	defer func() { tracing.End(span, err) }()
+
 	team, err := s.teamRepo.GetTeam(ctx, teamName)
 	if err != nil {
 		return nil, err
 	}
 
-	memberResponses := make([]teams.MemberResponse, len(team.Members))
-	for i, member := range team.Members {
-		memberResponses[i] = teams.MemberResponse{
-			UserID:   member.UserID,
-			Username: member.Username,
-			IsActive: member.IsActive,
+	response := teamToResponse(team)
+
+	return &response, nil
+}
+
+func (s *TeamService) ListTeams(ctx context.Context) (_ *teams.ListResponse, err error) {
+	ctx, span := tracer.Start(ctx, "TeamService.ListTeams")
+	defer func() { tracing.End(span, err) }()
+
+	summaries, err := s.teamRepo.ListTeams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]teams.TeamSummary, len(summaries))
+	for i, summary := range summaries {
+		responses[i] = teams.TeamSummary{
+			TeamName:      summary.Name,
+			Members:       summary.Members,
+			ActiveMembers: summary.ActiveMembers,
 		}
 	}
 
-	return &teams.TeamResponse{
-		TeamName: team.Name,
-		Members:  memberResponses,
+	return &teams.ListResponse{
+		Teams: responses,
 	}, nil
 }
 
-func (s *TeamService) BulkDeactivateUsers(ctx context.Context, teamName string) error {
-    _, err := s.teamRepo.GetTeam(ctx, teamName)
+func (s *TeamService) BulkDeactivateUsers(ctx context.Context, teamName string) (err error) {
+	ctx, span := tracer.Start(ctx, "TeamService.BulkDeactivateUsers", trace.WithAttributes(
+		attribute.String("team_name", teamName),
+	))
+	defer func() { tracing.End(span, err) }()
+
+    _, err = s.teamRepo.GetTeam(ctx, teamName)
     if err != nil {
-        return fmt.Errorf("team not found: %w", err)
+        return fmt.Errorf("team %s: %w", teamName, err)
     }
 
     err = s.teamRepo.BulkDeactivateUsers(ctx, teamName)
@@ -94,5 +154,533 @@ func (s *TeamService) BulkDeactivateUsers(ctx context.Context, teamName string)
         return err
     }
 
+    slog.InfoContext(ctx, "team deactivated", "team_name", teamName)
+
     return nil
-}
\ No newline at end of file
+}
+
+func (s *TeamService) AddMembers(ctx context.Context, req teams.AddMembersRequest) (_ *teams.TeamResponse, err error) {
+	ctx, span := tracer.Start(ctx, "TeamService.AddMembers", trace.WithAttributes(
+		attribute.String("team_name", req.TeamName),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	members := make([]models.Member, len(req.Members))
+	for i, member := range req.Members {
+		members[i] = models.Member{
+			UserID:   member.UserID,
+			Username: member.Username,
+			IsActive: member.IsActive,
+		}
+	}
+
+	if err := s.teamRepo.AddMembers(ctx, req.TeamName, members); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "team members added", "team_name", req.TeamName, "members", len(members))
+
+	return s.GetTeam(ctx, req.TeamName)
+}
+
+func (s *TeamService) RemoveMembers(ctx context.Context, req teams.RemoveMembersRequest) (_ *teams.RemoveMembersResponse, err error) {
+	ctx, span := tracer.Start(ctx, "TeamService.RemoveMembers", trace.WithAttributes(
+		attribute.String("team_name", req.TeamName),
+		attribute.StringSlice("user_ids", req.UserIDs),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	changes, err := s.teamRepo.RemoveMembers(ctx, req.TeamName, req.UserIDs, models.RemoveMembersOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	recordReviewerChanges(s.metrics, changes)
+
+	slog.InfoContext(ctx, "team members removed",
+		"team_name", req.TeamName,
+		"user_ids", req.UserIDs,
+		"reassignments", len(changes),
+	)
+
+	team, err := s.GetTeam(ctx, req.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &teams.RemoveMembersResponse{
+		Team:          *team,
+		Reassignments: reviewerChangesToResponse(changes),
+	}, nil
+}
+
+// MoveUser moves a user to another team. Their open reviews on pull requests
+// authored outside the new team are handed to the author's team.
+func (s *TeamService) MoveUser(ctx context.Context, req users.MoveRequest) (_ *users.MoveResponse, err error) {
+	ctx, span := tracer.Start(ctx, "TeamService.MoveUser", trace.WithAttributes(
+		attribute.String("user_id", req.UserID),
+		attribute.String("team_name", req.TeamName),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	user, changes, err := s.teamRepo.MoveUser(ctx, req.UserID, req.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	recordReviewerChanges(s.metrics, changes)
+
+	slog.InfoContext(ctx, "user moved",
+		"user_id", user.UserID,
+		"team_name", user.TeamName,
+		"reassignments", len(changes),
+	)
+
+	return &users.MoveResponse{
+		User: users.UserResponse{
+			UserID:   user.UserID,
+			Username: user.Username,
+			TeamName: user.TeamName,
+			IsActive: user.IsActive,
+		},
+		Reassignments: reviewerChangesToResponse(changes),
+	}, nil
+}
+
+func (s *TeamService) RenameTeam(ctx context.Context, req teams.RenameRequest) (_ *teams.TeamResponse, err error) {
+	ctx, span := tracer.Start(ctx, "TeamService.RenameTeam", trace.WithAttributes(
+		attribute.String("team_name", req.TeamName),
+		attribute.String("new_team_name", req.NewTeamName),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	if err := s.teamRepo.RenameTeam(ctx, req.TeamName, req.NewTeamName); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "team renamed", "team_name", req.TeamName, "new_team_name", req.NewTeamName)
+
+	return s.GetTeam(ctx, req.NewTeamName)
+}
+
+// DeleteTeam deletes a team that has no members left.
+func (s *TeamService) DeleteTeam(ctx context.Context, teamName string) (err error) {
+	ctx, span := tracer.Start(ctx, "TeamService.DeleteTeam", trace.WithAttributes(
+		attribute.String("team_name", teamName),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	if err := s.teamRepo.DeleteTeam(ctx, teamName); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "team deleted", "team_name", teamName)
+
+	return nil
+}
+
+// SetReviewLimits replaces the defaults for members without limits of their
+// own.
+func (s *TeamService) SetReviewLimits(ctx context.Context, req teams.ReviewLimitsRequest) (_ *teams.ReviewLimitsResponse, err error) {
+	ctx, span := tracer.Start(ctx, "TeamService.SetReviewLimits", trace.WithAttributes(
+		attribute.String("team_name", req.TeamName),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	if err := s.teamRepo.SetReviewLimits(ctx, req.TeamName, reviewLimitsFromRequest(req.ReviewLimits)); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "team review limits changed",
+		"team_name", req.TeamName,
+		"max_open_reviews", optionalInt(req.MaxOpenReviews),
+		"review_cooldown_minutes", optionalInt(req.ReviewCooldownMinutes),
+	)
+
+	return &teams.ReviewLimitsResponse{
+		TeamName:     req.TeamName,
+		ReviewLimits: req.ReviewLimits,
+	}, nil
+}
+
+func (s *TeamService) GetReviewLoad(ctx context.Context, teamName string) (_ *teams.ReviewLoadResponse, err error) {
+	ctx, span := tracer.Start(ctx, "TeamService.GetReviewLoad", trace.WithAttributes(
+		attribute.String("team_name", teamName),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	load, err := s.teamRepo.GetReviewLoad(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]teams.MemberLoad, len(load.Members))
+	for i, member := range load.Members {
+		var cooldownUntil *string
+		if member.CooldownUntil != nil {
+			until := member.CooldownUntil.Format(time.RFC3339)
+			cooldownUntil = &until
+		}
+
+		members[i] = teams.MemberLoad{
+			UserID:        member.User.UserID,
+			Username:      member.User.Username,
+			IsActive:      member.User.IsActive,
+			OpenReviews:   member.OpenReviews,
+			ReviewLimits:  reviewLimitsToResponse(member.Limits),
+			CooldownUntil: cooldownUntil,
+			Away:          member.Away,
+			Available:     member.Available,
+		}
+	}
+
+	return &teams.ReviewLoadResponse{
+		TeamName:     load.TeamName,
+		ReviewLimits: reviewLimitsToResponse(load.Limits),
+		Members:      members,
+	}, nil
+}
+
+// SyncTeams compares the desired roster with the database and, unless
+// req.DryRun is set, applies the difference atomically.
+func (s *TeamService) SyncTeams(ctx context.Context, req teams.SyncRequest) (_ *teams.SyncResponse, err error) {
+	ctx, span := tracer.Start(ctx, "TeamService.SyncTeams", trace.WithAttributes(
+		attribute.Int("teams", len(req.Teams)),
+		attribute.Bool("dry_run", req.DryRun),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	desired := make([]models.SyncTeam, len(req.Teams))
+	for i, team := range req.Teams {
+		members := make([]models.Member, len(team.Members))
+		for j, member := range team.Members {
+			members[j] = models.Member{
+				UserID:   member.UserID,
+				Username: member.Username,
+				IsActive: member.IsActive == nil || *member.IsActive,
+			}
+		}
+
+		desired[i] = models.SyncTeam{
+			Name:    team.TeamName,
+			Limits:  reviewLimitsFromRequest(team.ReviewLimits),
+			Members: members,
+		}
+	}
+
+	plan, err := s.teamRepo.SyncTeams(ctx, desired, req.DryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.DryRun && !plan.Empty() {
+		recordReviewerChanges(s.metrics, plan.Reassignments)
+
+		slog.InfoContext(ctx, "roster synced",
+			"teams_created", len(plan.TeamsCreated),
+			"teams_updated", len(plan.TeamsUpdated),
+			"teams_removed", len(plan.TeamsRemoved),
+			"users_added", len(plan.UsersAdded),
+			"users_moved", len(plan.UsersMoved),
+			"users_updated", len(plan.UsersUpdated),
+			"users_removed", len(plan.UsersRemoved),
+			"reassignments", len(plan.Reassignments),
+		)
+	}
+
+	return &teams.SyncResponse{
+		DryRun:        req.DryRun,
+		TeamsCreated:  append([]string{}, plan.TeamsCreated...),
+		TeamsUpdated:  append([]string{}, plan.TeamsUpdated...),
+		TeamsRemoved:  append([]string{}, plan.TeamsRemoved...),
+		UsersAdded:    syncUserChangesToResponse(plan.UsersAdded),
+		UsersMoved:    syncUserChangesToResponse(plan.UsersMoved),
+		UsersUpdated:  syncUserChangesToResponse(plan.UsersUpdated),
+		UsersRemoved:  syncUserChangesToResponse(plan.UsersRemoved),
+		Reassignments: reviewerChangesToResponse(plan.Reassignments),
+	}, nil
+}
+
+func syncUserChangesToResponse(changes []models.SyncUserChange) []teams.SyncUserChange {
+	response := make([]teams.SyncUserChange, len(changes))
+	for i, change := range changes {
+		response[i] = teams.SyncUserChange{
+			UserID:   change.UserID,
+			Username: change.Username,
+			TeamName: change.TeamName,
+			FromTeam: change.FromTeam,
+			IsActive: change.IsActive,
+		}
+	}
+
+	return response
+}
+
+// ImportTeams creates or updates the teams listed in req.Rows; members not
+// listed are left alone. Rows repeating a user, and rows for users of
+// another team unless req.AllowMove is set, are rejected. An atomic import
+// with rejected rows applies nothing and returns only the rejections; a
+// best-effort import applies the remaining rows team by team.
+func (s *TeamService) ImportTeams(ctx context.Context, req teams.ImportRequest) (_ *teams.ImportResponse, err error) {
+	ctx, span := tracer.Start(ctx, "TeamService.ImportTeams", trace.WithAttributes(
+		attribute.Int("rows", len(req.Rows)),
+		attribute.String("mode", req.Mode),
+		attribute.Bool("allow_move", req.AllowMove),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	response := &teams.ImportResponse{
+		Mode:          req.Mode,
+		Teams:         []string{},
+		Skipped:       []teams.ImportError{},
+		Reassignments: []common.ReviewerChange{},
+	}
+
+	currentUsers, err := s.userRepo.ListUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	currentTeam := make(map[string]string, len(currentUsers))
+	for _, user := range currentUsers {
+		currentTeam[user.UserID] = user.TeamName
+	}
+
+	var order []string
+	rowsByTeam := make(map[string][]teams.ImportRow)
+	seen := make(map[string]int)
+
+	for _, row := range req.Rows {
+		if line, ok := seen[row.UserID]; ok {
+			response.Skipped = append(response.Skipped, teams.ImportError{
+				Line:   row.Line,
+				Field:  "user_id",
+				Reason: fmt.Sprintf("user is already listed on line %d", line),
+			})
+			continue
+		}
+
+		seen[row.UserID] = row.Line
+
+		if team := currentTeam[row.UserID]; team != "" && team != row.TeamName && !req.AllowMove {
+			response.Skipped = append(response.Skipped, teams.ImportError{
+				Line:   row.Line,
+				Field:  "user_id",
+				Reason: fmt.Sprintf("user belongs to team %s", team),
+			})
+			continue
+		}
+
+		if _, ok := rowsByTeam[row.TeamName]; !ok {
+			order = append(order, row.TeamName)
+		}
+
+		rowsByTeam[row.TeamName] = append(rowsByTeam[row.TeamName], row)
+	}
+
+	if req.Mode == teams.ImportAtomic {
+		if len(response.Skipped) > 0 {
+			return response, nil
+		}
+
+		batch := make([]models.Team, len(order))
+		for i, name := range order {
+			batch[i] = models.Team{Name: name, Members: importMembers(rowsByTeam[name])}
+		}
+
+		changes, err := s.teamRepo.CreateTeams(ctx, batch, models.CreateTeamOptions{
+			Upsert:    true,
+			AllowMove: req.AllowMove,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		recordReviewerChanges(s.metrics, changes)
+
+		response.Teams = order
+		response.Imported = len(req.Rows)
+		response.Reassignments = reviewerChangesToResponse(changes)
+	} else {
+		for _, name := range order {
+			rows := rowsByTeam[name]
+
+			created, err := s.CreateTeam(ctx, teams.CreateRequest{
+				TeamName:  name,
+				Members:   importMemberRequests(rows),
+				Upsert:    true,
+				AllowMove: req.AllowMove,
+			})
+			if err != nil {
+				slog.WarnContext(ctx, "team import failed", "team_name", name, "error", err)
+
+				reason := "team could not be imported"
+				if errors.Is(err, models.ErrMemberConflict) {
+					reason = "a member of this team belongs to another team"
+				}
+
+				for _, row := range rows {
+					response.Skipped = append(response.Skipped, teams.ImportError{Line: row.Line, Reason: reason})
+				}
+				continue
+			}
+
+			response.Teams = append(response.Teams, name)
+			response.Imported += len(rows)
+			response.Reassignments = append(response.Reassignments, created.Reassignments...)
+		}
+	}
+
+	slog.InfoContext(ctx, "teams imported",
+		"mode", req.Mode,
+		"teams", len(response.Teams),
+		"imported", response.Imported,
+		"skipped", len(response.Skipped),
+	)
+
+	return response, nil
+}
+
+// ExportTeams returns every team with its members, including empty teams.
+func (s *TeamService) ExportTeams(ctx context.Context) (_ *teams.ExportResponse, err error) {
+	ctx, span := tracer.Start(ctx, "TeamService.ExportTeams")
+	defer func() { tracing.End(span, err) }()
+
+	all, err := loadTeams(ctx, s.teamRepo, s.userRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]teams.TeamResponse, len(all))
+	for i := range all {
+		responses[i] = teamToResponse(&all[i])
+	}
+
+	return &teams.ExportResponse{Teams: responses}, nil
+}
+
+// loadTeams returns every team with its members, including empty teams,
+// with two queries instead of one per team.
+func loadTeams(ctx context.Context, teamRepo TeamRepository, userRepo UserRepository) ([]models.Team, error) {
+	summaries, err := teamRepo.ListTeams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	allUsers, err := userRepo.ListUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make(map[string][]models.Member, len(summaries))
+	for _, user := range allUsers {
+		if user.TeamName == "" {
+			continue
+		}
+
+		members[user.TeamName] = append(members[user.TeamName], models.Member{
+			UserID:   user.UserID,
+			Username: user.Username,
+			IsActive: user.IsActive,
+		})
+	}
+
+	all := make([]models.Team, len(summaries))
+	for i, summary := range summaries {
+		all[i] = models.Team{Name: summary.Name, Members: members[summary.Name]}
+	}
+
+	return all, nil
+}
+
+func importMembers(rows []teams.ImportRow) []models.Member {
+	members := make([]models.Member, len(rows))
+	for i, row := range rows {
+		members[i] = models.Member{
+			UserID:   row.UserID,
+			Username: row.Username,
+			IsActive: row.IsActive,
+		}
+	}
+
+	return members
+}
+
+func importMemberRequests(rows []teams.ImportRow) []teams.MemberCreate {
+	members := make([]teams.MemberCreate, len(rows))
+	for i, row := range rows {
+		members[i] = teams.MemberCreate{
+			UserID:   row.UserID,
+			Username: row.Username,
+			IsActive: row.IsActive,
+		}
+	}
+
+	return members
+}
+
+func teamToResponse(team *models.Team) teams.TeamResponse {
+	memberResponses := make([]teams.MemberResponse, len(team.Members))
+	for i, member := range team.Members {
+		memberResponses[i] = teams.MemberResponse{
+			UserID:   member.UserID,
+			Username: member.Username,
+			IsActive: member.IsActive,
+		}
+	}
+
+	return teams.TeamResponse{
+		TeamName: team.Name,
+		Members:  memberResponses,
+	}
+}
+
+func reviewLimitsFromRequest(limits common.ReviewLimits) models.ReviewLimits {
+	return models.ReviewLimits{
+		MaxOpenReviews:  limits.MaxOpenReviews,
+		CooldownMinutes: limits.ReviewCooldownMinutes,
+	}
+}
+
+func reviewLimitsToResponse(limits models.ReviewLimits) common.ReviewLimits {
+	return common.ReviewLimits{
+		MaxOpenReviews:        limits.MaxOpenReviews,
+		ReviewCooldownMinutes: limits.CooldownMinutes,
+	}
+}
+
+// optionalInt unwraps a nullable setting for logging.
+func optionalInt(value *int) any {
+	if value == nil {
+		return nil
+	}
+
+	return *value
+}
+
+// recordReviewerChanges counts the reviews a repository handed over or
+// dropped while changing the roster.
+func recordReviewerChanges(metrics ReassignmentMetrics, changes []models.ReviewerChange) {
+	for _, change := range changes {
+		if change.NewReviewerID == "" {
+			metrics.Reassignment(reassignmentNoCandidate)
+			continue
+		}
+
+		metrics.Reassignment(reassignmentSuccess)
+		metrics.ReviewAssigned(change.NewReviewerID)
+	}
+}
+
+func reviewerChangesToResponse(changes []models.ReviewerChange) []common.ReviewerChange {
+	responses := make([]common.ReviewerChange, len(changes))
+	for i, change := range changes {
+		responses[i] = common.ReviewerChange{
+			PullRequestID: change.PullRequestID,
+			OldReviewerID: change.OldReviewerID,
+			NewReviewerID: change.NewReviewerID,
+		}
+	}
+
+	return responses
+}
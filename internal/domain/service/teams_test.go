@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/common"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/teams"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/models"
+)
+
+// fakeSyncRepo records what SyncTeams is asked for and answers with plan.
+type fakeSyncRepo struct {
+	TeamRepository
+	plan    models.SyncPlan
+	desired []models.SyncTeam
+	dryRun  bool
+}
+
+func (r *fakeSyncRepo) SyncTeams(_ context.Context, desired []models.SyncTeam, dryRun bool) (*models.SyncPlan, error) {
+	r.desired, r.dryRun = desired, dryRun
+
+	return &r.plan, nil
+}
+
+// fakeRemoveRepo answers RemoveMembers with changes.
+type fakeRemoveRepo struct {
+	TeamRepository
+	changes []models.ReviewerChange
+}
+
+func (r *fakeRemoveRepo) RemoveMembers(context.Context, string, []string, models.RemoveMembersOptions) ([]models.ReviewerChange, error) {
+	return r.changes, nil
+}
+
+func (r *fakeRemoveRepo) GetTeam(_ context.Context, teamName string) (*models.Team, error) {
+	return &models.Team{Name: teamName}, nil
+}
+
+func TestRemoveMembersRecordsReassignments(t *testing.T) {
+	repo := &fakeRemoveRepo{changes: []models.ReviewerChange{
+		{PullRequestID: "pr-1", OldReviewerID: "u2", NewReviewerID: "u3"},
+		{PullRequestID: "pr-2", OldReviewerID: "u2", NewReviewerID: "u4"},
+		{PullRequestID: "pr-3", OldReviewerID: "u2"},
+	}}
+	metrics := &fakeMetrics{}
+	s := NewTeamService(repo, nil, metrics)
+
+	_, err := s.RemoveMembers(context.Background(), teams.RemoveMembersRequest{TeamName: "backend", UserIDs: []string{"u2"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"u3", "u4"}; !reflect.DeepEqual(metrics.assigned, want) {
+		t.Errorf("assigned = %v, want %v", metrics.assigned, want)
+	}
+	want := []string{reassignmentSuccess, reassignmentSuccess, reassignmentNoCandidate}
+	if !reflect.DeepEqual(metrics.reassignments, want) {
+		t.Errorf("reassignments = %v, want %v", metrics.reassignments, want)
+	}
+}
+
+func TestSyncTeams(t *testing.T) {
+	five, inactive := 5, false
+
+	req := teams.SyncRequest{Teams: []teams.SyncTeam{{
+		TeamName:     "backend",
+		ReviewLimits: common.ReviewLimits{MaxOpenReviews: &five},
+		Members: []teams.SyncMember{
+			{UserID: "u1", Username: "Alice"},
+			{UserID: "u2", Username: "Bob", IsActive: &inactive},
+		},
+	}}}
+	wantDesired := []models.SyncTeam{{
+		Name:   "backend",
+		Limits: models.ReviewLimits{MaxOpenReviews: &five},
+		Members: []models.Member{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: false},
+		},
+	}}
+	plan := models.SyncPlan{
+		TeamsUpdated: []string{"backend"},
+		UsersRemoved: []models.SyncUserChange{{UserID: "u3", Username: "Carol", TeamName: "backend"}},
+		Reassignments: []models.ReviewerChange{
+			{PullRequestID: "pr-1", OldReviewerID: "u3", NewReviewerID: "u1"},
+			{PullRequestID: "pr-2", OldReviewerID: "u3"},
+		},
+	}
+
+	for _, dryRun := range []bool{true, false} {
+		t.Run(fmt.Sprintf("dry run %v", dryRun), func(t *testing.T) {
+			repo := &fakeSyncRepo{plan: plan}
+			metrics := &fakeMetrics{}
+			s := NewTeamService(repo, nil, metrics)
+
+			req := req
+			req.DryRun = dryRun
+
+			response, err := s.SyncTeams(context.Background(), req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if repo.dryRun != dryRun || response.DryRun != dryRun {
+				t.Errorf("dry run: repository %v, response %v, want %v", repo.dryRun, response.DryRun, dryRun)
+			}
+			if !reflect.DeepEqual(repo.desired, wantDesired) {
+				t.Errorf("desired = %+v, want %+v", repo.desired, wantDesired)
+			}
+			if !reflect.DeepEqual(response.TeamsUpdated, []string{"backend"}) || len(response.UsersRemoved) != 1 {
+				t.Errorf("plan = %+v, want backend updated and u3 removed", response)
+			}
+			if len(response.Reassignments) != 2 {
+				t.Errorf("reassignments = %+v, want both of the plan", response.Reassignments)
+			}
+
+			// A dry run changes nothing, so nothing is counted.
+			var wantAssigned, wantOutcomes []string
+			if !dryRun {
+				wantAssigned = []string{"u1"}
+				wantOutcomes = []string{reassignmentSuccess, reassignmentNoCandidate}
+			}
+			if !reflect.DeepEqual(metrics.assigned, wantAssigned) || !reflect.DeepEqual(metrics.reassignments, wantOutcomes) {
+				t.Errorf("metrics: assigned %v, outcomes %v, want %v and %v",
+					metrics.assigned, metrics.reassignments, wantAssigned, wantOutcomes)
+			}
+		})
+	}
+}
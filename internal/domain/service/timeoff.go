@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/timeoff"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/models"
+	"github.com/IlyaAGL/avito_autumn_2025/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type TimeOffRepository interface {
+	CreateTimeOff(ctx context.Context, timeOff *models.TimeOff) error
+	ListUserTimeOff(ctx context.Context, userID string) ([]models.TimeOff, error)
+	ListAway(ctx context.Context, teamName string) ([]models.Away, error)
+	DeleteTimeOff(ctx context.Context, userID string, timeOffID int64) error
+	StartDueTimeOff(ctx context.Context) ([]models.TimeOff, []models.ReviewerChange, error)
+	EndDueTimeOff(ctx context.Context) ([]models.TimeOff, error)
+}
+
+// TimeOffService manages absence periods. Users on time off are skipped by
+// reviewer selection for the whole period; RunScheduler hands their open
+// reviews over when a period asking for it starts.
+type TimeOffService struct {
+	timeOffRepo TimeOffRepository
+	userRepo    UserRepository
+	teamRepo    TeamRepository
+	metrics     ReassignmentMetrics
+}
+
+func NewTimeOffService(timeOffRepo TimeOffRepository, userRepo UserRepository, teamRepo TeamRepository, metrics ReassignmentMetrics) *TimeOffService {
+	return &TimeOffService{
+		timeOffRepo: timeOffRepo,
+		userRepo:    userRepo,
+		teamRepo:    teamRepo,
+		metrics:     metrics,
+	}
+}
+
+func (s *TimeOffService) CreateTimeOff(ctx context.Context, req timeoff.CreateRequest) (_ *timeoff.CreateResponse, err error) {
+	ctx, span := tracer.Start(ctx, "TimeOffService.CreateTimeOff", trace.WithAttributes(
+		attribute.String("user_id", req.UserID),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	if !req.EndsAt.After(time.Now()) {
+		return nil, fmt.Errorf("ends_at %s is in the past: %w", req.EndsAt.Format(time.RFC3339), models.ErrInvalidValue)
+	}
+
+	timeOff := &models.TimeOff{
+		UserID:          req.UserID,
+		StartsAt:        req.StartsAt,
+		EndsAt:          req.EndsAt,
+		Reason:          req.Reason,
+		ReassignReviews: req.ReassignReviews,
+	}
+
+	if err := s.timeOffRepo.CreateTimeOff(ctx, timeOff); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "time off scheduled",
+		"user_id", timeOff.UserID,
+		"time_off_id", timeOff.ID,
+		"starts_at", timeOff.StartsAt,
+		"ends_at", timeOff.EndsAt,
+	)
+
+	return &timeoff.CreateResponse{
+		TimeOff: timeOffToResponse(timeOff),
+	}, nil
+}
+
+func (s *TimeOffService) ListTimeOff(ctx context.Context, userID string) (_ *timeoff.ListResponse, err error) {
+	ctx, span := tracer.Start(ctx, "TimeOffService.ListTimeOff", trace.WithAttributes(
+		attribute.String("user_id", userID),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	if _, err := s.userRepo.GetUser(ctx, userID); err != nil {
+		return nil, fmt.Errorf("user %s: %w", userID, err)
+	}
+
+	periods, err := s.timeOffRepo.ListUserTimeOff(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]timeoff.TimeOffResponse, len(periods))
+	for i := range periods {
+		responses[i] = timeOffToResponse(&periods[i])
+	}
+
+	return &timeoff.ListResponse{
+		UserID:  userID,
+		TimeOff: responses,
+	}, nil
+}
+
+func (s *TimeOffService) CancelTimeOff(ctx context.Context, req timeoff.CancelRequest) (err error) {
+	ctx, span := tracer.Start(ctx, "TimeOffService.CancelTimeOff", trace.WithAttributes(
+		attribute.String("user_id", req.UserID),
+		attribute.Int64("time_off_id", req.TimeOffID),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	if err := s.timeOffRepo.DeleteTimeOff(ctx, req.UserID, req.TimeOffID); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "time off cancelled", "user_id", req.UserID, "time_off_id", req.TimeOffID)
+
+	return nil
+}
+
+// ListAway returns the members of the team who are on time off now.
+func (s *TimeOffService) ListAway(ctx context.Context, teamName string) (_ *timeoff.AwayResponse, err error) {
+	ctx, span := tracer.Start(ctx, "TimeOffService.ListAway", trace.WithAttributes(
+		attribute.String("team_name", teamName),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	exists, err := s.teamRepo.TeamExists(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("team %s: %w", teamName, models.ErrNotFound)
+	}
+
+	away, err := s.timeOffRepo.ListAway(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]timeoff.AwayMember, len(away))
+	for i, member := range away {
+		members[i] = timeoff.AwayMember{
+			UserID:    member.User.UserID,
+			Username:  member.User.Username,
+			TimeOffID: member.TimeOff.ID,
+			EndsAt:    member.TimeOff.EndsAt.Format(time.RFC3339),
+			Reason:    member.TimeOff.Reason,
+		}
+	}
+
+	return &timeoff.AwayResponse{
+		TeamName: teamName,
+		Away:     members,
+	}, nil
+}
+
+// RunScheduler applies the schedule every interval until ctx is done.
+func (s *TimeOffService) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.ApplySchedule(ctx); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "time off scheduler failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ApplySchedule starts the periods that have begun, reassigning open reviews
+// where asked, and ends the periods that are over.
+func (s *TimeOffService) ApplySchedule(ctx context.Context) (err error) {
+	ctx, span := tracer.Start(ctx, "TimeOffService.ApplySchedule")
+	defer func() { tracing.End(span, err) }()
+
+	started, changes, err := s.timeOffRepo.StartDueTimeOff(ctx)
+	if err != nil {
+		return fmt.Errorf("start time off: %w", err)
+	}
+
+	recordReviewerChanges(s.metrics, changes)
+
+	for _, timeOff := range started {
+		slog.InfoContext(ctx, "time off started",
+			"user_id", timeOff.UserID,
+			"time_off_id", timeOff.ID,
+			"ends_at", timeOff.EndsAt,
+		)
+	}
+
+	for _, change := range changes {
+		slog.InfoContext(ctx, "review handed over for time off",
+			"pull_request_id", change.PullRequestID,
+			"old_reviewer_id", change.OldReviewerID,
+			"new_reviewer_id", change.NewReviewerID,
+		)
+	}
+
+	ended, err := s.timeOffRepo.EndDueTimeOff(ctx)
+	if err != nil {
+		return fmt.Errorf("end time off: %w", err)
+	}
+
+	for _, timeOff := range ended {
+		slog.InfoContext(ctx, "time off ended", "user_id", timeOff.UserID, "time_off_id", timeOff.ID)
+	}
+
+	span.SetAttributes(
+		attribute.Int("started", len(started)),
+		attribute.Int("ended", len(ended)),
+		attribute.Int("reassignments", len(changes)),
+	)
+
+	return nil
+}
+
+func timeOffToResponse(timeOff *models.TimeOff) timeoff.TimeOffResponse {
+	return timeoff.TimeOffResponse{
+		TimeOffID:       timeOff.ID,
+		UserID:          timeOff.UserID,
+		StartsAt:        timeOff.StartsAt.Format(time.RFC3339),
+		EndsAt:          timeOff.EndsAt.Format(time.RFC3339),
+		Reason:          timeOff.Reason,
+		ReassignReviews: timeOff.ReassignReviews,
+	}
+}
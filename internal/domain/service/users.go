@@ -2,17 +2,31 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
+	"slices"
 
 	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/users"
 	"github.com/IlyaAGL/avito_autumn_2025/internal/models"
+	"github.com/IlyaAGL/avito_autumn_2025/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type UserRepository interface {
 	CreateOrUpdateUser(ctx context.Context, user *models.User) error
+	CreateUser(ctx context.Context, user *models.User) error
+	UpdateUser(ctx context.Context, userID, username string, isActive bool) (*models.User, error)
 	GetUser(ctx context.Context, userID string) (*models.User, error)
+	ListUsers(ctx context.Context) ([]models.User, error)
 	SetUserActive(ctx context.Context, userID string, isActive bool) (*models.User, error)
 	GetActiveTeamMembers(ctx context.Context, teamName string, excludeUserIDs []string) ([]models.User, error)
+	GetAvailableUsers(ctx context.Context, userIDs, teamNames, excludeUserIDs []string) ([]models.User, error)
 	GetUserReviewPRs(ctx context.Context, userID string) ([]models.PullRequestShort, error)
+	SetReviewLimits(ctx context.Context, userID string, limits models.ReviewLimits) error
+	GetSkills(ctx context.Context, userIDs []string) (map[string][]string, error)
+	GetOpenReviews(ctx context.Context, userIDs []string) (map[string]models.OpenReviews, error)
+	SetSkills(ctx context.Context, userID string, skills []string) error
 }
 
 type userService struct {
@@ -27,12 +41,19 @@ func NewUserService(userRepo UserRepository, prRepo PullRequestRepository) *user
 	}
 }
 
-func (s *userService) SetUserActive(ctx context.Context, req users.SetActiveRequest) (*users.SetActiveResponse, error) {
+func (s *userService) SetUserActive(ctx context.Context, req users.SetActiveRequest) (_ *users.SetActiveResponse, err error) {
+	ctx, span := tracer.Start(ctx, "userService.SetUserActive", trace.WithAttributes(
+		attribute.String("user_id", req.UserID),
+	))
+	defer func() { tracing.End(span, err) }()
+
 	user, err := s.userRepo.SetUserActive(ctx, req.UserID, req.IsActive)
 	if err != nil {
 		return nil, err
 	}
 
+	slog.InfoContext(ctx, "user activity changed", "user_id", user.UserID, "is_active", user.IsActive)
+
 	return &users.SetActiveResponse{
 		User: users.UserResponse{
 			UserID:   user.UserID,
@@ -43,8 +64,13 @@ func (s *userService) SetUserActive(ctx context.Context, req users.SetActiveRequ
 	}, nil
 }
 
-func (s *userService) GetUserReviewPRs(ctx context.Context, userID string) (*users.ReviewResponse, error) {
-	_, err := s.userRepo.GetUser(ctx, userID)
+func (s *userService) GetUserReviewPRs(ctx context.Context, userID string) (_ *users.ReviewResponse, err error) {
+	ctx, span := tracer.Start(ctx, "userService.GetUserReviewPRs", trace.WithAttributes(
+		attribute.String("user_id", userID),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	_, err = s.userRepo.GetUser(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -70,7 +96,12 @@ func (s *userService) GetUserReviewPRs(ctx context.Context, userID string) (*use
 	}, nil
 }
 
-func (s *userService) GetUser(ctx context.Context, userID string) (*users.UserResponse, error) {
+func (s *userService) GetUser(ctx context.Context, userID string) (_ *users.UserResponse, err error) {
+	ctx, span := tracer.Start(ctx, "userService.GetUser", trace.WithAttributes(
+		attribute.String("user_id", userID),
+	))
+	defer func() { tracing.End(span, err) }()
+
 	user, err := s.userRepo.GetUser(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -84,3 +115,93 @@ func (s *userService) GetUser(ctx context.Context, userID string) (*users.UserRe
 	}, nil
 }
 
+// SetReviewLimits replaces the user's own limits; null fields fall back to
+// the team defaults.
+func (s *userService) SetReviewLimits(ctx context.Context, req users.ReviewLimitsRequest) (_ *users.ReviewLimitsResponse, err error) {
+	ctx, span := tracer.Start(ctx, "userService.SetReviewLimits", trace.WithAttributes(
+		attribute.String("user_id", req.UserID),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	if err := s.userRepo.SetReviewLimits(ctx, req.UserID, reviewLimitsFromRequest(req.ReviewLimits)); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "user review limits changed",
+		"user_id", req.UserID,
+		"max_open_reviews", optionalInt(req.MaxOpenReviews),
+		"review_cooldown_minutes", optionalInt(req.ReviewCooldownMinutes),
+	)
+
+	return &users.ReviewLimitsResponse{
+		UserID:       req.UserID,
+		ReviewLimits: req.ReviewLimits,
+	}, nil
+}
+
+func (s *userService) GetSkills(ctx context.Context, userID string) (_ *users.SkillsResponse, err error) {
+	ctx, span := tracer.Start(ctx, "userService.GetSkills", trace.WithAttributes(
+		attribute.String("user_id", userID),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	if _, err := s.userRepo.GetUser(ctx, userID); err != nil {
+		return nil, fmt.Errorf("user %s: %w", userID, err)
+	}
+
+	skills, err := s.userRepo.GetSkills(ctx, []string{userID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &users.SkillsResponse{
+		UserID: userID,
+		Skills: append([]string{}, skills[userID]...),
+	}, nil
+}
+
+// SetSkills replaces the user's skills; reviewer selection prefers users with
+// a skill among the tags of a PR.
+func (s *userService) SetSkills(ctx context.Context, req users.SkillsRequest) (_ *users.SkillsResponse, err error) {
+	ctx, span := tracer.Start(ctx, "userService.SetSkills", trace.WithAttributes(
+		attribute.String("user_id", req.UserID),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	if err := s.userRepo.SetSkills(ctx, req.UserID, req.Skills); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "user skills changed", "user_id", req.UserID, "skills", req.Skills)
+
+	skills := slices.Clone(req.Skills)
+	slices.Sort(skills)
+
+	return &users.SkillsResponse{
+		UserID: req.UserID,
+		Skills: skills,
+	}, nil
+}
+
+// ExportUsers returns every user, including users without a team.
+func (s *userService) ExportUsers(ctx context.Context) (_ *users.ExportResponse, err error) {
+	ctx, span := tracer.Start(ctx, "userService.ExportUsers")
+	defer func() { tracing.End(span, err) }()
+
+	all, err := s.userRepo.ListUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]users.UserResponse, len(all))
+	for i, user := range all {
+		responses[i] = users.UserResponse{
+			UserID:   user.UserID,
+			Username: user.Username,
+			TeamName: user.TeamName,
+			IsActive: user.IsActive,
+		}
+	}
+
+	return &users.ExportResponse{Users: responses}, nil
+}
@@ -0,0 +1,106 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "pr_reviewers"
+
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests        *prometheus.CounterVec
+	httpRequestDuration *prometheus.HistogramVec
+	reviewsAssigned     *prometheus.CounterVec
+	reassignments       *prometheus.CounterVec
+	understaffedPRs     prometheus.Counter
+	timeToMerge         prometheus.Histogram
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by method, route and status.",
+		}, []string{"method", "route", "status"}),
+		httpRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method, route and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		reviewsAssigned: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reviews_assigned_total",
+			Help:      "Reviews assigned to a user on PR creation or reassignment.",
+		}, []string{"user_id"}),
+		reassignments: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reassignments_total",
+			Help:      "Reviewer reassignments by outcome.",
+		}, []string{"outcome"}),
+		understaffedPRs: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "understaffed_prs_created_total",
+			Help:      "PRs created with fewer reviewers than the policy requires.",
+		}),
+		timeToMerge: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "pr_time_to_merge_seconds",
+			Help:      "Time from PR creation to merge.",
+			Buckets:   []float64{60, 300, 900, 3600, 4 * 3600, 12 * 3600, 24 * 3600, 3 * 24 * 3600, 7 * 24 * 3600},
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpRequestDuration,
+		m.reviewsAssigned,
+		m.reassignments,
+		m.understaffedPRs,
+		m.timeToMerge,
+	)
+
+	return m
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+func (m *Metrics) MustRegister(cs ...prometheus.Collector) {
+	m.registry.MustRegister(cs...)
+}
+
+func (m *Metrics) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	statusLabel := strconv.Itoa(status)
+
+	m.httpRequests.WithLabelValues(method, route, statusLabel).Inc()
+	m.httpRequestDuration.WithLabelValues(method, route, statusLabel).Observe(duration.Seconds())
+}
+
+func (m *Metrics) ReviewAssigned(userID string) {
+	m.reviewsAssigned.WithLabelValues(userID).Inc()
+}
+
+func (m *Metrics) Reassignment(outcome string) {
+	m.reassignments.WithLabelValues(outcome).Inc()
+}
+
+func (m *Metrics) PRUnderstaffed() {
+	m.understaffedPRs.Inc()
+}
+
+func (m *Metrics) PRMerged(timeToMerge time.Duration) {
+	m.timeToMerge.Observe(timeToMerge.Seconds())
+}
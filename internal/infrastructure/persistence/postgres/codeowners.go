@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/IlyaAGL/avito_autumn_2025/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type postgresCodeOwnersRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresCodeOwnersRepository(pool *pgxpool.Pool) *postgresCodeOwnersRepo {
+	return &postgresCodeOwnersRepo{pool: pool}
+}
+
+// ReplaceRules makes rules, in file order, the rules of the repository; no
+// rules removes them.
+func (repo *postgresCodeOwnersRepo) ReplaceRules(ctx context.Context, repository string, rules []models.CodeOwnerRule) error {
+	tx, err := repo.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer rollback(ctx, tx)
+
+	var locked string
+	err = tx.QueryRow(ctx, "SELECT repository FROM repositories WHERE repository = $1 FOR UPDATE", repository).Scan(&locked)
+	if err != nil {
+		return fmt.Errorf("repository %s: %w", repository, notFound(err))
+	}
+
+	batch := &pgx.Batch{}
+	batch.Queue("DELETE FROM code_owner_rules WHERE repository = $1", repository)
+	for i, rule := range rules {
+		owners := rule.Owners
+		if owners == nil {
+			owners = []string{}
+		}
+
+		batch.Queue(
+			`INSERT INTO code_owner_rules (repository, position, line, pattern, owners)
+             VALUES ($1, $2, $3, $4, $5)`,
+			repository, i, rule.Line, rule.Pattern, owners,
+		)
+	}
+
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ListRules returns the rules of the repository in file order.
+func (repo *postgresCodeOwnersRepo) ListRules(ctx context.Context, repository string) ([]models.CodeOwnerRule, error) {
+	var exists bool
+	err := repo.pool.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM repositories WHERE repository = $1)", repository).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("repository %s: %w", repository, models.ErrNotFound)
+	}
+
+	rows, err := repo.pool.Query(ctx,
+		`SELECT line, pattern, owners FROM code_owner_rules
+         WHERE repository = $1
+         ORDER BY position`,
+		repository,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.CodeOwnerRule, error) {
+		var rule models.CodeOwnerRule
+		err := row.Scan(&rule.Line, &rule.Pattern, &rule.Owners)
+		return rule, err
+	})
+}
+
+// MissingOwners returns the userIDs and teamNames that do not exist.
+func (repo *postgresCodeOwnersRepo) MissingOwners(ctx context.Context, userIDs, teamNames []string) ([]string, []string, error) {
+	var missingUsers, missingTeams []string
+
+	err := repo.pool.QueryRow(ctx,
+		`SELECT
+             ARRAY(SELECT id FROM unnest($1::text[]) AS id
+                   WHERE NOT EXISTS (SELECT 1 FROM users WHERE user_id = id) ORDER BY id),
+             ARRAY(SELECT name FROM unnest($2::text[]) AS name
+                   WHERE NOT EXISTS (SELECT 1 FROM teams WHERE team_name = name) ORDER BY name)`,
+		userIDs, teamNames,
+	).Scan(&missingUsers, &missingTeams)
+
+	return missingUsers, missingTeams, err
+}
@@ -0,0 +1,33 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type postgresHealthRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresHealthRepository(pool *pgxpool.Pool) *postgresHealthRepo {
+	return &postgresHealthRepo{pool: pool}
+}
+
+func (repo *postgresHealthRepo) Ping(ctx context.Context) error {
+	return repo.pool.Ping(ctx)
+}
+
+func (repo *postgresHealthRepo) SchemaVersion(ctx context.Context) (uint, bool, error) {
+	var version int64
+	var dirty bool
+
+	err := repo.pool.QueryRow(ctx,
+		"SELECT version, dirty FROM schema_migrations LIMIT 1",
+	).Scan(&version, &dirty)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return uint(version), dirty, nil
+}
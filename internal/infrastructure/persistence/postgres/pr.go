@@ -2,99 +2,165 @@ package postgres
 
 import (
 	"context"
-	"database/sql"
-	"log"
+	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/IlyaAGL/avito_autumn_2025/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// selectPullRequests loads PRs together with their reviewers and tags in one
+// round trip; callers append the WHERE and GROUP BY pr.pull_request_id clauses
+// and scan rows with scanPullRequest.
+const selectPullRequests = `SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.created_at, pr.merged_at,
+                COALESCE(pr.repository, ''), COALESCE(pr.number, 0), pr.required_reviewers,
+                pr.lines_added, pr.lines_removed, pr.files_changed, COALESCE(pr.size, ''), pr.priority,
+                COALESCE(array_agg(prr.user_id ORDER BY prr.assigned_at, prr.user_id)
+                         FILTER (WHERE prr.user_id IS NOT NULL), '{}'),
+                COALESCE(array_agg(COALESCE(prr.owner_rule, '') ORDER BY prr.assigned_at, prr.user_id)
+                         FILTER (WHERE prr.user_id IS NOT NULL), '{}'),
+                ARRAY(SELECT t.tag FROM pull_request_tags t WHERE t.pull_request_id = pr.pull_request_id ORDER BY t.tag)
+         FROM pull_requests pr
+         LEFT JOIN pull_request_reviewers prr ON pr.pull_request_id = prr.pull_request_id`
+
 type postgresPRRepo struct {
-	db *sql.DB
+	pool *pgxpool.Pool
 }
 
-func NewPostgresPullRequestRepository(db *sql.DB) *postgresPRRepo {
-	return &postgresPRRepo{db: db}
+func NewPostgresPullRequestRepository(pool *pgxpool.Pool) *postgresPRRepo {
+	return &postgresPRRepo{pool: pool}
 }
 
+// CreatePR inserts the PR. A PR of a repository without a number gets the
+// next one there; its ID is always "<repository>:<number>", which keeps IDs
+// unique across repositories.
 func (repo *postgresPRRepo) CreatePR(ctx context.Context, pr *models.PullRequest) error {
-	tx, err := repo.db.BeginTx(ctx, nil)
+	tx, err := repo.pool.Begin(ctx)
 	if err != nil {
 		return err
 	}
 
-	defer func() {
-		if err := tx.Rollback(); err != nil {
-			log.Println("Failed to rollback")
+	defer rollback(ctx, tx)
+
+	if pr.Repository != "" {
+		// The lock serializes the numbering of the repository's PRs.
+		var locked string
+		err = tx.QueryRow(ctx, "SELECT repository FROM repositories WHERE repository = $1 FOR UPDATE", pr.Repository).Scan(&locked)
+		if err != nil {
+			return fmt.Errorf("repository %s: %w", pr.Repository, notFound(err))
 		}
-	}()
 
-	_, err = tx.ExecContext(ctx,
-		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) 
-         VALUES ($1, $2, $3, $4)`,
-		pr.ID, pr.Name, pr.AuthorID, "OPEN",
+		if pr.Number == 0 {
+			err = tx.QueryRow(ctx,
+				"SELECT COALESCE(MAX(number), 0) + 1 FROM pull_requests WHERE repository = $1",
+				pr.Repository,
+			).Scan(&pr.Number)
+			if err != nil {
+				return err
+			}
+		}
+		pr.ID = fmt.Sprintf("%s:%d", pr.Repository, pr.Number)
+	}
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, repository, number, required_reviewers,
+                                    lines_added, lines_removed, files_changed, size, priority) 
+         VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, 0), $7, $8, $9, $10, NULLIF($11, ''), $12)`,
+		pr.ID, pr.Name, pr.AuthorID, "OPEN", pr.Repository, pr.Number, pr.RequiredReviewers,
+		pr.LinesAdded, pr.LinesRemoved, pr.FilesChanged, pr.Size, pr.Priority,
 	)
+	if isUniqueViolation(err) {
+		return models.ErrPRExists
+	}
 	if err != nil {
 		return err
 	}
 
-	for _, reviewerID := range pr.AssignedReviewers {
-		_, err = tx.ExecContext(ctx,
-			"INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES ($1, $2)",
-			pr.ID, reviewerID,
+	if err := checkCapacity(ctx, tx, pr.AssignedReviewers); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO pull_request_reviewers (pull_request_id, user_id, owner_rule)
+         SELECT $1, r.user_id, NULLIF(r.rule, '')
+         FROM unnest($2::text[], $3::text[]) AS r(user_id, rule)`,
+		pr.ID, pr.AssignedReviewers, alignOwnerRules(pr.AssignedReviewers, pr.OwnerRules),
+	)
+	if err != nil {
+		return err
+	}
+
+	if len(pr.Tags) > 0 {
+		_, err = tx.Exec(ctx,
+			`INSERT INTO pull_request_tags (pull_request_id, tag)
+             SELECT $1, unnest($2::text[])`,
+			pr.ID, pr.Tags,
 		)
 		if err != nil {
 			return err
 		}
 	}
 
-	if err := tx.Commit(); err != nil {
+	if err := tx.Commit(ctx); err != nil {
 		return err
 	}
 
+	slog.DebugContext(ctx, "pull request inserted", "pull_request_id", pr.ID, "reviewers", len(pr.AssignedReviewers))
+
 	return nil
 }
 
 func (repo *postgresPRRepo) GetPR(ctx context.Context, prID string) (*models.PullRequest, error) {
-	var pr models.PullRequest
-
-	err := repo.db.QueryRowContext(ctx,
-		`SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at
-         FROM pull_requests WHERE pull_request_id = $1`,
+	pr, err := scanPullRequest(repo.pool.QueryRow(ctx,
+		selectPullRequests+`
+         WHERE pr.pull_request_id = $1
+         GROUP BY pr.pull_request_id`,
 		prID,
-	).Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt)
+	))
 	if err != nil {
-		return nil, err
+		return nil, notFound(err)
 	}
 
-	rows, err := repo.db.QueryContext(ctx,
-		"SELECT user_id FROM pull_request_reviewers WHERE pull_request_id = $1 ORDER BY assigned_at",
-		prID,
-	)
+	return &pr, nil
+}
+
+func (repo *postgresPRRepo) GetPRByNumber(ctx context.Context, repository string, number int) (*models.PullRequest, error) {
+	pr, err := scanPullRequest(repo.pool.QueryRow(ctx,
+		selectPullRequests+`
+         WHERE pr.repository = $1 AND pr.number = $2
+         GROUP BY pr.pull_request_id`,
+		repository, number,
+	))
 	if err != nil {
-		return nil, err
+		return nil, notFound(err)
 	}
 
-	defer func() {
-		if err := rows.Close(); err != nil {
-			log.Println("Failed to close the rows")
-		}
-	}()
+	return &pr, nil
+}
 
-	for rows.Next() {
-		var reviewerID string
-		if err := rows.Scan(&reviewerID); err != nil {
-			return nil, err
-		}
-		pr.AssignedReviewers = append(pr.AssignedReviewers, reviewerID)
+func (repo *postgresPRRepo) ListPRs(ctx context.Context, filter models.PullRequestFilter) ([]models.PullRequest, error) {
+	rows, err := repo.pool.Query(ctx,
+		selectPullRequests+`
+         WHERE ($1 = '' OR pr.status = $1) AND ($2 = '' OR pr.author_id = $2) AND ($3 = '' OR pr.repository = $3)
+         GROUP BY pr.pull_request_id
+         HAVING NOT $4 OR COUNT(prr.user_id) < pr.required_reviewers
+         ORDER BY pr.created_at DESC, pr.pull_request_id`,
+		filter.Status, filter.AuthorID, filter.Repository, filter.Understaffed,
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	return &pr, nil
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.PullRequest, error) {
+		return scanPullRequest(row)
+	})
 }
 
 func (repo *postgresPRRepo) PRExists(ctx context.Context, prID string) (bool, error) {
 	var exists bool
-	err := repo.db.QueryRowContext(ctx,
+	err := repo.pool.QueryRow(ctx,
 		"SELECT EXISTS(SELECT 1 FROM pull_requests WHERE pull_request_id = $1)",
 		prID,
 	).Scan(&exists)
@@ -103,54 +169,56 @@ func (repo *postgresPRRepo) PRExists(ctx context.Context, prID string) (bool, er
 
 func (repo *postgresPRRepo) MergePR(ctx context.Context, prID string) error {
 	mergedAt := time.Now()
-	_, err := repo.db.ExecContext(ctx,
+	_, err := repo.pool.Exec(ctx,
 		"UPDATE pull_requests SET status = 'MERGED', merged_at = $1, updated_at = NOW() WHERE pull_request_id = $2 AND status != 'MERGED'",
 		mergedAt, prID,
 	)
 	return err
 }
 
-func (repo *postgresPRRepo) UpdatePRReviewers(ctx context.Context, prID string, reviewerIDs []string) error {
-	tx, err := repo.db.BeginTx(ctx, nil)
+// UpdatePRReviewers replaces the reviewers of the PR. ownerRules gives the
+// CODEOWNERS rule of added reviewers assigned as code owners.
+func (repo *postgresPRRepo) UpdatePRReviewers(ctx context.Context, prID string, reviewerIDs []string, ownerRules map[string]string) error {
+	tx, err := repo.pool.Begin(ctx)
 	if err != nil {
 		return err
 	}
 
-	defer func() {
-		if err := tx.Rollback(); err != nil {
-			log.Println("Failed to rollback")
-		}
-	}()
+	defer rollback(ctx, tx)
 
-	_, err = tx.ExecContext(ctx,
-		"DELETE FROM pull_request_reviewers WHERE pull_request_id = $1",
-		prID,
-	)
-	if err != nil {
-		return err
+	if reviewerIDs == nil {
+		reviewerIDs = []string{}
 	}
 
-	for _, reviewerID := range reviewerIDs {
-		_, err = tx.ExecContext(ctx,
-			"INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES ($1, $2)",
-			prID, reviewerID,
-		)
-		if err != nil {
-			return err
-		}
+	// Reviewers that stay keep their assigned_at, which cooldowns rely on.
+	batch := &pgx.Batch{}
+	batch.Queue(
+		"DELETE FROM pull_request_reviewers WHERE pull_request_id = $1 AND user_id <> ALL($2::text[])",
+		prID, reviewerIDs,
+	)
+	batch.Queue(
+		`INSERT INTO pull_request_reviewers (pull_request_id, user_id, owner_rule)
+         SELECT $1, r.user_id, NULLIF(r.rule, '')
+         FROM unnest($2::text[], $3::text[]) AS r(user_id, rule)
+         ON CONFLICT DO NOTHING`,
+		prID, reviewerIDs, alignOwnerRules(reviewerIDs, ownerRules),
+	)
+
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return err
 	}
 
-	if err := tx.Commit(); err != nil {
+	if err := tx.Commit(ctx); err != nil {
 		return err
 	}
 
+	slog.DebugContext(ctx, "pull request reviewers replaced", "pull_request_id", prID, "reviewers", len(reviewerIDs))
+
 	return nil
 }
 
 func (repo *postgresPRRepo) GetPRsByReviewer(ctx context.Context, userID string) ([]models.PullRequestShort, error) {
-	var prs []models.PullRequestShort
-
-	rows, err := repo.db.QueryContext(ctx,
+	rows, err := repo.pool.Query(ctx,
 		`SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status
          FROM pull_requests pr
          JOIN pull_request_reviewers prr ON pr.pull_request_id = prr.pull_request_id
@@ -162,25 +230,11 @@ func (repo *postgresPRRepo) GetPRsByReviewer(ctx context.Context, userID string)
 		return nil, err
 	}
 
-	defer func() {
-		if err := rows.Close(); err != nil {
-			log.Println("Failed to close the rows")
-		}
-	}()
-
-	for rows.Next() {
-		var pr models.PullRequestShort
-		if err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status); err != nil {
-			return nil, err
-		}
-		prs = append(prs, pr)
-	}
-
-	return prs, nil
+	return pgx.CollectRows(rows, scanPullRequestShort)
 }
 
 func (repo *postgresPRRepo) GetReviewStats(ctx context.Context) ([]models.ReviewStats, error) {
-    query := `
+	query := `
         SELECT 
             u.user_id,
             u.username,
@@ -192,26 +246,101 @@ func (repo *postgresPRRepo) GetReviewStats(ctx context.Context) ([]models.Review
         ORDER BY total_reviews DESC
     `
 
-    rows, err := repo.db.QueryContext(ctx, query)
-    if err != nil {
-        return nil, err
-    }
-    
-	defer func() {
-		if err := rows.Close(); err != nil {
-			log.Println("Failed to close the rows")
+	rows, err := repo.pool.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.ReviewStats, error) {
+		var stat models.ReviewStats
+		var username string
+		err := row.Scan(&stat.UserID, &username, &stat.TotalReviews)
+		return stat, err
+	})
+}
+
+// GetGroupStats counts the PRs of every size and priority that has any.
+func (repo *postgresPRRepo) GetGroupStats(ctx context.Context) ([]models.PRGroupStats, error) {
+	rows, err := repo.pool.Query(ctx,
+		`SELECT COALESCE(size, ''), priority, COUNT(*) FILTER (WHERE status = 'OPEN'), COUNT(*)
+         FROM pull_requests
+         GROUP BY size, priority`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.PRGroupStats, error) {
+		var stat models.PRGroupStats
+		err := row.Scan(&stat.Size, &stat.Priority, &stat.OpenPRs, &stat.TotalPRs)
+		return stat, err
+	})
+}
+
+func scanPullRequest(row pgx.Row) (models.PullRequest, error) {
+	var pr models.PullRequest
+	var rules []string
+
+	err := row.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt,
+		&pr.Repository, &pr.Number, &pr.RequiredReviewers,
+		&pr.LinesAdded, &pr.LinesRemoved, &pr.FilesChanged, &pr.Size, &pr.Priority,
+		&pr.AssignedReviewers, &rules, &pr.Tags)
+	if err != nil {
+		return pr, err
+	}
+
+	for i, rule := range rules {
+		if rule == "" {
+			continue
+		}
+		if pr.OwnerRules == nil {
+			pr.OwnerRules = make(map[string]string)
+		}
+		pr.OwnerRules[pr.AssignedReviewers[i]] = rule
+	}
+
+	return pr, nil
+}
+
+// alignOwnerRules lines the rules up with reviewerIDs, with "" for reviewers not
+// assigned as code owners.
+func alignOwnerRules(reviewerIDs []string, rules map[string]string) []string {
+	aligned := make([]string, len(reviewerIDs))
+	for i, reviewerID := range reviewerIDs {
+		aligned[i] = rules[reviewerID]
+	}
+
+	return aligned
+}
+
+func scanPullRequestShort(row pgx.CollectableRow) (models.PullRequestShort, error) {
+	var pr models.PullRequestShort
+	err := row.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status)
+	return pr, err
+}
+
+func (repo *postgresPRRepo) CountOpenPRsByTeam(ctx context.Context) (map[string]int, error) {
+	rows, err := repo.pool.Query(ctx,
+		`SELECT COALESCE(u.team_name, ''), COUNT(*)
+         FROM pull_requests pr
+         JOIN users u ON pr.author_id = u.user_id
+         WHERE pr.status = 'OPEN'
+         GROUP BY u.team_name`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var teamName string
+		var count int
+		if err := rows.Scan(&teamName, &count); err != nil {
+			return nil, err
 		}
-	}()
-
-    var stats []models.ReviewStats
-    for rows.Next() {
-        var stat models.ReviewStats
-        var username string
-        if err := rows.Scan(&stat.UserID, &username, &stat.TotalReviews); err != nil {
-            return nil, err
-        }
-        stats = append(stats, stat)
-    }
-
-    return stats, nil
-}
\ No newline at end of file
+		counts[teamName] = count
+	}
+
+	return counts, rows.Err()
+}
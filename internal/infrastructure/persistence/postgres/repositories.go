@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/IlyaAGL/avito_autumn_2025/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const selectRepositories = `SELECT r.repository, COALESCE(r.team_name, ''), r.reviewers, r.strategy, r.created_at,
+                (SELECT COUNT(*) FROM pull_requests pr WHERE pr.repository = r.repository)
+         FROM repositories r`
+
+type postgresRepositoryRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresRepositoryRepository(pool *pgxpool.Pool) *postgresRepositoryRepo {
+	return &postgresRepositoryRepo{pool: pool}
+}
+
+func (repo *postgresRepositoryRepo) CreateRepository(ctx context.Context, repository *models.Repository) error {
+	err := repo.pool.QueryRow(ctx,
+		`INSERT INTO repositories (repository, team_name, reviewers, strategy)
+         VALUES ($1, NULLIF($2, ''), $3, $4)
+         RETURNING created_at`,
+		repository.Name, repository.TeamName, repository.Reviewers, repository.Strategy,
+	).Scan(&repository.CreatedAt)
+	if isUniqueViolation(err) {
+		return models.ErrRepoExists
+	}
+	if isForeignKeyViolation(err) {
+		return fmt.Errorf("team %s: %w", repository.TeamName, models.ErrNotFound)
+	}
+
+	return err
+}
+
+func (repo *postgresRepositoryRepo) GetRepository(ctx context.Context, name string) (*models.Repository, error) {
+	repository, err := scanRepository(repo.pool.QueryRow(ctx, selectRepositories+" WHERE r.repository = $1", name))
+	if err != nil {
+		return nil, fmt.Errorf("repository %s: %w", name, notFound(err))
+	}
+
+	return &repository, nil
+}
+
+func (repo *postgresRepositoryRepo) ListRepositories(ctx context.Context) ([]models.Repository, error) {
+	rows, err := repo.pool.Query(ctx, selectRepositories+" ORDER BY r.repository")
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Repository, error) {
+		return scanRepository(row)
+	})
+}
+
+// UpdateRepository replaces the owning team and policy of the repository.
+func (repo *postgresRepositoryRepo) UpdateRepository(ctx context.Context, repository *models.Repository) error {
+	tag, err := repo.pool.Exec(ctx,
+		`UPDATE repositories SET team_name = NULLIF($2, ''), reviewers = $3, strategy = $4
+         WHERE repository = $1`,
+		repository.Name, repository.TeamName, repository.Reviewers, repository.Strategy,
+	)
+	if isForeignKeyViolation(err) {
+		return fmt.Errorf("team %s: %w", repository.TeamName, models.ErrNotFound)
+	}
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("repository %s: %w", repository.Name, models.ErrNotFound)
+	}
+
+	return nil
+}
+
+// DeleteRepository deletes a repository without pull requests together with
+// its CODEOWNERS rules.
+func (repo *postgresRepositoryRepo) DeleteRepository(ctx context.Context, name string) error {
+	tx, err := repo.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer rollback(ctx, tx)
+
+	var hasPRs bool
+	err = tx.QueryRow(ctx,
+		`SELECT EXISTS(SELECT 1 FROM pull_requests WHERE repository = r.repository)
+         FROM repositories r
+         WHERE r.repository = $1
+         FOR UPDATE`,
+		name,
+	).Scan(&hasPRs)
+	if err != nil {
+		return fmt.Errorf("repository %s: %w", name, notFound(err))
+	}
+
+	if hasPRs {
+		return models.ErrRepoNotEmpty
+	}
+
+	if _, err := tx.Exec(ctx, "DELETE FROM repositories WHERE repository = $1", name); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func scanRepository(row pgx.Row) (models.Repository, error) {
+	var repository models.Repository
+	err := row.Scan(&repository.Name, &repository.TeamName, &repository.Reviewers, &repository.Strategy,
+		&repository.CreatedAt, &repository.PullRequests)
+	return repository, err
+}
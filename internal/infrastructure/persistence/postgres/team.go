@@ -2,65 +2,97 @@ package postgres
 
 import (
 	"context"
-	"database/sql"
-	"log"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/IlyaAGL/avito_autumn_2025/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type postgresTeamRepo struct {
-	db *sql.DB
+	pool *pgxpool.Pool
 }
 
-func NewPostgresTeamRepository(db *sql.DB) *postgresTeamRepo {
-	return &postgresTeamRepo{db: db}
+func NewPostgresTeamRepository(pool *pgxpool.Pool) *postgresTeamRepo {
+	return &postgresTeamRepo{pool: pool}
 }
 
-func (repo *postgresTeamRepo) CreateTeam(ctx context.Context, team *models.Team) error {
-	tx, err := repo.db.BeginTx(ctx, nil)
+// CreateTeam creates the team and its members. An existing team fails with
+// models.ErrTeamExists unless opts.Upsert is set; listed users that belong
+// to another team fail with *models.MemberConflictError unless
+// opts.AllowMove is set.
+func (repo *postgresTeamRepo) CreateTeam(ctx context.Context, team *models.Team, opts models.CreateTeamOptions) ([]models.ReviewerChange, error) {
+	return repo.CreateTeams(ctx, []models.Team{*team}, opts)
+}
+
+// CreateTeams is CreateTeam for several teams in one transaction: either
+// every team is written or none is.
+func (repo *postgresTeamRepo) CreateTeams(ctx context.Context, teams []models.Team, opts models.CreateTeamOptions) ([]models.ReviewerChange, error) {
+	tx, err := repo.pool.Begin(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	defer func() {
-		if err := tx.Rollback(); err != nil {
-			log.Println("Failed to rollback")
+	defer rollback(ctx, tx)
+
+	// Lock teams in a fixed order so concurrent imports cannot deadlock.
+	ordered := slices.SortedFunc(slices.Values(teams), func(a, b models.Team) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	var changes []models.ReviewerChange
+	for _, team := range ordered {
+		teamChanges, err := createTeam(ctx, tx, team, opts)
+		if err != nil {
+			return nil, err
 		}
-	}()
 
-	_, err = tx.ExecContext(ctx,
+		changes = append(changes, teamChanges...)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	for _, team := range ordered {
+		slog.DebugContext(ctx, "team upserted", "team_name", team.Name, "members", len(team.Members))
+	}
+
+	return changes, nil
+}
+
+func createTeam(ctx context.Context, tx pgx.Tx, team models.Team, opts models.CreateTeamOptions) ([]models.ReviewerChange, error) {
+	tag, err := tx.Exec(ctx,
 		"INSERT INTO teams (team_name) VALUES ($1) ON CONFLICT (team_name) DO NOTHING",
 		team.Name,
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	for _, member := range team.Members {
-		_, err = tx.ExecContext(ctx,
-			`INSERT INTO users (user_id, username, team_name, is_active) 
-             VALUES ($1, $2, $3, $4)
-             ON CONFLICT (user_id) 
-             DO UPDATE SET username = $2, team_name = $3, is_active = $4, updated_at = NOW()`,
-			member.UserID, member.Username, team.Name, member.IsActive,
-		)
-		if err != nil {
-			return err
+	if tag.RowsAffected() == 0 {
+		if !opts.Upsert {
+			return nil, fmt.Errorf("team %s: %w", team.Name, models.ErrTeamExists)
 		}
-	}
 
-	if err := tx.Commit(); err != nil {
-		return err
+		if err := lockTeam(ctx, tx, team.Name); err != nil {
+			return nil, err
+		}
 	}
 
-	return nil
+	return upsertMembers(ctx, tx, team.Name, team.Members, opts.AllowMove)
 }
 
 func (repo *postgresTeamRepo) GetTeam(ctx context.Context, teamName string) (*models.Team, error) {
 	var team models.Team
 	team.Name = teamName
 
-	rows, err := repo.db.QueryContext(ctx,
+	rows, err := repo.pool.Query(ctx,
 		"SELECT user_id, username, is_active FROM users WHERE team_name = $1 ORDER BY user_id",
 		teamName,
 	)
@@ -68,51 +100,69 @@ func (repo *postgresTeamRepo) GetTeam(ctx context.Context, teamName string) (*mo
 		return nil, err
 	}
 
-	defer func() {
-		if err := rows.Close(); err != nil {
-			log.Println("Failed to close the rows")
-		}
-	}()
-
-	for rows.Next() {
+	team.Members, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Member, error) {
 		var member models.Member
-		if err := rows.Scan(&member.UserID, &member.Username, &member.IsActive); err != nil {
-			return nil, err
-		}
-		team.Members = append(team.Members, member)
+		err := row.Scan(&member.UserID, &member.Username, &member.IsActive)
+		return member, err
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	if len(team.Members) == 0 {
-		return nil, sql.ErrNoRows
+		exists, err := repo.TeamExists(ctx, teamName)
+		if err != nil {
+			return nil, err
+		}
+
+		if !exists {
+			return nil, models.ErrNotFound
+		}
 	}
 
 	return &team, nil
 }
 
+func (repo *postgresTeamRepo) ListTeams(ctx context.Context) ([]models.TeamSummary, error) {
+	rows, err := repo.pool.Query(ctx,
+		`SELECT t.team_name, COUNT(u.user_id), COUNT(u.user_id) FILTER (WHERE u.is_active)
+         FROM teams t
+         LEFT JOIN users u ON u.team_name = t.team_name
+         GROUP BY t.team_name
+         ORDER BY t.team_name`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.TeamSummary, error) {
+		var summary models.TeamSummary
+		err := row.Scan(&summary.Name, &summary.Members, &summary.ActiveMembers)
+		return summary, err
+	})
+}
+
 func (repo *postgresTeamRepo) TeamExists(ctx context.Context, teamName string) (bool, error) {
 	var exists bool
 
-	err := repo.db.QueryRowContext(ctx,
+	err := repo.pool.QueryRow(ctx,
 		"SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)",
 		teamName,
 	).Scan(&exists)
-	
+
 	return exists, err
 }
 
 func (repo *postgresTeamRepo) BulkDeactivateUsers(ctx context.Context, teamName string) error {
-    tx, err := repo.db.BeginTx(ctx, nil)
-    if err != nil {
-        return err
-    }
-    
-	defer func() {
-		if err := tx.Rollback(); err != nil {
-			log.Println("Failed to rollback")
-		}
-	}()
-
-    _, err = tx.ExecContext(ctx, `
+	tx, err := repo.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer rollback(ctx, tx)
+
+	batch := &pgx.Batch{}
+	batch.Queue(`
         DELETE FROM pull_request_reviewers prr
         USING pull_requests pr, users u
         WHERE prr.pull_request_id = pr.pull_request_id
@@ -120,21 +170,618 @@ func (repo *postgresTeamRepo) BulkDeactivateUsers(ctx context.Context, teamName
         AND u.team_name = $1
         AND pr.status = 'OPEN'
     `, teamName)
-    if err != nil {
-        return err
-    }
-
-    _, err = tx.ExecContext(ctx,
-        "UPDATE users SET is_active = false, updated_at = NOW() WHERE team_name = $1",
-        teamName,
-    )
-    if err != nil {
-        return err
-    }
-
-    if err := tx.Commit(); err != nil {
+	batch.Queue(
+		"UPDATE users SET is_active = false, updated_at = NOW() WHERE team_name = $1",
+		teamName,
+	)
+
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *postgresTeamRepo) AddMembers(ctx context.Context, teamName string, members []models.Member) error {
+	tx, err := repo.pool.Begin(ctx)
+	if err != nil {
 		return err
 	}
 
+	defer rollback(ctx, tx)
+
+	if err := lockTeam(ctx, tx, teamName); err != nil {
+		return err
+	}
+
+	if _, err := upsertMembers(ctx, tx, teamName, members, false); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	slog.DebugContext(ctx, "team members added", "team_name", teamName, "members", len(members))
+
 	return nil
-}
\ No newline at end of file
+}
+
+func (repo *postgresTeamRepo) RemoveMembers(ctx context.Context, teamName string, userIDs []string, opts models.RemoveMembersOptions) ([]models.ReviewerChange, error) {
+	tx, err := repo.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rollback(ctx, tx)
+
+	if err := lockTeam(ctx, tx, teamName); err != nil {
+		return nil, err
+	}
+
+	tag, err := tx.Exec(ctx,
+		`UPDATE users SET team_name = NULL, is_active = is_active AND $3, updated_at = NOW()
+         WHERE team_name = $1 AND user_id = ANY($2::text[])`,
+		teamName, userIDs, opts.KeepActive,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if tag.RowsAffected() != int64(len(userIDs)) {
+		return nil, fmt.Errorf("some users are not members of team %s: %w", teamName, models.ErrNotFound)
+	}
+
+	changes, err := releaseReviews(ctx, tx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+func (repo *postgresTeamRepo) MoveUser(ctx context.Context, userID, teamName string) (*models.User, []models.ReviewerChange, error) {
+	tx, err := repo.pool.Begin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer rollback(ctx, tx)
+
+	if err := lockTeam(ctx, tx, teamName); err != nil {
+		return nil, nil, err
+	}
+
+	var user models.User
+	err = tx.QueryRow(ctx,
+		`UPDATE users SET team_name = $1, updated_at = NOW() WHERE user_id = $2
+         RETURNING user_id, username, team_name, is_active`,
+		teamName, userID,
+	).Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive)
+	if err != nil {
+		return nil, nil, notFound(err)
+	}
+
+	changes, err := releaseReviews(ctx, tx, []string{userID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	return &user, changes, nil
+}
+
+func (repo *postgresTeamRepo) RenameTeam(ctx context.Context, teamName, newName string) error {
+	tag, err := repo.pool.Exec(ctx,
+		"UPDATE teams SET team_name = $1 WHERE team_name = $2",
+		newName, teamName,
+	)
+	if isUniqueViolation(err) {
+		return models.ErrTeamExists
+	}
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *postgresTeamRepo) DeleteTeam(ctx context.Context, teamName string) error {
+	tx, err := repo.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer rollback(ctx, tx)
+
+	if err := lockTeam(ctx, tx, teamName); err != nil {
+		return err
+	}
+
+	var hasMembers bool
+	err = tx.QueryRow(ctx,
+		"SELECT EXISTS(SELECT 1 FROM users WHERE team_name = $1)",
+		teamName,
+	).Scan(&hasMembers)
+	if err != nil {
+		return err
+	}
+
+	if hasMembers {
+		return models.ErrTeamNotEmpty
+	}
+
+	if _, err := tx.Exec(ctx, "DELETE FROM teams WHERE team_name = $1", teamName); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// SyncTeams makes the teams table and memberships match desired in one
+// transaction: missing teams are created, team review limits are replaced,
+// listed members are upserted and moved, unlisted users are removed from
+// their team and deactivated, and unlisted teams are deleted. The plan is
+// computed under the same lock it is applied with; a dry run computes it and
+// rolls back.
+func (repo *postgresTeamRepo) SyncTeams(ctx context.Context, desired []models.SyncTeam, dryRun bool) (*models.SyncPlan, error) {
+	tx, err := repo.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rollback(ctx, tx)
+
+	// Blocks every other membership change until the sync commits.
+	if _, err := tx.Exec(ctx, "LOCK TABLE teams IN EXCLUSIVE MODE"); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx,
+		"SELECT team_name, max_open_reviews, review_cooldown_minutes FROM teams ORDER BY team_name",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	currentTeams, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.SyncTeam, error) {
+		var team models.SyncTeam
+		err := row.Scan(&team.Name, &team.Limits.MaxOpenReviews, &team.Limits.CooldownMinutes)
+		return team, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err = tx.Query(ctx,
+		"SELECT user_id, username, COALESCE(team_name, ''), is_active FROM users ORDER BY user_id FOR UPDATE",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	currentUsers, err := pgx.CollectRows(rows, scanUser)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := planSync(desired, currentTeams, currentUsers)
+	if dryRun || plan.Empty() {
+		return plan, nil
+	}
+
+	teamNames := make([]string, len(desired))
+	maxOpenReviews := make([]*int, len(desired))
+	cooldowns := make([]*int, len(desired))
+	userIDs := make([]string, 0)
+
+	for i, team := range desired {
+		teamNames[i] = team.Name
+		maxOpenReviews[i] = team.Limits.MaxOpenReviews
+		cooldowns[i] = team.Limits.CooldownMinutes
+		for _, member := range team.Members {
+			userIDs = append(userIDs, member.UserID)
+		}
+	}
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO teams (team_name, max_open_reviews, review_cooldown_minutes)
+         SELECT * FROM unnest($1::text[], $2::int[], $3::int[])
+         ON CONFLICT (team_name) DO UPDATE
+         SET max_open_reviews = EXCLUDED.max_open_reviews,
+             review_cooldown_minutes = EXCLUDED.review_cooldown_minutes`,
+		teamNames, maxOpenReviews, cooldowns,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, team := range desired {
+		moved, err := upsertMembers(ctx, tx, team.Name, team.Members, true)
+		if err != nil {
+			return nil, err
+		}
+
+		plan.Reassignments = append(plan.Reassignments, moved...)
+	}
+
+	rows, err = tx.Query(ctx,
+		`UPDATE users SET team_name = NULL, is_active = false, updated_at = NOW()
+         WHERE team_name IS NOT NULL AND user_id <> ALL($1::text[])
+         RETURNING user_id`,
+		userIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	removedIDs, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(removedIDs) > 0 {
+		released, err := releaseReviews(ctx, tx, removedIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		plan.Reassignments = append(plan.Reassignments, released...)
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM teams WHERE team_name <> ALL($1::text[])", teamNames)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// planSync lists what SyncTeams changes to turn currentTeams and
+// currentUsers into desired.
+func planSync(desired, currentTeams []models.SyncTeam, currentUsers []models.User) *models.SyncPlan {
+	plan := &models.SyncPlan{}
+
+	existingTeams := make(map[string]models.ReviewLimits, len(currentTeams))
+	for _, team := range currentTeams {
+		existingTeams[team.Name] = team.Limits
+	}
+
+	existingUsers := make(map[string]models.User, len(currentUsers))
+	for _, user := range currentUsers {
+		existingUsers[user.UserID] = user
+	}
+
+	desiredTeams := make(map[string]bool, len(desired))
+	listed := make(map[string]bool)
+
+	for _, team := range desired {
+		desiredTeams[team.Name] = true
+
+		limits, ok := existingTeams[team.Name]
+		switch {
+		case !ok:
+			plan.TeamsCreated = append(plan.TeamsCreated, team.Name)
+		case !sameLimit(limits.MaxOpenReviews, team.Limits.MaxOpenReviews) ||
+			!sameLimit(limits.CooldownMinutes, team.Limits.CooldownMinutes):
+			plan.TeamsUpdated = append(plan.TeamsUpdated, team.Name)
+		}
+
+		for _, member := range team.Members {
+			listed[member.UserID] = true
+
+			change := models.SyncUserChange{
+				UserID:   member.UserID,
+				Username: member.Username,
+				TeamName: team.Name,
+				IsActive: member.IsActive,
+			}
+
+			current, ok := existingUsers[member.UserID]
+			switch {
+			case !ok || current.TeamName == "":
+				plan.UsersAdded = append(plan.UsersAdded, change)
+			case current.TeamName != team.Name:
+				change.FromTeam = current.TeamName
+				plan.UsersMoved = append(plan.UsersMoved, change)
+			case current.Username != member.Username || current.IsActive != member.IsActive:
+				plan.UsersUpdated = append(plan.UsersUpdated, change)
+			}
+		}
+	}
+
+	for _, team := range currentTeams {
+		if !desiredTeams[team.Name] {
+			plan.TeamsRemoved = append(plan.TeamsRemoved, team.Name)
+		}
+	}
+
+	for _, user := range currentUsers {
+		if user.TeamName != "" && !listed[user.UserID] {
+			plan.UsersRemoved = append(plan.UsersRemoved, models.SyncUserChange{
+				UserID:   user.UserID,
+				Username: user.Username,
+				TeamName: user.TeamName,
+			})
+		}
+	}
+
+	return plan
+}
+
+// sameLimit compares two nullable limits by value.
+func sameLimit(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+// upsertMembers creates or updates members of teamName. Users that belong to
+// another team are refused with *models.MemberConflictError, or moved when
+// allowMove is set, in which case their open reviews are released.
+func upsertMembers(ctx context.Context, tx pgx.Tx, teamName string, members []models.Member, allowMove bool) ([]models.ReviewerChange, error) {
+	userIDs := make([]string, len(members))
+	usernames := make([]string, len(members))
+	activeFlags := make([]bool, len(members))
+
+	for i, member := range members {
+		userIDs[i] = member.UserID
+		usernames[i] = member.Username
+		activeFlags[i] = member.IsActive
+	}
+
+	rows, err := tx.Query(ctx,
+		`SELECT user_id, team_name FROM users
+         WHERE user_id = ANY($1::text[]) AND team_name IS NOT NULL AND team_name <> $2
+         ORDER BY user_id
+         FOR UPDATE`,
+		userIDs, teamName,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.MemberConflict, error) {
+		var conflict models.MemberConflict
+		err := row.Scan(&conflict.UserID, &conflict.TeamName)
+		return conflict, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(conflicts) > 0 && !allowMove {
+		return nil, &models.MemberConflictError{Conflicts: conflicts}
+	}
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO users (user_id, username, team_name, is_active)
+         SELECT m.user_id, m.username, $1, m.is_active
+         FROM unnest($2::text[], $3::text[], $4::bool[]) AS m(user_id, username, is_active)
+         ON CONFLICT (user_id)
+         DO UPDATE SET username = EXCLUDED.username, team_name = EXCLUDED.team_name,
+                       is_active = EXCLUDED.is_active, updated_at = NOW()`,
+		teamName, userIDs, usernames, activeFlags,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(conflicts) == 0 {
+		return nil, nil
+	}
+
+	movedIDs := make([]string, len(conflicts))
+	for i, conflict := range conflicts {
+		movedIDs[i] = conflict.UserID
+	}
+
+	slog.InfoContext(ctx, "users moved between teams", "team_name", teamName, "user_ids", movedIDs)
+
+	return releaseReviews(ctx, tx, movedIDs)
+}
+
+// SetReviewLimits replaces the team defaults; nil fields mean no limit.
+func (repo *postgresTeamRepo) SetReviewLimits(ctx context.Context, teamName string, limits models.ReviewLimits) error {
+	tag, err := repo.pool.Exec(ctx,
+		"UPDATE teams SET max_open_reviews = $2, review_cooldown_minutes = $3 WHERE team_name = $1",
+		teamName, limits.MaxOpenReviews, limits.CooldownMinutes,
+	)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("team %s: %w", teamName, models.ErrNotFound)
+	}
+
+	return nil
+}
+
+// GetReviewLoad returns the team defaults and, for every member, their open
+// reviews, effective limits and whether they can be picked as a reviewer.
+func (repo *postgresTeamRepo) GetReviewLoad(ctx context.Context, teamName string) (*models.TeamReviewLoad, error) {
+	load := &models.TeamReviewLoad{TeamName: teamName}
+
+	err := repo.pool.QueryRow(ctx,
+		"SELECT max_open_reviews, review_cooldown_minutes FROM teams WHERE team_name = $1",
+		teamName,
+	).Scan(&load.Limits.MaxOpenReviews, &load.Limits.CooldownMinutes)
+	if err != nil {
+		return nil, fmt.Errorf("team %s: %w", teamName, notFound(err))
+	}
+
+	rows, err := repo.pool.Query(ctx,
+		`SELECT u.user_id, u.username, u.team_name, u.is_active,
+                (SELECT COUNT(*) FROM pull_request_reviewers r
+                 JOIN pull_requests p ON p.pull_request_id = r.pull_request_id
+                 WHERE r.user_id = u.user_id AND p.status = 'OPEN'),
+                COALESCE(u.max_open_reviews, tm.max_open_reviews),
+                COALESCE(u.review_cooldown_minutes, tm.review_cooldown_minutes),
+                (SELECT MAX(r.assigned_at) FROM pull_request_reviewers r WHERE r.user_id = u.user_id)
+                    + make_interval(mins => COALESCE(u.review_cooldown_minutes, tm.review_cooldown_minutes)),
+                NOT `+notAway+`,
+                u.is_active AND `+notAway+` AND `+hasCapacity+`
+         FROM users u
+         JOIN teams tm ON tm.team_name = u.team_name
+         WHERE u.team_name = $1
+         ORDER BY u.user_id`,
+		teamName,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	load.Members, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.ReviewLoad, error) {
+		var member models.ReviewLoad
+		err := row.Scan(
+			&member.User.UserID, &member.User.Username, &member.User.TeamName, &member.User.IsActive,
+			&member.OpenReviews, &member.Limits.MaxOpenReviews, &member.Limits.CooldownMinutes,
+			&member.CooldownUntil, &member.Away, &member.Available,
+		)
+		if err != nil {
+			return member, err
+		}
+
+		if member.CooldownUntil != nil && !member.CooldownUntil.After(now) {
+			member.CooldownUntil = nil
+		}
+
+		return member, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return load, nil
+}
+
+// lockTeam locks the team row for the rest of tx so concurrent membership
+// changes, renames and deletes of the same team are serialized.
+func lockTeam(ctx context.Context, tx pgx.Tx, teamName string) error {
+	var name string
+	err := tx.QueryRow(ctx,
+		"SELECT team_name FROM teams WHERE team_name = $1 FOR UPDATE",
+		teamName,
+	).Scan(&name)
+	if err != nil {
+		return fmt.Errorf("team %s: %w", teamName, notFound(err))
+	}
+
+	return nil
+}
+
+// releaseReviews replaces userIDs on open pull requests whose review team -
+// the owning team of the PR's repository, or else the author's team - they
+// are no longer in, picking a random active member of that team. Code owners
+// keep their reviews while they stay active. Reviews without a candidate are
+// dropped.
+func releaseReviews(ctx context.Context, tx pgx.Tx, userIDs []string) ([]models.ReviewerChange, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT prr.pull_request_id, prr.user_id, pr.author_id, COALESCE(rp.team_name, a.team_name)
+         FROM pull_request_reviewers prr
+         JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+         JOIN users r ON r.user_id = prr.user_id
+         JOIN users a ON a.user_id = pr.author_id
+         LEFT JOIN repositories rp ON rp.repository = pr.repository
+         WHERE prr.user_id = ANY($1::text[])
+         AND pr.status = 'OPEN'
+         AND CASE WHEN prr.owner_rule IS NULL
+             THEN r.team_name IS DISTINCT FROM COALESCE(rp.team_name, a.team_name)
+             ELSE NOT r.is_active
+         END
+         ORDER BY prr.pull_request_id, prr.user_id`,
+		userIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	type review struct {
+		change     models.ReviewerChange
+		authorID   string
+		reviewTeam *string
+	}
+
+	reviews, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (review, error) {
+		var r review
+		err := row.Scan(&r.change.PullRequestID, &r.change.OldReviewerID, &r.authorID, &r.reviewTeam)
+		return r, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]models.ReviewerChange, 0, len(reviews))
+
+	for _, r := range reviews {
+		_, err := tx.Exec(ctx,
+			"DELETE FROM pull_request_reviewers WHERE pull_request_id = $1 AND user_id = $2",
+			r.change.PullRequestID, r.change.OldReviewerID,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if r.reviewTeam != nil {
+			r.change.NewReviewerID, err = assignReplacement(ctx, tx, r.change.PullRequestID, *r.reviewTeam, r.authorID)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		changes = append(changes, r.change)
+	}
+
+	return changes, nil
+}
+
+// assignReplacement adds a random active member of teamName who is not on
+// time off, within their review limits, not the author and not yet reviewing
+// the PR. It returns an empty ID when there is no such member.
+func assignReplacement(ctx context.Context, tx pgx.Tx, prID, teamName, authorID string) (string, error) {
+	var reviewerID string
+	err := tx.QueryRow(ctx,
+		`INSERT INTO pull_request_reviewers (pull_request_id, user_id)
+         SELECT $1, u.user_id FROM users u
+         WHERE u.team_name = $2 AND u.is_active AND u.user_id <> $3
+         AND NOT EXISTS (
+             SELECT 1 FROM pull_request_reviewers prr
+             WHERE prr.pull_request_id = $1 AND prr.user_id = u.user_id
+         )
+         AND `+notAway+`
+         AND `+hasCapacity+`
+         ORDER BY random()
+         LIMIT 1
+         RETURNING user_id`,
+		prID, teamName, authorID,
+	).Scan(&reviewerID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return "", err
+	}
+
+	return reviewerID, nil
+}
@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/IlyaAGL/avito_autumn_2025/internal/models"
+)
+
+func TestPlanSync(t *testing.T) {
+	three, five := 3, 5
+
+	currentTeams := []models.SyncTeam{
+		{Name: "backend", Limits: models.ReviewLimits{MaxOpenReviews: &three}},
+		{Name: "frontend"},
+		{Name: "legacy"},
+	}
+	currentUsers := []models.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Carol", TeamName: "frontend", IsActive: true},
+		{UserID: "u4", Username: "Dave", TeamName: "legacy", IsActive: true},
+		// u5 was removed from its team earlier.
+		{UserID: "u5", Username: "Eve", IsActive: false},
+	}
+
+	tests := []struct {
+		name    string
+		desired []models.SyncTeam
+		want    *models.SyncPlan
+	}{
+		{
+			name: "nothing changes",
+			desired: []models.SyncTeam{
+				{Name: "backend", Limits: models.ReviewLimits{MaxOpenReviews: &three}, Members: []models.Member{
+					{UserID: "u1", Username: "Alice", IsActive: true},
+					{UserID: "u2", Username: "Bob", IsActive: true},
+				}},
+				{Name: "frontend", Members: []models.Member{{UserID: "u3", Username: "Carol", IsActive: true}}},
+				{Name: "legacy", Members: []models.Member{{UserID: "u4", Username: "Dave", IsActive: true}}},
+			},
+			want: &models.SyncPlan{},
+		},
+		{
+			name: "members added, moved, updated and removed",
+			desired: []models.SyncTeam{
+				{Name: "backend", Limits: models.ReviewLimits{MaxOpenReviews: &three}, Members: []models.Member{
+					{UserID: "u1", Username: "Alice", IsActive: false},
+					{UserID: "u3", Username: "Carol", IsActive: true},
+				}},
+				{Name: "frontend", Members: []models.Member{
+					{UserID: "u5", Username: "Eve", IsActive: true},
+					{UserID: "u6", Username: "Frank", IsActive: true},
+				}},
+				{Name: "legacy", Members: []models.Member{{UserID: "u4", Username: "Dave", IsActive: true}}},
+			},
+			want: &models.SyncPlan{
+				UsersAdded: []models.SyncUserChange{
+					{UserID: "u5", Username: "Eve", TeamName: "frontend", IsActive: true},
+					{UserID: "u6", Username: "Frank", TeamName: "frontend", IsActive: true},
+				},
+				UsersMoved: []models.SyncUserChange{
+					{UserID: "u3", Username: "Carol", TeamName: "backend", FromTeam: "frontend", IsActive: true},
+				},
+				UsersUpdated: []models.SyncUserChange{
+					{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: false},
+				},
+				UsersRemoved: []models.SyncUserChange{
+					{UserID: "u2", Username: "Bob", TeamName: "backend"},
+				},
+			},
+		},
+		{
+			name: "teams created and removed",
+			desired: []models.SyncTeam{
+				{Name: "backend", Limits: models.ReviewLimits{MaxOpenReviews: &three}, Members: []models.Member{
+					{UserID: "u1", Username: "Alice", IsActive: true},
+					{UserID: "u2", Username: "Bob", IsActive: true},
+				}},
+				{Name: "frontend", Members: []models.Member{{UserID: "u3", Username: "Carol", IsActive: true}}},
+				{Name: "platform", Members: []models.Member{{UserID: "u4", Username: "Dave", IsActive: true}}},
+			},
+			want: &models.SyncPlan{
+				TeamsCreated: []string{"platform"},
+				TeamsRemoved: []string{"legacy"},
+				UsersMoved: []models.SyncUserChange{
+					{UserID: "u4", Username: "Dave", TeamName: "platform", FromTeam: "legacy", IsActive: true},
+				},
+			},
+		},
+		{
+			name: "team limits synced",
+			desired: []models.SyncTeam{
+				{Name: "backend", Limits: models.ReviewLimits{MaxOpenReviews: &five}, Members: []models.Member{
+					{UserID: "u1", Username: "Alice", IsActive: true},
+					{UserID: "u2", Username: "Bob", IsActive: true},
+				}},
+				{Name: "frontend", Limits: models.ReviewLimits{CooldownMinutes: &three}, Members: []models.Member{
+					{UserID: "u3", Username: "Carol", IsActive: true},
+				}},
+				{Name: "legacy", Members: []models.Member{{UserID: "u4", Username: "Dave", IsActive: true}}},
+			},
+			want: &models.SyncPlan{TeamsUpdated: []string{"backend", "frontend"}},
+		},
+		{
+			name: "team limit left out is removed",
+			desired: []models.SyncTeam{
+				{Name: "backend", Members: []models.Member{
+					{UserID: "u1", Username: "Alice", IsActive: true},
+					{UserID: "u2", Username: "Bob", IsActive: true},
+				}},
+				{Name: "frontend", Members: []models.Member{{UserID: "u3", Username: "Carol", IsActive: true}}},
+				{Name: "legacy", Members: []models.Member{{UserID: "u4", Username: "Dave", IsActive: true}}},
+			},
+			want: &models.SyncPlan{TeamsUpdated: []string{"backend"}},
+		},
+		{
+			name:    "empty roster removes everything",
+			desired: []models.SyncTeam{},
+			want: &models.SyncPlan{
+				TeamsRemoved: []string{"backend", "frontend", "legacy"},
+				UsersRemoved: []models.SyncUserChange{
+					{UserID: "u1", Username: "Alice", TeamName: "backend"},
+					{UserID: "u2", Username: "Bob", TeamName: "backend"},
+					{UserID: "u3", Username: "Carol", TeamName: "frontend"},
+					{UserID: "u4", Username: "Dave", TeamName: "legacy"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := planSync(tt.desired, currentTeams, currentUsers)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("plan = %+v, want %+v", got, tt.want)
+			}
+			if got.Empty() != reflect.DeepEqual(tt.want, &models.SyncPlan{}) {
+				t.Errorf("Empty() = %v for %+v", got.Empty(), got)
+			}
+		})
+	}
+}
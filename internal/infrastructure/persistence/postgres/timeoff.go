@@ -0,0 +1,273 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/IlyaAGL/avito_autumn_2025/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// notAway is the condition that the user aliased u is not on time off now.
+const notAway = `NOT EXISTS (
+             SELECT 1 FROM time_off t
+             WHERE t.user_id = u.user_id AND t.starts_at <= NOW() AND t.ends_at > NOW()
+         )`
+
+type postgresTimeOffRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresTimeOffRepository(pool *pgxpool.Pool) *postgresTimeOffRepo {
+	return &postgresTimeOffRepo{pool: pool}
+}
+
+// CreateTimeOff stores a period and fills in its ID. A period overlapping
+// another one of the same user fails with models.ErrTimeOffOverlap.
+func (repo *postgresTimeOffRepo) CreateTimeOff(ctx context.Context, timeOff *models.TimeOff) error {
+	tx, err := repo.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer rollback(ctx, tx)
+
+	// Locking the user serializes concurrent requests for the same user, so
+	// the overlap check below cannot race.
+	var userID string
+	err = tx.QueryRow(ctx,
+		"SELECT user_id FROM users WHERE user_id = $1 FOR UPDATE",
+		timeOff.UserID,
+	).Scan(&userID)
+	if err != nil {
+		return fmt.Errorf("user %s: %w", timeOff.UserID, notFound(err))
+	}
+
+	var overlapping int64
+	err = tx.QueryRow(ctx,
+		`SELECT time_off_id FROM time_off
+         WHERE user_id = $1 AND starts_at < $3 AND ends_at > $2
+         LIMIT 1`,
+		timeOff.UserID, timeOff.StartsAt, timeOff.EndsAt,
+	).Scan(&overlapping)
+	if err == nil {
+		return fmt.Errorf("time off %d: %w", overlapping, models.ErrTimeOffOverlap)
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+
+	err = tx.QueryRow(ctx,
+		`INSERT INTO time_off (user_id, starts_at, ends_at, reason, reassign_reviews)
+         VALUES ($1, $2, $3, $4, $5)
+         RETURNING time_off_id`,
+		timeOff.UserID, timeOff.StartsAt, timeOff.EndsAt, timeOff.Reason, timeOff.ReassignReviews,
+	).Scan(&timeOff.ID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ListUserTimeOff returns the user's current and upcoming periods.
+func (repo *postgresTimeOffRepo) ListUserTimeOff(ctx context.Context, userID string) ([]models.TimeOff, error) {
+	rows, err := repo.pool.Query(ctx,
+		`SELECT time_off_id, user_id, starts_at, ends_at, reason, reassign_reviews
+         FROM time_off
+         WHERE user_id = $1 AND ends_at > NOW()
+         ORDER BY starts_at`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, scanTimeOff)
+}
+
+// ListAway returns the members of teamName who are on time off now.
+func (repo *postgresTimeOffRepo) ListAway(ctx context.Context, teamName string) ([]models.Away, error) {
+	rows, err := repo.pool.Query(ctx,
+		`SELECT u.user_id, u.username, u.team_name, u.is_active,
+                t.time_off_id, t.user_id, t.starts_at, t.ends_at, t.reason, t.reassign_reviews
+         FROM time_off t
+         JOIN users u ON u.user_id = t.user_id
+         WHERE u.team_name = $1 AND t.starts_at <= NOW() AND t.ends_at > NOW()
+         ORDER BY u.user_id`,
+		teamName,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Away, error) {
+		var away models.Away
+		err := row.Scan(
+			&away.User.UserID, &away.User.Username, &away.User.TeamName, &away.User.IsActive,
+			&away.TimeOff.ID, &away.TimeOff.UserID, &away.TimeOff.StartsAt, &away.TimeOff.EndsAt,
+			&away.TimeOff.Reason, &away.TimeOff.ReassignReviews,
+		)
+		return away, err
+	})
+}
+
+// DeleteTimeOff cancels a period of the user. Cancelling a current period
+// makes the user eligible for reviews again right away.
+func (repo *postgresTimeOffRepo) DeleteTimeOff(ctx context.Context, userID string, timeOffID int64) error {
+	tag, err := repo.pool.Exec(ctx,
+		"DELETE FROM time_off WHERE user_id = $1 AND time_off_id = $2",
+		userID, timeOffID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("time off %d of user %s: %w", timeOffID, userID, models.ErrNotFound)
+	}
+
+	return nil
+}
+
+// StartDueTimeOff marks the periods that have begun as started and hands the
+// open reviews of those asking for it over to teammates. A review is kept
+// when the author's team has no one else to take it. Rows locked by another
+// instance are skipped, so several API replicas can run the scheduler.
+func (repo *postgresTimeOffRepo) StartDueTimeOff(ctx context.Context) ([]models.TimeOff, []models.ReviewerChange, error) {
+	tx, err := repo.pool.Begin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer rollback(ctx, tx)
+
+	rows, err := tx.Query(ctx,
+		`UPDATE time_off SET started = true
+         WHERE time_off_id IN (
+             SELECT time_off_id FROM time_off
+             WHERE NOT started AND starts_at <= NOW() AND ends_at > NOW()
+             FOR UPDATE SKIP LOCKED
+         )
+         RETURNING time_off_id, user_id, starts_at, ends_at, reason, reassign_reviews`,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	started, err := pgx.CollectRows(rows, scanTimeOff)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var userIDs []string
+	for _, timeOff := range started {
+		if timeOff.ReassignReviews {
+			userIDs = append(userIDs, timeOff.UserID)
+		}
+	}
+
+	var changes []models.ReviewerChange
+	if len(userIDs) > 0 {
+		changes, err = handOverReviews(ctx, tx, userIDs)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	return started, changes, nil
+}
+
+// EndDueTimeOff marks the periods that are over as ended, including ones that
+// were over before the scheduler saw them start.
+func (repo *postgresTimeOffRepo) EndDueTimeOff(ctx context.Context) ([]models.TimeOff, error) {
+	rows, err := repo.pool.Query(ctx,
+		`UPDATE time_off SET started = true, ended = true
+         WHERE time_off_id IN (
+             SELECT time_off_id FROM time_off
+             WHERE NOT ended AND ends_at <= NOW()
+             FOR UPDATE SKIP LOCKED
+         )
+         RETURNING time_off_id, user_id, starts_at, ends_at, reason, reassign_reviews`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, scanTimeOff)
+}
+
+// handOverReviews moves the open reviews of userIDs to other members of each
+// PR's review team: the repository's owning team, or else the author's.
+// Unlike releaseReviews it never drops a review.
+func handOverReviews(ctx context.Context, tx pgx.Tx, userIDs []string) ([]models.ReviewerChange, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT prr.pull_request_id, prr.user_id, pr.author_id, COALESCE(rp.team_name, a.team_name, '')
+         FROM pull_request_reviewers prr
+         JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+         JOIN users a ON a.user_id = pr.author_id
+         LEFT JOIN repositories rp ON rp.repository = pr.repository
+         WHERE prr.user_id = ANY($1::text[]) AND pr.status = 'OPEN'
+         ORDER BY prr.pull_request_id, prr.user_id`,
+		userIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	type review struct {
+		change     models.ReviewerChange
+		authorID   string
+		reviewTeam string
+	}
+
+	reviews, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (review, error) {
+		var r review
+		err := row.Scan(&r.change.PullRequestID, &r.change.OldReviewerID, &r.authorID, &r.reviewTeam)
+		return r, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]models.ReviewerChange, 0, len(reviews))
+
+	for _, r := range reviews {
+		if r.reviewTeam == "" {
+			continue
+		}
+
+		r.change.NewReviewerID, err = assignReplacement(ctx, tx, r.change.PullRequestID, r.reviewTeam, r.authorID)
+		if err != nil {
+			return nil, err
+		}
+
+		if r.change.NewReviewerID == "" {
+			continue
+		}
+
+		_, err = tx.Exec(ctx,
+			"DELETE FROM pull_request_reviewers WHERE pull_request_id = $1 AND user_id = $2",
+			r.change.PullRequestID, r.change.OldReviewerID,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, r.change)
+	}
+
+	return changes, nil
+}
+
+func scanTimeOff(row pgx.CollectableRow) (models.TimeOff, error) {
+	var timeOff models.TimeOff
+	err := row.Scan(&timeOff.ID, &timeOff.UserID, &timeOff.StartsAt, &timeOff.EndsAt, &timeOff.Reason, &timeOff.ReassignReviews)
+	return timeOff, err
+}
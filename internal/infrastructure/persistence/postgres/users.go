@@ -2,23 +2,83 @@ package postgres
 
 import (
 	"context"
-	"database/sql"
-	"log"
+	"fmt"
+	"strings"
 
 	"github.com/IlyaAGL/avito_autumn_2025/internal/models"
-	"github.com/lib/pq"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// hasCapacity is the condition that the user aliased u is below their open
+// review limit and out of the cooldown after their last assignment. Limits
+// left NULL on the user are taken from their team, so users without a team
+// are held to their own limits only.
+const hasCapacity = `NOT COALESCE(
+             COALESCE(u.max_open_reviews, (SELECT lt.max_open_reviews FROM teams lt WHERE lt.team_name = u.team_name)) <= (
+                 SELECT COUNT(*) FROM pull_request_reviewers lr
+                 JOIN pull_requests lp ON lp.pull_request_id = lr.pull_request_id
+                 WHERE lr.user_id = u.user_id AND lp.status = 'OPEN'
+             )
+             OR EXISTS (
+                 SELECT 1 FROM pull_request_reviewers lr
+                 WHERE lr.user_id = u.user_id
+                 AND lr.assigned_at > NOW() - make_interval(mins => COALESCE(
+                     u.review_cooldown_minutes,
+                     (SELECT lt.review_cooldown_minutes FROM teams lt WHERE lt.team_name = u.team_name)
+                 ))
+             ),
+             false
+         )`
+
+// checkCapacity locks the users and fails with models.ErrReviewerBusy when
+// any of them is no longer within hasCapacity. Reviewers are picked before
+// the transaction starts; the lock makes concurrent assignments of a user
+// wait for each other, so the check sees the reviews committed meanwhile.
+func checkCapacity(ctx context.Context, tx pgx.Tx, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	_, err := tx.Exec(ctx,
+		"SELECT user_id FROM users WHERE user_id = ANY($1::text[]) ORDER BY user_id FOR UPDATE",
+		userIDs,
+	)
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(ctx,
+		`SELECT u.user_id FROM users u
+         WHERE u.user_id = ANY($1::text[]) AND NOT (`+hasCapacity+`)
+         ORDER BY u.user_id`,
+		userIDs,
+	)
+	if err != nil {
+		return err
+	}
+
+	busy, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return err
+	}
+	if len(busy) > 0 {
+		return fmt.Errorf("%w: %s", models.ErrReviewerBusy, strings.Join(busy, ", "))
+	}
+
+	return nil
+}
+
 type postgresUserRepo struct {
-	db *sql.DB
+	pool *pgxpool.Pool
 }
 
-func NewPostgresUserRepository(db *sql.DB) *postgresUserRepo {
-	return &postgresUserRepo{db: db}
+func NewPostgresUserRepository(pool *pgxpool.Pool) *postgresUserRepo {
+	return &postgresUserRepo{pool: pool}
 }
 
 func (repo *postgresUserRepo) CreateOrUpdateUser(ctx context.Context, user *models.User) error {
-	_, err := repo.db.ExecContext(ctx,
+	_, err := repo.pool.Exec(ctx,
 		`INSERT INTO users (user_id, username, team_name, is_active) 
          VALUES ($1, $2, $3, $4)
          ON CONFLICT (user_id) 
@@ -29,78 +89,236 @@ func (repo *postgresUserRepo) CreateOrUpdateUser(ctx context.Context, user *mode
 	return err
 }
 
+// CreateUser inserts a user, without a team when user.TeamName is empty. An
+// existing user_id fails with models.ErrUserExists.
+func (repo *postgresUserRepo) CreateUser(ctx context.Context, user *models.User) error {
+	_, err := repo.pool.Exec(ctx,
+		`INSERT INTO users (user_id, username, team_name, is_active)
+         VALUES ($1, $2, NULLIF($3, ''), $4)`,
+		user.UserID, user.Username, user.TeamName, user.IsActive,
+	)
+	if isUniqueViolation(err) {
+		return models.ErrUserExists
+	}
+
+	return err
+}
+
+// UpdateUser changes the username and active flag, keeping the team.
+func (repo *postgresUserRepo) UpdateUser(ctx context.Context, userID, username string, isActive bool) (*models.User, error) {
+	var user models.User
+	err := repo.pool.QueryRow(ctx,
+		`UPDATE users SET username = $2, is_active = $3, updated_at = NOW()
+         WHERE user_id = $1
+         RETURNING user_id, username, COALESCE(team_name, ''), is_active`,
+		userID, username, isActive,
+	).Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive)
+	if err != nil {
+		return nil, notFound(err)
+	}
+
+	return &user, nil
+}
+
 func (repo *postgresUserRepo) GetUser(ctx context.Context, userID string) (*models.User, error) {
 	var user models.User
-	err := repo.db.QueryRowContext(ctx,
-		"SELECT user_id, username, team_name, is_active FROM users WHERE user_id = $1",
+	err := repo.pool.QueryRow(ctx,
+		"SELECT user_id, username, COALESCE(team_name, ''), is_active FROM users WHERE user_id = $1",
 		userID,
 	).Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive)
 	if err != nil {
-		return nil, err
+		return nil, notFound(err)
 	}
 
 	return &user, nil
 }
 
 func (repo *postgresUserRepo) SetUserActive(ctx context.Context, userID string, isActive bool) (*models.User, error) {
-	_, err := repo.db.ExecContext(ctx,
-		"UPDATE users SET is_active = $1, updated_at = NOW() WHERE user_id = $2",
+	var user models.User
+	err := repo.pool.QueryRow(ctx,
+		`UPDATE users SET is_active = $1, updated_at = NOW() WHERE user_id = $2
+         RETURNING user_id, username, COALESCE(team_name, ''), is_active`,
 		isActive, userID,
+	).Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive)
+	if err != nil {
+		return nil, notFound(err)
+	}
+
+	return &user, nil
+}
+
+// ListUsers returns every user, including users without a team.
+func (repo *postgresUserRepo) ListUsers(ctx context.Context) ([]models.User, error) {
+	rows, err := repo.pool.Query(ctx,
+		"SELECT user_id, username, COALESCE(team_name, ''), is_active FROM users ORDER BY user_id",
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	return repo.GetUser(ctx, userID)
+	return pgx.CollectRows(rows, scanUser)
 }
 
+// GetActiveTeamMembers returns the members who can be picked as reviewers:
+// active, not on time off and within their review limits.
 func (repo *postgresUserRepo) GetActiveTeamMembers(ctx context.Context, teamName string, excludeUserIDs []string) ([]models.User, error) {
-	var users []models.User
-
-	var query string
-	
-	var args []any
-
-	if len(excludeUserIDs) > 0 {
-		query = `SELECT user_id, username, team_name, is_active 
-                 FROM users 
-                 WHERE team_name = $1 AND is_active = true AND user_id != ALL($2)
-                 ORDER BY user_id`
-		args = []any{teamName, pq.Array(excludeUserIDs)}
-	} else {
-		query = `SELECT user_id, username, team_name, is_active 
-                 FROM users 
-                 WHERE team_name = $1 AND is_active = true
-                 ORDER BY user_id`
-		args = []any{teamName}
-	}
-
-	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if excludeUserIDs == nil {
+		excludeUserIDs = []string{}
+	}
+
+	rows, err := repo.pool.Query(ctx,
+		`SELECT u.user_id, u.username, u.team_name, u.is_active
+         FROM users u
+         WHERE u.team_name = $1 AND u.is_active = true AND u.user_id != ALL($2::text[])
+         AND `+notAway+`
+         AND `+hasCapacity+`
+         ORDER BY u.user_id`,
+		teamName, excludeUserIDs,
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	defer func() {
-		if err := rows.Close(); err != nil {
-			log.Println("Failed to close the rows")
+	return pgx.CollectRows(rows, scanUser)
+}
+
+// GetAvailableUsers returns the users among userIDs and the members of
+// teamNames who can be picked as reviewers, like GetActiveTeamMembers.
+func (repo *postgresUserRepo) GetAvailableUsers(ctx context.Context, userIDs, teamNames, excludeUserIDs []string) ([]models.User, error) {
+	if excludeUserIDs == nil {
+		excludeUserIDs = []string{}
+	}
+
+	rows, err := repo.pool.Query(ctx,
+		`SELECT u.user_id, u.username, COALESCE(u.team_name, ''), u.is_active
+         FROM users u
+         WHERE (u.user_id = ANY($1::text[]) OR u.team_name = ANY($2::text[]))
+         AND u.is_active = true AND u.user_id != ALL($3::text[])
+         AND `+notAway+`
+         AND `+hasCapacity+`
+         ORDER BY u.user_id`,
+		userIDs, teamNames, excludeUserIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, scanUser)
+}
+
+// SetReviewLimits replaces the user's own limits; nil fields fall back to
+// the team.
+func (repo *postgresUserRepo) SetReviewLimits(ctx context.Context, userID string, limits models.ReviewLimits) error {
+	tag, err := repo.pool.Exec(ctx,
+		`UPDATE users SET max_open_reviews = $2, review_cooldown_minutes = $3, updated_at = NOW()
+         WHERE user_id = $1`,
+		userID, limits.MaxOpenReviews, limits.CooldownMinutes,
+	)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("user %s: %w", userID, models.ErrNotFound)
+	}
+
+	return nil
+}
+
+// GetSkills returns the skills of each of userIDs; users without skills are
+// left out.
+func (repo *postgresUserRepo) GetSkills(ctx context.Context, userIDs []string) (map[string][]string, error) {
+	rows, err := repo.pool.Query(ctx,
+		`SELECT user_id, skill FROM user_skills
+         WHERE user_id = ANY($1::text[])
+         ORDER BY user_id, skill`,
+		userIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	skills := make(map[string][]string)
+	for rows.Next() {
+		var userID, skill string
+		if err := rows.Scan(&userID, &skill); err != nil {
+			return nil, err
 		}
-	}()
+		skills[userID] = append(skills[userID], skill)
+	}
+
+	return skills, rows.Err()
+}
+
+// GetOpenReviews counts the open PRs each of userIDs reviews by PR size;
+// users without open reviews are left out.
+func (repo *postgresUserRepo) GetOpenReviews(ctx context.Context, userIDs []string) (map[string]models.OpenReviews, error) {
+	rows, err := repo.pool.Query(ctx,
+		`SELECT r.user_id, COALESCE(p.size, ''), COUNT(*) FROM pull_request_reviewers r
+         JOIN pull_requests p ON p.pull_request_id = r.pull_request_id
+         WHERE r.user_id = ANY($1::text[]) AND p.status = 'OPEN'
+         GROUP BY r.user_id, p.size`,
+		userIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
+	reviews := make(map[string]models.OpenReviews)
 	for rows.Next() {
-		var user models.User
-		if err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive); err != nil {
+		var userID, size string
+		var count int
+		if err := rows.Scan(&userID, &size, &count); err != nil {
 			return nil, err
 		}
-		users = append(users, user)
+		if reviews[userID] == nil {
+			reviews[userID] = make(models.OpenReviews)
+		}
+		reviews[userID][size] = count
 	}
 
-	return users, nil
+	return reviews, rows.Err()
 }
 
-func (repo *postgresUserRepo) GetUserReviewPRs(ctx context.Context, userID string) ([]models.PullRequestShort, error) {
-	var prs []models.PullRequestShort
+// SetSkills replaces the skills of the user.
+func (repo *postgresUserRepo) SetSkills(ctx context.Context, userID string, skills []string) error {
+	tx, err := repo.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
 
-	rows, err := repo.db.QueryContext(ctx,
+	defer rollback(ctx, tx)
+
+	var lockedID string
+	err = tx.QueryRow(ctx, "SELECT user_id FROM users WHERE user_id = $1 FOR UPDATE", userID).Scan(&lockedID)
+	if err != nil {
+		return fmt.Errorf("user %s: %w", userID, notFound(err))
+	}
+
+	if skills == nil {
+		skills = []string{}
+	}
+
+	batch := &pgx.Batch{}
+	batch.Queue("DELETE FROM user_skills WHERE user_id = $1", userID)
+	batch.Queue(
+		`INSERT INTO user_skills (user_id, skill)
+         SELECT $1, unnest($2::text[])
+         ON CONFLICT DO NOTHING`,
+		userID, skills,
+	)
+
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (repo *postgresUserRepo) GetUserReviewPRs(ctx context.Context, userID string) ([]models.PullRequestShort, error) {
+	rows, err := repo.pool.Query(ctx,
 		`SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status
          FROM pull_requests pr
          JOIN pull_request_reviewers prr ON pr.pull_request_id = prr.pull_request_id
@@ -112,20 +330,11 @@ func (repo *postgresUserRepo) GetUserReviewPRs(ctx context.Context, userID strin
 		return nil, err
 	}
 
-	defer func() {
-		if err := rows.Close(); err != nil {
-			log.Println("Failed to close the rows")
-		}
-	}()
-
-	for rows.Next() {
-		var pr models.PullRequestShort
-		if err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status); err != nil {
-			return nil, err
-		}
-		
-		prs = append(prs, pr)
-	}
+	return pgx.CollectRows(rows, scanPullRequestShort)
+}
 
-	return prs, nil
+func scanUser(row pgx.CollectableRow) (models.User, error) {
+	var user models.User
+	err := row.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive)
+	return user, err
 }
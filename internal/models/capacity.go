@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// ReviewLimits cap how many reviews a user takes on. A nil field of a user
+// falls back to their team; a nil field of a team means no limit.
+type ReviewLimits struct {
+	MaxOpenReviews  *int
+	CooldownMinutes *int
+}
+
+// ReviewLoad is a member's open reviews against their effective limits.
+type ReviewLoad struct {
+	User        User
+	OpenReviews int
+	Limits      ReviewLimits
+	// CooldownUntil is when the cooldown after the last assignment ends, if
+	// it has not ended yet.
+	CooldownUntil *time.Time
+	Away          bool
+	// Available reports whether the member can be picked as a reviewer now.
+	Available bool
+}
+
+type TeamReviewLoad struct {
+	TeamName string
+	Limits   ReviewLimits
+	Members  []ReviewLoad
+}
@@ -0,0 +1,9 @@
+package models
+
+// CodeOwnerRule is one line of a CODEOWNERS file. Owners are kept as written:
+// "@user_id" names a user and "@org/team_name" a team.
+type CodeOwnerRule struct {
+	Line    int
+	Pattern string
+	Owners  []string
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Domain errors returned by the repositories and services. Callers wrap them
+// with context and transports map them with errors.Is.
+var (
+	ErrNotFound       = errors.New("not found")
+	ErrTeamExists     = errors.New("team already exists")
+	ErrUserExists     = errors.New("user already exists")
+	ErrTeamNotEmpty   = errors.New("team still has members")
+	ErrMemberConflict = errors.New("user belongs to another team")
+	ErrPRExists       = errors.New("PR already exists")
+	ErrPRMerged       = errors.New("cannot reassign on merged PR")
+	ErrNotAssigned    = errors.New("reviewer is not assigned to this PR")
+	ErrNoCandidate    = errors.New("no active replacement candidate in team")
+	ErrInvalidFilter  = errors.New("unsupported filter")
+	ErrInvalidValue   = errors.New("invalid value")
+	ErrTimeOffOverlap = errors.New("time off overlaps an existing period")
+	ErrRepoExists     = errors.New("repository already exists")
+	ErrRepoNotEmpty   = errors.New("repository still has pull requests")
+	ErrReviewerBusy   = errors.New("reviewer has no review capacity left")
+)
+
+// MemberConflict is a user that already belongs to a team other than the
+// one being changed.
+type MemberConflict struct {
+	UserID   string
+	TeamName string
+}
+
+// MemberConflictError reports every conflicting user at once. It matches
+// ErrMemberConflict with errors.Is.
+type MemberConflictError struct {
+	Conflicts []MemberConflict
+}
+
+func (e *MemberConflictError) Error() string {
+	users := make([]string, len(e.Conflicts))
+	for i, conflict := range e.Conflicts {
+		users[i] = fmt.Sprintf("%s (team %s)", conflict.UserID, conflict.TeamName)
+	}
+
+	return fmt.Sprintf("%s: %s", ErrMemberConflict, strings.Join(users, ", "))
+}
+
+func (e *MemberConflictError) Is(target error) bool {
+	return target == ErrMemberConflict
+}
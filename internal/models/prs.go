@@ -8,16 +8,47 @@ type PullRequest struct {
 	AuthorID          string
 	Status            string
 	AssignedReviewers []string
-	CreatedAt         time.Time
-	MergedAt          *time.Time
+	Tags              []string
+	// Repository owns the PR, if any; Number is its number there.
+	Repository string
+	Number     int
+	// RequiredReviewers is how many reviewers the policy and the size asked
+	// for when the PR was created.
+	RequiredReviewers int
+	// LinesAdded, LinesRemoved and FilesChanged are nil when not given;
+	// Size is their bucket.
+	LinesAdded   *int
+	LinesRemoved *int
+	FilesChanged *int
+	Size         string
+	Priority     string
+	// OwnerRules maps reviewers assigned as code owners to their rule.
+	OwnerRules map[string]string
+	CreatedAt  time.Time
+	MergedAt   *time.Time
 }
 
 type ReviewStats struct {
-	UserID   string
-	OpenPRs  int
+	UserID       string
 	TotalReviews int
 }
 
+// PRGroupStats counts the PRs of a size and priority.
+type PRGroupStats struct {
+	Size     string
+	Priority string
+	OpenPRs  int
+	TotalPRs int
+}
+
+type PullRequestFilter struct {
+	Status   string
+	AuthorID string
+	Repository string
+	// Understaffed keeps only PRs with fewer reviewers than they require.
+	Understaffed bool
+}
+
 type PullRequestShort struct {
 	ID       string
 	Name     string
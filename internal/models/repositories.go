@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+// Review strategies: how the team reviewers of a PR are picked among the
+// available candidates. Both fill the expert seats of a tagged PR first.
+const (
+	// StrategyRandom picks at random.
+	StrategyRandom = "random"
+	// StrategyLeastLoaded picks the candidates with the least open review
+	// load, weighted by PR size, at random among equals.
+	StrategyLeastLoaded = "least_loaded"
+)
+
+// Repository owns the pull requests numbered in it. Its reviewers are drawn
+// from the owning team when it has one; nil policy fields fall back to the
+// service defaults.
+type Repository struct {
+	Name      string
+	TeamName  string
+	Reviewers *int
+	Strategy  *string
+	// PullRequests is how many pull requests the repository has.
+	PullRequests int
+	CreatedAt    time.Time
+}
+
+// ReviewPolicy decides how many team reviewers a PR gets and how they are
+// picked.
+type ReviewPolicy struct {
+	Reviewers int
+	Strategy  string
+	// MinExperts is how many reviewers of a tagged PR should have a skill
+	// matching its tags, as far as such candidates exist.
+	MinExperts int
+}
+
+// For returns the policy with the overrides of repo applied.
+func (p ReviewPolicy) For(repo *Repository) ReviewPolicy {
+	if repo == nil {
+		return p
+	}
+
+	if repo.Reviewers != nil {
+		p.Reviewers = *repo.Reviewers
+	}
+	if repo.Strategy != nil {
+		p.Strategy = *repo.Strategy
+	}
+
+	return p
+}
+
+// ForPR adjusts the policy to a PR: larger sizes add reviewers, up to
+// MaxReviewers, and urgent PRs go to the least loaded candidates.
+func (p ReviewPolicy) ForPR(size, priority string) ReviewPolicy {
+	p.Reviewers = min(p.Reviewers+ExtraReviewers(size), MaxReviewers)
+	if priority == PriorityUrgent {
+		p.Strategy = StrategyLeastLoaded
+	}
+
+	return p
+}
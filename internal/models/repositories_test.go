@@ -0,0 +1,54 @@
+package models
+
+import "testing"
+
+func TestReviewPolicyForPR(t *testing.T) {
+	base := ReviewPolicy{Reviewers: 2, Strategy: StrategyRandom, MinExperts: 1}
+
+	tests := []struct {
+		name     string
+		policy   ReviewPolicy
+		size     string
+		priority string
+		want     ReviewPolicy
+	}{
+		{name: "unsized", policy: base, priority: PriorityNormal, want: base},
+		{name: "small", policy: base, size: SizeSmall, priority: PriorityLow, want: base},
+		{
+			name:     "large adds a reviewer",
+			policy:   base,
+			size:     SizeLarge,
+			priority: PriorityNormal,
+			want:     ReviewPolicy{Reviewers: 3, Strategy: StrategyRandom, MinExperts: 1},
+		},
+		{
+			name:     "huge adds two reviewers",
+			policy:   base,
+			size:     SizeHuge,
+			priority: PriorityNormal,
+			want:     ReviewPolicy{Reviewers: 4, Strategy: StrategyRandom, MinExperts: 1},
+		},
+		{
+			name:     "capped at MaxReviewers",
+			policy:   ReviewPolicy{Reviewers: 9, Strategy: StrategyRandom},
+			size:     SizeHuge,
+			priority: PriorityNormal,
+			want:     ReviewPolicy{Reviewers: MaxReviewers, Strategy: StrategyRandom},
+		},
+		{
+			name:     "urgent goes to the least loaded",
+			policy:   base,
+			size:     SizeMedium,
+			priority: PriorityUrgent,
+			want:     ReviewPolicy{Reviewers: 2, Strategy: StrategyLeastLoaded, MinExperts: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.ForPR(tt.size, tt.priority); got != tt.want {
+				t.Errorf("ForPR = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
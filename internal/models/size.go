@@ -0,0 +1,125 @@
+package models
+
+// Pull request priorities.
+const (
+	PriorityLow    = "low"
+	PriorityNormal = "normal"
+	// PriorityUrgent PRs go to the least loaded candidates whatever the
+	// review strategy.
+	PriorityUrgent = "urgent"
+)
+
+// Priorities lists the priorities, lowest first.
+func Priorities() []string {
+	return []string{PriorityLow, PriorityNormal, PriorityUrgent}
+}
+
+// Size buckets of pull requests, smallest first. A PR created without lines
+// or files changed has no size.
+const (
+	SizeSmall  = "small"
+	SizeMedium = "medium"
+	SizeLarge  = "large"
+	SizeHuge   = "huge"
+)
+
+// MaxReviewers caps the reviewers of a PR after its size is applied.
+const MaxReviewers = 10
+
+type sizeBucket struct {
+	name string
+	// maxLines and maxFiles are the most lines and files changed a PR of the
+	// bucket may have; 0 means no limit.
+	maxLines int
+	maxFiles int
+	// extraReviewers are added to what the policy asks for.
+	extraReviewers int
+	// weight is how much an open review of the size adds to a reviewer's
+	// load.
+	weight int
+}
+
+var sizeBuckets = []sizeBucket{
+	{name: SizeSmall, maxLines: 50, maxFiles: 5, weight: 1},
+	{name: SizeMedium, maxLines: 250, maxFiles: 15, weight: 2},
+	{name: SizeLarge, maxLines: 1000, maxFiles: 50, extraReviewers: 1, weight: 4},
+	{name: SizeHuge, extraReviewers: 2, weight: 8},
+}
+
+// unsizedWeight is the load of an open review of a PR without a size, taken
+// to be medium.
+const unsizedWeight = 2
+
+// Sizes lists the size buckets, smallest first.
+func Sizes() []string {
+	sizes := make([]string, len(sizeBuckets))
+	for i, bucket := range sizeBuckets {
+		sizes[i] = bucket.name
+	}
+
+	return sizes
+}
+
+// PRSize returns the smallest bucket that fits both the lines and the files
+// changed; either may be left out. It returns "" when both are.
+func PRSize(lines, files *int) string {
+	if lines == nil && files == nil {
+		return ""
+	}
+
+	for _, bucket := range sizeBuckets {
+		if lines != nil && bucket.maxLines > 0 && *lines > bucket.maxLines {
+			continue
+		}
+		if files != nil && bucket.maxFiles > 0 && *files > bucket.maxFiles {
+			continue
+		}
+
+		return bucket.name
+	}
+
+	return SizeHuge
+}
+
+// ExtraReviewers returns how many reviewers a PR of the size gets on top of
+// the policy.
+func ExtraReviewers(size string) int {
+	for _, bucket := range sizeBuckets {
+		if bucket.name == size {
+			return bucket.extraReviewers
+		}
+	}
+
+	return 0
+}
+
+// OpenReviews counts a reviewer's open reviews by PR size, with "" for PRs
+// without one.
+type OpenReviews map[string]int
+
+// Count returns the number of open reviews.
+func (r OpenReviews) Count() int {
+	count := 0
+	for _, n := range r {
+		count += n
+	}
+
+	return count
+}
+
+// Load weighs the open reviews by size, so one huge PR counts as much as
+// several small ones.
+func (r OpenReviews) Load() int {
+	load := 0
+	for size, n := range r {
+		weight := unsizedWeight
+		for _, bucket := range sizeBuckets {
+			if bucket.name == size {
+				weight = bucket.weight
+			}
+		}
+		load += weight * n
+	}
+
+	return load
+}
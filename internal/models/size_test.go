@@ -0,0 +1,82 @@
+package models
+
+import "testing"
+
+func TestPRSize(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines *int
+		files *int
+		want  string
+	}{
+		{name: "unknown", want: ""},
+		{name: "no changes", lines: ptr(0), files: ptr(0), want: SizeSmall},
+		{name: "small upper bound", lines: ptr(50), files: ptr(5), want: SizeSmall},
+		{name: "lines past small", lines: ptr(51), files: ptr(1), want: SizeMedium},
+		{name: "files past small", lines: ptr(10), files: ptr(6), want: SizeMedium},
+		{name: "medium upper bound", lines: ptr(250), files: ptr(15), want: SizeMedium},
+		{name: "large", lines: ptr(251), want: SizeLarge},
+		{name: "large upper bound", lines: ptr(1000), files: ptr(50), want: SizeLarge},
+		{name: "huge by lines", lines: ptr(1001), files: ptr(1), want: SizeHuge},
+		{name: "huge by files", lines: ptr(1), files: ptr(51), want: SizeHuge},
+		{name: "files only", files: ptr(20), want: SizeLarge},
+		{name: "lines only", lines: ptr(30), want: SizeSmall},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PRSize(tt.lines, tt.files); got != tt.want {
+				t.Errorf("PRSize = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtraReviewers(t *testing.T) {
+	tests := []struct {
+		size string
+		want int
+	}{
+		{size: "", want: 0},
+		{size: SizeSmall, want: 0},
+		{size: SizeMedium, want: 0},
+		{size: SizeLarge, want: 1},
+		{size: SizeHuge, want: 2},
+	}
+
+	for _, tt := range tests {
+		if got := ExtraReviewers(tt.size); got != tt.want {
+			t.Errorf("ExtraReviewers(%q) = %d, want %d", tt.size, got, tt.want)
+		}
+	}
+}
+
+func TestOpenReviews(t *testing.T) {
+	tests := []struct {
+		name      string
+		reviews   OpenReviews
+		wantCount int
+		wantLoad  int
+	}{
+		{name: "none", reviews: nil},
+		{name: "small", reviews: OpenReviews{SizeSmall: 3}, wantCount: 3, wantLoad: 3},
+		{name: "unsized counts as medium", reviews: OpenReviews{"": 1, SizeMedium: 1}, wantCount: 2, wantLoad: 4},
+		{name: "one huge outweighs several small", reviews: OpenReviews{SizeHuge: 1}, wantCount: 1, wantLoad: 8},
+		{name: "mixed", reviews: OpenReviews{SizeSmall: 2, SizeLarge: 1, SizeHuge: 1}, wantCount: 4, wantLoad: 14},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.reviews.Count(); got != tt.wantCount {
+				t.Errorf("Count = %d, want %d", got, tt.wantCount)
+			}
+			if got := tt.reviews.Load(); got != tt.wantLoad {
+				t.Errorf("Load = %d, want %d", got, tt.wantLoad)
+			}
+		})
+	}
+}
+
+func ptr(n int) *int {
+	return &n
+}
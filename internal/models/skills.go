@@ -0,0 +1,24 @@
+package models
+
+// Reasons a reviewer was picked.
+const (
+	// MatchSkill means the reviewer has a skill matching a tag of the PR.
+	MatchSkill = "skill"
+	// MatchFallback means the PR has tags but no more matching candidates
+	// were wanted or available.
+	MatchFallback = "fallback"
+	// MatchRandom means the PR has no tags.
+	MatchRandom = "random"
+	// MatchOwner means the reviewer owns a changed path by a CODEOWNERS rule.
+	MatchOwner = "owner"
+)
+
+// ReviewerMatch explains why a reviewer was picked.
+type ReviewerMatch struct {
+	UserID string
+	Reason string
+	// Skills are the reviewer's skills among the PR tags.
+	Skills []string
+	// Rule is the CODEOWNERS pattern that assigned an owner.
+	Rule string
+}
@@ -5,8 +5,78 @@ type Team struct {
 	Members []Member
 }
 
+// CreateTeamOptions relax the default create-only semantics of CreateTeam.
+type CreateTeamOptions struct {
+	// Upsert updates an existing team instead of failing with ErrTeamExists.
+	Upsert bool
+	// AllowMove moves listed users out of their current team instead of
+	// failing with a MemberConflictError.
+	AllowMove bool
+}
+
+// RemoveMembersOptions adjust what happens to users removed from a team.
+type RemoveMembersOptions struct {
+	// KeepActive leaves the active flag alone instead of deactivating the
+	// removed users.
+	KeepActive bool
+}
+
+type TeamSummary struct {
+	Name          string
+	Members       int
+	ActiveMembers int
+}
+
 type Member struct {
 	UserID   string
 	Username string
 	IsActive bool
 }
+
+// ReviewerChange records what happened to an open review when its reviewer
+// left the author's team. An empty NewReviewerID means no active candidate
+// was found and the review was dropped.
+type ReviewerChange struct {
+	PullRequestID string
+	OldReviewerID string
+	NewReviewerID string
+}
+
+// SyncTeam is one team of the desired roster passed to SyncTeams. Its
+// limits replace the team defaults; nil fields mean no limit.
+type SyncTeam struct {
+	Name    string
+	Limits  ReviewLimits
+	Members []Member
+}
+
+// SyncPlan lists what SyncTeams changes to turn the roster into the desired
+// state. TeamsUpdated are existing teams whose review limits change.
+// Reassignments are only known once the plan is applied.
+type SyncPlan struct {
+	TeamsCreated  []string
+	TeamsUpdated  []string
+	TeamsRemoved  []string
+	UsersAdded    []SyncUserChange
+	UsersMoved    []SyncUserChange
+	UsersUpdated  []SyncUserChange
+	UsersRemoved  []SyncUserChange
+	Reassignments []ReviewerChange
+}
+
+// Empty reports whether applying the plan changes nothing.
+func (p *SyncPlan) Empty() bool {
+	return len(p.TeamsCreated) == 0 && len(p.TeamsUpdated) == 0 && len(p.TeamsRemoved) == 0 &&
+		len(p.UsersAdded) == 0 && len(p.UsersMoved) == 0 &&
+		len(p.UsersUpdated) == 0 && len(p.UsersRemoved) == 0
+}
+
+// SyncUserChange is one user in a sync plan. TeamName is the team the user
+// ends up in, or the team they leave for removed users.
+type SyncUserChange struct {
+	UserID   string
+	Username string
+	TeamName string
+	FromTeam string
+	IsActive bool
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// TimeOff is an absence period. The user is not picked as a reviewer from
+// StartsAt until EndsAt.
+type TimeOff struct {
+	ID       int64
+	UserID   string
+	StartsAt time.Time
+	EndsAt   time.Time
+	Reason   string
+	// ReassignReviews hands the user's open reviews over to teammates when
+	// the period starts.
+	ReassignReviews bool
+}
+
+// Away is a team member on time off right now.
+type Away struct {
+	User    User
+	TimeOff TimeOff
+}
@@ -0,0 +1,7 @@
+-- Members removed from a team keep their user row (and review history) with no team.
+ALTER TABLE users ALTER COLUMN team_name DROP NOT NULL;
+
+-- Renaming a team cascades to its members; deleting a team with members is refused.
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_team_name_fkey;
+ALTER TABLE users ADD CONSTRAINT users_team_name_fkey
+    FOREIGN KEY (team_name) REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE RESTRICT;
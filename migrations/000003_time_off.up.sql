@@ -0,0 +1,17 @@
+-- Absence periods. While NOW() is inside [starts_at, ends_at) the user is not
+-- picked as a reviewer; the scheduler marks periods started and ended.
+CREATE TABLE IF NOT EXISTS time_off (
+    time_off_id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    reassign_reviews BOOLEAN NOT NULL DEFAULT false,
+    started BOOLEAN NOT NULL DEFAULT false,
+    ended BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP DEFAULT NOW(),
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_time_off_user ON time_off(user_id, ends_at);
+CREATE INDEX IF NOT EXISTS idx_time_off_pending ON time_off(starts_at) WHERE NOT ended;
@@ -0,0 +1,6 @@
+ALTER TABLE pull_request_reviewers ALTER COLUMN assigned_at TYPE TIMESTAMP;
+
+ALTER TABLE users DROP COLUMN IF EXISTS review_cooldown_minutes;
+ALTER TABLE users DROP COLUMN IF EXISTS max_open_reviews;
+ALTER TABLE teams DROP COLUMN IF EXISTS review_cooldown_minutes;
+ALTER TABLE teams DROP COLUMN IF EXISTS max_open_reviews;
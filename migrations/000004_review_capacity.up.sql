@@ -0,0 +1,10 @@
+-- Review capacity: at most max_open_reviews open reviews per user and no new
+-- assignment within review_cooldown_minutes of the previous one. NULL on a
+-- user falls back to the team value; NULL on a team means no limit.
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS max_open_reviews INT CHECK (max_open_reviews > 0);
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS review_cooldown_minutes INT CHECK (review_cooldown_minutes >= 0);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS max_open_reviews INT CHECK (max_open_reviews > 0);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS review_cooldown_minutes INT CHECK (review_cooldown_minutes >= 0);
+
+-- Cooldowns compare assigned_at with NOW(), so store it with the time zone.
+ALTER TABLE pull_request_reviewers ALTER COLUMN assigned_at TYPE TIMESTAMPTZ;
@@ -0,0 +1,13 @@
+-- Skill tags. Reviewer selection prefers users with a skill matching one of
+-- the tags of the pull request.
+CREATE TABLE IF NOT EXISTS user_skills (
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    skill VARCHAR(64) NOT NULL,
+    PRIMARY KEY (user_id, skill)
+);
+
+CREATE TABLE IF NOT EXISTS pull_request_tags (
+    pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    tag VARCHAR(64) NOT NULL,
+    PRIMARY KEY (pull_request_id, tag)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE pull_request_reviewers DROP COLUMN IF EXISTS owner_rule;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS repository;
+DROP TABLE IF EXISTS code_owner_rules;
@@ -0,0 +1,15 @@
+-- CODEOWNERS rules uploaded per repository, in file order; the last rule
+-- matching a path decides its owners.
+CREATE TABLE IF NOT EXISTS code_owner_rules (
+    repository VARCHAR(255) NOT NULL,
+    position INT NOT NULL,
+    line INT NOT NULL,
+    pattern VARCHAR(1024) NOT NULL,
+    owners TEXT[] NOT NULL DEFAULT '{}',
+    PRIMARY KEY (repository, position)
+);
+
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS repository VARCHAR(255);
+
+-- The rule a reviewer was assigned by as a code owner, NULL for the others.
+ALTER TABLE pull_request_reviewers ADD COLUMN IF NOT EXISTS owner_rule VARCHAR(1024);
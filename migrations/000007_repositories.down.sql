@@ -0,0 +1,7 @@
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS required_reviewers;
+ALTER TABLE code_owner_rules DROP CONSTRAINT IF EXISTS code_owner_rules_repository_fkey;
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_repository_fkey;
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_repository_number_check;
+DROP INDEX IF EXISTS idx_pull_requests_repository_number;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS number;
+DROP TABLE IF EXISTS repositories;
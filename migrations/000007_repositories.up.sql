@@ -0,0 +1,49 @@
+-- Repositories own pull requests: a PR created in a repository is numbered
+-- within it and gets its reviewers by the repository policy. NULL policy
+-- columns fall back to the service defaults.
+CREATE TABLE IF NOT EXISTS repositories (
+    repository VARCHAR(255) PRIMARY KEY,
+    team_name VARCHAR(255) REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE SET NULL,
+    reviewers INT CHECK (reviewers BETWEEN 1 AND 10),
+    strategy VARCHAR(50),
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Repositories already named by pull requests or CODEOWNERS rules become
+-- rows without an owning team or policy.
+INSERT INTO repositories (repository)
+SELECT repository FROM pull_requests WHERE repository IS NOT NULL
+UNION
+SELECT repository FROM code_owner_rules
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS number INT CHECK (number > 0);
+
+-- Existing pull requests of a repository are numbered in creation order.
+UPDATE pull_requests pr SET number = numbered.number
+FROM (
+    SELECT pull_request_id,
+           ROW_NUMBER() OVER (PARTITION BY repository ORDER BY created_at, pull_request_id) AS number
+    FROM pull_requests
+    WHERE repository IS NOT NULL
+) numbered
+WHERE pr.pull_request_id = numbered.pull_request_id AND pr.number IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pull_requests_repository_number ON pull_requests(repository, number);
+
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_repository_number_check;
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_repository_number_check
+    CHECK (repository IS NULL OR number IS NOT NULL);
+
+-- Renaming a repository cascades; deleting one with pull requests is refused.
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_repository_fkey;
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_repository_fkey
+    FOREIGN KEY (repository) REFERENCES repositories(repository) ON UPDATE CASCADE ON DELETE RESTRICT;
+
+ALTER TABLE code_owner_rules DROP CONSTRAINT IF EXISTS code_owner_rules_repository_fkey;
+ALTER TABLE code_owner_rules ADD CONSTRAINT code_owner_rules_repository_fkey
+    FOREIGN KEY (repository) REFERENCES repositories(repository) ON UPDATE CASCADE ON DELETE CASCADE;
+
+-- How many reviewers the policy asked for when the PR was created; an open PR
+-- with fewer is understaffed. Earlier PRs were created under the fixed two.
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS required_reviewers INT NOT NULL DEFAULT 2;
@@ -0,0 +1,5 @@
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS priority;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS size;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS files_changed;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS lines_removed;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS lines_added;
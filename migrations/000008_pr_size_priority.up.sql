@@ -0,0 +1,10 @@
+-- Size and priority metadata of pull requests. The size bucket is derived
+-- from the lines and files changed when the PR is created and stays as it
+-- was, like required_reviewers; PRs without the metadata have no size.
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS lines_added INT CHECK (lines_added >= 0);
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS lines_removed INT CHECK (lines_removed >= 0);
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS files_changed INT CHECK (files_changed >= 0);
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS size VARCHAR(10)
+    CHECK (size IN ('small', 'medium', 'large', 'huge'));
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS priority VARCHAR(10) NOT NULL DEFAULT 'normal'
+    CHECK (priority IN ('low', 'normal', 'urgent'));
@@ -0,0 +1,352 @@
+package migrations
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	sqlmigrations "github.com/IlyaAGL/avito_autumn_2025/migrations"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// NilVersion marks a database without any applied migration.
+const NilVersion = -1
+
+type Migrator struct {
+	m   *migrate.Migrate
+	src source.Driver
+}
+
+type MigrationStatus struct {
+	Version    uint
+	Identifier string
+	Applied    bool
+	Dirty      bool
+}
+
+type PlannedMigration struct {
+	Version    uint
+	Identifier string
+	Direction  source.Direction
+	SQL        string
+}
+
+func NewMigrator(pool *pgxpool.Pool, migrationsPath string, lockTimeout time.Duration) (*Migrator, error) {
+	driver, err := pgx.WithInstance(stdlib.OpenDBFromPool(pool), &pgx.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pg driver: %w", err)
+	}
+
+	src, err := openSource(migrationsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open migrations source: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("source", src, "pgx5", driver)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migrate instance: %w", err)
+	}
+
+	m.LockTimeout = lockTimeout
+	m.Log = migrateLogger{}
+
+	return &Migrator{m: m, src: src}, nil
+}
+
+// GracefulStop asks a running migration command to stop after the current file.
+func (mg *Migrator) GracefulStop() {
+	select {
+	case mg.m.GracefulStop <- true:
+	default:
+	}
+}
+
+func (mg *Migrator) Close() error {
+	srcErr, dbErr := mg.m.Close()
+	return errors.Join(srcErr, dbErr)
+}
+
+func (mg *Migrator) Up() error {
+	return ignoreNoChange(mg.m.Up())
+}
+
+func (mg *Migrator) Steps(n int) error {
+	return ignoreNoChange(mg.m.Steps(n))
+}
+
+func (mg *Migrator) Goto(version uint) error {
+	return ignoreNoChange(mg.m.Migrate(version))
+}
+
+func (mg *Migrator) Force(version int) error {
+	return mg.m.Force(version)
+}
+
+// Version returns the current schema version or NilVersion if nothing is applied.
+func (mg *Migrator) Version() (int, bool, error) {
+	version, dirty, err := mg.m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return NilVersion, false, nil
+	}
+
+	if err != nil {
+		return 0, false, err
+	}
+
+	return int(version), dirty, nil
+}
+
+func (mg *Migrator) Status() ([]MigrationStatus, error) {
+	current, dirty, err := mg.Version()
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := mg.versions()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(versions))
+	for _, version := range versions {
+		identifier, err := mg.identifier(version)
+		if err != nil {
+			return nil, err
+		}
+
+		statuses = append(statuses, MigrationStatus{
+			Version:    version,
+			Identifier: identifier,
+			Applied:    int(version) <= current,
+			Dirty:      dirty && int(version) == current,
+		})
+	}
+
+	return statuses, nil
+}
+
+// VerifySchema fails if the database is dirty or behind the latest migration
+// in the source and returns that latest version otherwise.
+func (mg *Migrator) VerifySchema() (uint, error) {
+	current, dirty, err := mg.Version()
+	if err != nil {
+		return 0, err
+	}
+
+	latest, err := mg.LatestVersion()
+	if err != nil {
+		return 0, err
+	}
+
+	if dirty {
+		return 0, fmt.Errorf("schema version %d is dirty", current)
+	}
+
+	if current < latest {
+		return 0, fmt.Errorf("schema version %d is behind the latest migration %d", current, latest)
+	}
+
+	if latest == NilVersion {
+		return 0, nil
+	}
+
+	return uint(latest), nil
+}
+
+// LatestVersion returns the highest version in the source or NilVersion if it is empty.
+func (mg *Migrator) LatestVersion() (int, error) {
+	versions, err := mg.versions()
+	if err != nil {
+		return 0, err
+	}
+
+	if len(versions) == 0 {
+		return NilVersion, nil
+	}
+
+	return int(versions[len(versions)-1]), nil
+}
+
+// TargetForSteps resolves the version reached after n steps (negative n goes down).
+func (mg *Migrator) TargetForSteps(n int) (int, error) {
+	current, _, err := mg.Version()
+	if err != nil {
+		return 0, err
+	}
+
+	versions, err := mg.versions()
+	if err != nil {
+		return 0, err
+	}
+
+	idx := -1
+	for i, version := range versions {
+		if int(version) <= current {
+			idx = i
+		}
+	}
+
+	targetIdx := idx + n
+	switch {
+	case targetIdx >= len(versions):
+		targetIdx = len(versions) - 1
+	case targetIdx < 0:
+		return NilVersion, nil
+	}
+
+	return int(versions[targetIdx]), nil
+}
+
+// Plan lists the migrations that would run to move the schema to target,
+// including the SQL of every file, without touching the database.
+func (mg *Migrator) Plan(target int) ([]PlannedMigration, error) {
+	current, dirty, err := mg.Version()
+	if err != nil {
+		return nil, err
+	}
+
+	if dirty {
+		return nil, migrate.ErrDirty{Version: current}
+	}
+
+	versions, err := mg.versions()
+	if err != nil {
+		return nil, err
+	}
+
+	if target != NilVersion && !slices.Contains(versions, uint(target)) {
+		return nil, fmt.Errorf("migration version %d does not exist", target)
+	}
+
+	var plan []PlannedMigration
+
+	if target >= current {
+		for _, version := range versions {
+			if int(version) > current && int(version) <= target {
+				planned, err := mg.read(version, source.Up)
+				if err != nil {
+					return nil, err
+				}
+				plan = append(plan, planned)
+			}
+		}
+
+		return plan, nil
+	}
+
+	for _, version := range slices.Backward(versions) {
+		if int(version) <= current && int(version) > target {
+			planned, err := mg.read(version, source.Down)
+			if err != nil {
+				return nil, err
+			}
+			plan = append(plan, planned)
+		}
+	}
+
+	return plan, nil
+}
+
+func (mg *Migrator) versions() ([]uint, error) {
+	var versions []uint
+
+	version, err := mg.src.First()
+	if errors.Is(err, os.ErrNotExist) {
+		return versions, nil
+	}
+
+	for err == nil {
+		versions = append(versions, version)
+		version, err = mg.src.Next(version)
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+func (mg *Migrator) identifier(version uint) (string, error) {
+	r, identifier, err := mg.src.ReadUp(version)
+	if err != nil {
+		return "", err
+	}
+
+	return identifier, r.Close()
+}
+
+func (mg *Migrator) read(version uint, direction source.Direction) (PlannedMigration, error) {
+	var (
+		r          io.ReadCloser
+		identifier string
+		err        error
+	)
+
+	if direction == source.Up {
+		r, identifier, err = mg.src.ReadUp(version)
+	} else {
+		r, identifier, err = mg.src.ReadDown(version)
+	}
+
+	if err != nil {
+		return PlannedMigration{}, fmt.Errorf("failed to read %s migration %d: %w", direction, version, err)
+	}
+
+	defer func() {
+		if err := r.Close(); err != nil {
+			slog.Error("failed to close migration file", "version", version, "error", err)
+		}
+	}()
+
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return PlannedMigration{}, err
+	}
+
+	return PlannedMigration{
+		Version:    version,
+		Identifier: identifier,
+		Direction:  direction,
+		SQL:        string(body),
+	}, nil
+}
+
+// openSource uses the migrations embedded into the binary unless an explicit
+// source URL such as file://migrations/ is configured.
+func openSource(migrationsPath string) (source.Driver, error) {
+	if migrationsPath == "" {
+		return iofs.New(sqlmigrations.FS, ".")
+	}
+
+	return source.Open(migrationsPath)
+}
+
+func ignoreNoChange(err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+
+	return err
+}
+
+type migrateLogger struct{}
+
+func (migrateLogger) Printf(format string, v ...any) {
+	slog.Info(strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func (migrateLogger) Verbose() bool {
+	return false
+}
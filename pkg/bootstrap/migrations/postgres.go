@@ -1,36 +1,46 @@
 package migrations
 
 import (
-	"database/sql"
 	"errors"
 	"log"
-	"os"
+	"time"
 
-	"github.com/golang-migrate/migrate/v4"
-	"github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func RunMigrationsPG(db *sql.DB) {
-	driver, err := postgres.WithInstance(db, &postgres.Config{})
+func RunMigrationsPG(pool *pgxpool.Pool, migrationsPath string, lockTimeout time.Duration) {
+	err := withMigrator(pool, migrationsPath, lockTimeout, func(m *Migrator) error {
+		return m.Up()
+	})
 	if err != nil {
-		log.Fatalf("bootstrap: failed to create pg driver: %v", err)
+		log.Fatalf("bootstrap: migration failed: %v", err)
 	}
 
-	migrationsPath := os.Getenv("MIGRATIONS_PATH")
-	if migrationsPath == "" {
-		migrationsPath = "file://migrations/"
-	}
+	log.Println("Migrations applied successfully")
+}
+
+// VerifySchemaPG checks that the database schema is up to date and returns
+// the expected schema version.
+func VerifySchemaPG(pool *pgxpool.Pool, migrationsPath string, lockTimeout time.Duration) uint {
+	var version uint
 
-	m, err := migrate.NewWithDatabaseInstance(migrationsPath, "postgres", driver)
+	err := withMigrator(pool, migrationsPath, lockTimeout, func(m *Migrator) error {
+		var err error
+		version, err = m.VerifySchema()
+		return err
+	})
 	if err != nil {
-		log.Fatalf("bootstrap: failed to create migrate instance: %v", err)
+		log.Fatalf("bootstrap: refusing to start: %v", err)
 	}
 
-	err = m.Up()
-	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
-		log.Fatalf("bootstrap: migration failed: %v", err)
+	return version
+}
+
+func withMigrator(pool *pgxpool.Pool, migrationsPath string, lockTimeout time.Duration, fn func(m *Migrator) error) error {
+	m, err := NewMigrator(pool, migrationsPath, lockTimeout)
+	if err != nil {
+		return err
 	}
 
-	log.Println("Migrations applied successfully")
+	return errors.Join(fn(m), m.Close())
 }
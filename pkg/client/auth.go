@@ -0,0 +1,33 @@
+package client
+
+import "net/http"
+
+// Authenticator adds credentials to every outgoing request, including retries.
+type Authenticator interface {
+	Authenticate(req *http.Request) error
+}
+
+type AuthenticatorFunc func(req *http.Request) error
+
+func (f AuthenticatorFunc) Authenticate(req *http.Request) error {
+	return f(req)
+}
+
+// BearerToken sends the token in the Authorization header.
+type BearerToken string
+
+func (t BearerToken) Authenticate(req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+string(t))
+	return nil
+}
+
+// HeaderAuth sets a fixed header, e.g. an API key.
+type HeaderAuth struct {
+	Name  string
+	Value string
+}
+
+func (a HeaderAuth) Authenticate(req *http.Request) error {
+	req.Header.Set(a.Name, a.Value)
+	return nil
+}
@@ -0,0 +1,268 @@
+// Package client is a typed Go client for the PR reviewer assignment HTTP API.
+//
+// Errors returned by the API are *APIError values that unwrap to the
+// sentinel matching their code (ErrNotFound, ErrPRMerged, ...).
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/common"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// RetryPolicy controls how failed requests are retried. GET requests are
+// retried on transport errors and 429, 502, 503 and 504 responses; POST
+// requests are only retried on 429, since the server may already have
+// applied them otherwise.
+type RetryPolicy struct {
+	// MaxAttempts includes the first attempt; 1 disables retries.
+	MaxAttempts int
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  100 * time.Millisecond,
+	MaxBackoff:  2 * time.Second,
+}
+
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	auth       Authenticator
+	retry      RetryPolicy
+	userAgent  string
+}
+
+type Option func(*Client)
+
+func WithAuthenticator(auth Authenticator) Option {
+	return func(c *Client) {
+		c.auth = auth
+	}
+}
+
+// WithToken is shorthand for WithAuthenticator(BearerToken(token)); an empty
+// token disables authentication.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		if token == "" {
+			c.auth = nil
+			return
+		}
+
+		c.auth = BearerToken(token)
+	}
+}
+
+// WithHTTPClient replaces the underlying HTTP client, including its timeout.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTimeout limits each attempt; use a context deadline to bound the whole
+// call including retries.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		httpClient := *c.httpClient
+		httpClient.Timeout = timeout
+		c.httpClient = &httpClient
+	}
+}
+
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		retry:      DefaultRetryPolicy,
+		userAgent:  "avito-pr-reviewers-client",
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encode request: %w", err)
+		}
+	}
+
+	return c.doRaw(ctx, method, path, query, "application/json", payload, "application/json", func(r io.Reader) error {
+		if err := json.NewDecoder(r).Decode(out); err != nil {
+			return fmt.Errorf("decode response: %w", err)
+		}
+		return nil
+	})
+}
+
+// doRaw sends payload as contentType and hands a successful response body to
+// read; errors are decoded as JSON like in do.
+func (c *Client) doRaw(ctx context.Context, method, path string, query url.Values, contentType string, payload []byte, accept string, read func(io.Reader) error) error {
+	attempts := max(c.retry.MaxAttempts, 1)
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(ctx, method, path, query, contentType, payload, accept)
+		if err == nil && resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+			defer resp.Body.Close()
+
+			return read(resp.Body)
+		}
+
+		var retryAfter time.Duration
+		if err == nil {
+			retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+			err = decodeError(resp)
+			resp.Body.Close()
+		}
+
+		if attempt >= attempts || !c.retryable(method, resp, err) {
+			return err
+		}
+
+		if err := sleep(ctx, max(retryAfter, c.backoff(attempt))); err != nil {
+			return err
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, contentType string, payload []byte, accept string) (*http.Response, error) {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if payload != nil {
+		reqBody = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", accept)
+	req.Header.Set("User-Agent", c.userAgent)
+	if payload != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	if c.auth != nil {
+		if err := c.auth.Authenticate(req); err != nil {
+			return nil, fmt.Errorf("authenticate: %w", err)
+		}
+	}
+
+	return c.httpClient.Do(req)
+}
+
+func (c *Client) retryable(method string, resp *http.Response, err error) bool {
+	if resp == nil {
+		// Transport error: retry only if the request is safe to repeat and
+		// the caller has not given up.
+		var urlErr *url.Error
+		return method == http.MethodGet && errors.As(err, &urlErr) &&
+			!errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return method == http.MethodGet
+	default:
+		return false
+	}
+}
+
+// backoff returns an exponential delay with jitter for the given attempt.
+func (c *Client) backoff(attempt int) time.Duration {
+	if c.retry.MinBackoff <= 0 {
+		return 0
+	}
+
+	delay := c.retry.MinBackoff << min(attempt-1, 16)
+	if c.retry.MaxBackoff > 0 && delay > c.retry.MaxBackoff {
+		delay = c.retry.MaxBackoff
+	}
+
+	return delay/2 + rand.N(delay/2+1)
+}
+
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func decodeError(resp *http.Response) error {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Code:       http.StatusText(resp.StatusCode),
+		Message:    resp.Status,
+		RequestID:  resp.Header.Get("X-Request-ID"),
+	}
+
+	var body common.ErrorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err == nil && body.Error.Code != "" {
+		apiErr.Code = body.Error.Code
+		apiErr.Message = body.Error.Message
+		apiErr.Details = body.Error.Details
+		if body.Error.RequestID != "" {
+			apiErr.RequestID = body.Error.RequestID
+		}
+	}
+
+	return apiErr
+}
@@ -0,0 +1,610 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/IlyaAGL/avito_autumn_2025/api"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/app/router"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/app/validation"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/service"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/infrastructure/metrics"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/models"
+	"github.com/IlyaAGL/avito_autumn_2025/pkg/client"
+	"github.com/gin-gonic/gin"
+)
+
+// The fakes embed the repository interfaces and implement only the methods
+// the tested requests reach; err is returned by every one of them.
+
+type fakeTeamRepo struct {
+	service.TeamRepository
+	err error
+}
+
+func (r *fakeTeamRepo) CreateTeam(context.Context, *models.Team, models.CreateTeamOptions) ([]models.ReviewerChange, error) {
+	return nil, r.err
+}
+
+func (r *fakeTeamRepo) GetTeam(_ context.Context, teamName string) (*models.Team, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return &models.Team{Name: teamName}, nil
+}
+
+func (r *fakeTeamRepo) ListTeams(context.Context) ([]models.TeamSummary, error) {
+	return nil, r.err
+}
+
+func (r *fakeTeamRepo) DeleteTeam(context.Context, string) error {
+	return r.err
+}
+
+type fakeUserRepo struct {
+	service.UserRepository
+}
+
+func (r *fakeUserRepo) GetUser(_ context.Context, userID string) (*models.User, error) {
+	return &models.User{UserID: userID, Username: userID, TeamName: "backend", IsActive: true}, nil
+}
+
+func (r *fakeUserRepo) GetActiveTeamMembers(context.Context, string, []string) ([]models.User, error) {
+	return nil, nil
+}
+
+type fakePRRepo struct {
+	service.PullRequestRepository
+	err error
+}
+
+func (r *fakePRRepo) CreatePR(context.Context, *models.PullRequest) error {
+	return r.err
+}
+
+// GetPR returns a merged PR for "pr-merged" and an open one reviewed by u2
+// otherwise.
+func (r *fakePRRepo) GetPR(_ context.Context, prID string) (*models.PullRequest, error) {
+	pr := &models.PullRequest{
+		ID:                prID,
+		Name:              "Add search",
+		AuthorID:          "u1",
+		Status:            "OPEN",
+		AssignedReviewers: []string{"u2"},
+	}
+	if prID == "pr-merged" {
+		pr.Status = "MERGED"
+	}
+
+	return pr, nil
+}
+
+func (r *fakePRRepo) MergePR(context.Context, string) error {
+	return r.err
+}
+
+type fakeTimeOffRepo struct {
+	service.TimeOffRepository
+	err error
+}
+
+func (r *fakeTimeOffRepo) CreateTimeOff(context.Context, *models.TimeOff) error {
+	return r.err
+}
+
+type fakeRepositoryRepo struct {
+	service.RepositoryRepository
+	err error
+}
+
+func (r *fakeRepositoryRepo) CreateRepository(context.Context, *models.Repository) error {
+	return r.err
+}
+
+func (r *fakeRepositoryRepo) DeleteRepository(context.Context, string) error {
+	return r.err
+}
+
+type fakeHealthRepo struct {
+	err error
+}
+
+func (r *fakeHealthRepo) Ping(context.Context) error {
+	return r.err
+}
+
+func (r *fakeHealthRepo) SchemaVersion(context.Context) (uint, bool, error) {
+	return 1, false, nil
+}
+
+// newServer serves the real router over fakes failing with repoErr. wrap, if
+// set, sits in front of the router.
+func newServer(t *testing.T, repoErr error, wrap func(http.Handler) http.Handler) *httptest.Server {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	if err := validation.Register(); err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := api.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appMetrics := metrics.New()
+	policy := models.ReviewPolicy{Reviewers: 2, Strategy: models.StrategyRandom}
+
+	teamRepo := &fakeTeamRepo{err: repoErr}
+	userRepo := &fakeUserRepo{}
+	prRepo := &fakePRRepo{err: repoErr}
+	reposRepo := &fakeRepositoryRepo{err: repoErr}
+
+	r, err := router.New(router.Config{Spec: doc, Metrics: appMetrics}, router.Services{
+		Users:        service.NewUserService(userRepo, prRepo),
+		PullRequests: service.NewPullRequestService(prRepo, userRepo, teamRepo, nil, reposRepo, appMetrics, policy),
+		Teams:        service.NewTeamService(teamRepo, userRepo, appMetrics),
+		TimeOff:      service.NewTimeOffService(&fakeTimeOffRepo{err: repoErr}, userRepo, teamRepo, appMetrics),
+		Repositories: service.NewRepositoryService(reposRepo, policy),
+		Health:       service.NewHealthService(&fakeHealthRepo{err: repoErr}, 1),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var h http.Handler = r
+	if wrap != nil {
+		h = wrap(h)
+	}
+
+	server := httptest.NewServer(h)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// failing answers the first failures requests with status and passes the
+// rest on; requests counts all of them.
+type failing struct {
+	next     http.Handler
+	status   int
+	failures int32
+	requests atomic.Int32
+}
+
+func (f *failing) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if f.requests.Add(1) <= f.failures {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(f.status)
+		_, _ = w.Write([]byte(`{"error":{"code":"INTERNAL_ERROR","message":"try again"}}`))
+		return
+	}
+
+	f.next.ServeHTTP(w, r)
+}
+
+var fastRetries = client.WithRetryPolicy(client.RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  time.Millisecond,
+	MaxBackoff:  5 * time.Millisecond,
+})
+
+func TestErrorCodes(t *testing.T) {
+	ctx := context.Background()
+	team := client.CreateTeamRequest{
+		TeamName: "backend",
+		Members:  []client.TeamMemberInput{{UserID: "u1", Username: "Alice", IsActive: true}},
+	}
+
+	tests := []struct {
+		code    string
+		repoErr error
+		call    func(c *client.Client) error
+		want    error
+		status  int
+	}{
+		{
+			code: "INVALID_REQUEST",
+			call: func(c *client.Client) error {
+				_, err := c.CreateTeam(ctx, client.CreateTeamRequest{TeamName: "backend"})
+				return err
+			},
+			want:   client.ErrInvalidRequest,
+			status: http.StatusBadRequest,
+		},
+		{
+			code:    "NOT_FOUND",
+			repoErr: models.ErrNotFound,
+			call: func(c *client.Client) error {
+				_, err := c.GetTeam(ctx, "backend")
+				return err
+			},
+			want:   client.ErrNotFound,
+			status: http.StatusNotFound,
+		},
+		{
+			code:    "TEAM_EXISTS",
+			repoErr: models.ErrTeamExists,
+			call: func(c *client.Client) error {
+				_, err := c.CreateTeam(ctx, team)
+				return err
+			},
+			want:   client.ErrTeamExists,
+			status: http.StatusConflict,
+		},
+		{
+			code:    "TEAM_NOT_EMPTY",
+			repoErr: models.ErrTeamNotEmpty,
+			call: func(c *client.Client) error {
+				_, err := c.DeleteTeam(ctx, "backend")
+				return err
+			},
+			want:   client.ErrTeamNotEmpty,
+			status: http.StatusConflict,
+		},
+		{
+			code: "MEMBER_CONFLICT",
+			repoErr: &models.MemberConflictError{Conflicts: []models.MemberConflict{
+				{UserID: "u1", TeamName: "frontend"},
+			}},
+			call: func(c *client.Client) error {
+				_, err := c.CreateTeam(ctx, team)
+				return err
+			},
+			want:   client.ErrMemberConflict,
+			status: http.StatusConflict,
+		},
+		{
+			code:    "PR_EXISTS",
+			repoErr: models.ErrPRExists,
+			call: func(c *client.Client) error {
+				_, err := c.CreatePR(ctx, client.CreatePRRequest{
+					PullRequestID:   "pr-1",
+					PullRequestName: "Add search",
+					AuthorID:        "u1",
+				})
+				return err
+			},
+			want:   client.ErrPRExists,
+			status: http.StatusConflict,
+		},
+		{
+			code: "PR_MERGED",
+			call: func(c *client.Client) error {
+				_, err := c.ReassignReviewer(ctx, client.ReassignRequest{PullRequestID: "pr-merged", OldUserID: "u2"})
+				return err
+			},
+			want:   client.ErrPRMerged,
+			status: http.StatusConflict,
+		},
+		{
+			code: "NOT_ASSIGNED",
+			call: func(c *client.Client) error {
+				_, err := c.ReassignReviewer(ctx, client.ReassignRequest{PullRequestID: "pr-1", OldUserID: "u3"})
+				return err
+			},
+			want:   client.ErrNotAssigned,
+			status: http.StatusConflict,
+		},
+		{
+			code: "NO_CANDIDATE",
+			call: func(c *client.Client) error {
+				_, err := c.ReassignReviewer(ctx, client.ReassignRequest{PullRequestID: "pr-1", OldUserID: "u2"})
+				return err
+			},
+			want:   client.ErrNoCandidate,
+			status: http.StatusConflict,
+		},
+		{
+			code:    "TIME_OFF_OVERLAP",
+			repoErr: models.ErrTimeOffOverlap,
+			call: func(c *client.Client) error {
+				_, err := c.CreateTimeOff(ctx, client.CreateTimeOffRequest{
+					UserID:   "u1",
+					StartsAt: time.Now().Add(time.Hour),
+					EndsAt:   time.Now().Add(48 * time.Hour),
+				})
+				return err
+			},
+			want:   client.ErrTimeOffOverlap,
+			status: http.StatusConflict,
+		},
+		{
+			code:    "REPOSITORY_EXISTS",
+			repoErr: models.ErrRepoExists,
+			call: func(c *client.Client) error {
+				_, err := c.CreateRepository(ctx, client.CreateRepositoryRequest{Repository: "backend-api"})
+				return err
+			},
+			want:   client.ErrRepoExists,
+			status: http.StatusConflict,
+		},
+		{
+			code:    "REPOSITORY_NOT_EMPTY",
+			repoErr: models.ErrRepoNotEmpty,
+			call: func(c *client.Client) error {
+				_, err := c.DeleteRepository(ctx, "backend-api")
+				return err
+			},
+			want:   client.ErrRepoNotEmpty,
+			status: http.StatusConflict,
+		},
+		{
+			code:    "INTERNAL_ERROR",
+			repoErr: errors.New("connection refused"),
+			call: func(c *client.Client) error {
+				_, err := c.ListTeams(ctx)
+				return err
+			},
+			want:   client.ErrInternal,
+			status: http.StatusInternalServerError,
+		},
+		{
+			code:    "INTERNAL_ERROR",
+			repoErr: errors.New("connection refused"),
+			call: func(c *client.Client) error {
+				_, err := c.MergePR(ctx, "pr-1")
+				return err
+			},
+			want:   client.ErrInternal,
+			status: http.StatusInternalServerError,
+		},
+		{
+			code:    "NOT_READY",
+			repoErr: errors.New("connection refused"),
+			call: func(c *client.Client) error {
+				_, err := c.Readiness(ctx)
+				return err
+			},
+			want:   client.ErrNotReady,
+			status: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			server := newServer(t, tt.repoErr, nil)
+			c := client.New(server.URL, client.WithRetryPolicy(client.RetryPolicy{MaxAttempts: 1}))
+
+			err := tt.call(c)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("error = %v, want %v", err, tt.want)
+			}
+
+			var apiErr *client.APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("error %T is not an *APIError", err)
+			}
+			if apiErr.Code != tt.code || apiErr.StatusCode != tt.status {
+				t.Errorf("got %s (status %d), want %s (status %d)", apiErr.Code, apiErr.StatusCode, tt.code, tt.status)
+			}
+		})
+	}
+}
+
+func TestMemberConflictDetails(t *testing.T) {
+	server := newServer(t, &models.MemberConflictError{Conflicts: []models.MemberConflict{
+		{UserID: "u2", TeamName: "frontend"},
+	}}, nil)
+	c := client.New(server.URL)
+
+	_, err := c.CreateTeam(context.Background(), client.CreateTeamRequest{
+		TeamName: "backend",
+		Members: []client.TeamMemberInput{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+		},
+	})
+
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("error = %v, want an *APIError", err)
+	}
+	if len(apiErr.Details) != 1 || apiErr.Details[0].Field != "members[1].user_id" {
+		t.Errorf("details = %+v, want members[1].user_id", apiErr.Details)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		failures int32
+		call     func(c *client.Client) error
+		requests int32
+		wantErr  bool
+	}{
+		{
+			name:     "GET retried on 503",
+			status:   http.StatusServiceUnavailable,
+			failures: 2,
+			call: func(c *client.Client) error {
+				_, err := c.ListTeams(context.Background())
+				return err
+			},
+			requests: 3,
+		},
+		{
+			name:     "GET retried on 502 until attempts run out",
+			status:   http.StatusBadGateway,
+			failures: 5,
+			call: func(c *client.Client) error {
+				_, err := c.ListTeams(context.Background())
+				return err
+			},
+			requests: 3,
+			wantErr:  true,
+		},
+		{
+			name:     "POST retried on 429",
+			status:   http.StatusTooManyRequests,
+			failures: 1,
+			call: func(c *client.Client) error {
+				_, err := c.CreateTeam(context.Background(), client.CreateTeamRequest{
+					TeamName: "backend",
+					Members:  []client.TeamMemberInput{{UserID: "u1", Username: "Alice", IsActive: true}},
+				})
+				return err
+			},
+			requests: 2,
+		},
+		{
+			name:     "POST not retried on 503",
+			status:   http.StatusServiceUnavailable,
+			failures: 1,
+			call: func(c *client.Client) error {
+				_, err := c.DeleteTeam(context.Background(), "backend")
+				return err
+			},
+			requests: 1,
+			wantErr:  true,
+		},
+		{
+			name:     "GET not retried on 500",
+			status:   http.StatusInternalServerError,
+			failures: 1,
+			call: func(c *client.Client) error {
+				_, err := c.ListTeams(context.Background())
+				return err
+			},
+			requests: 1,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var handler *failing
+			server := newServer(t, nil, func(next http.Handler) http.Handler {
+				handler = &failing{next: next, status: tt.status, failures: tt.failures}
+				return handler
+			})
+
+			err := tt.call(client.New(server.URL, fastRetries))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %v", err, tt.wantErr)
+			}
+			if got := handler.requests.Load(); got != tt.requests {
+				t.Errorf("requests = %d, want %d", got, tt.requests)
+			}
+		})
+	}
+}
+
+func TestNoRetryOnClientErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		repoErr error
+		call    func(c *client.Client) error
+	}{
+		{
+			name:    "404",
+			repoErr: models.ErrNotFound,
+			call: func(c *client.Client) error {
+				_, err := c.GetTeam(context.Background(), "backend")
+				return err
+			},
+		},
+		{
+			name: "400",
+			call: func(c *client.Client) error {
+				_, err := c.GetTeam(context.Background(), "")
+				return err
+			},
+		},
+		{
+			name:    "409",
+			repoErr: models.ErrTeamNotEmpty,
+			call: func(c *client.Client) error {
+				_, err := c.DeleteTeam(context.Background(), "backend")
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var handler *failing
+			server := newServer(t, tt.repoErr, func(next http.Handler) http.Handler {
+				handler = &failing{next: next}
+				return handler
+			})
+
+			if err := tt.call(client.New(server.URL, fastRetries)); err == nil {
+				t.Fatal("expected an error")
+			}
+			if got := handler.requests.Load(); got != 1 {
+				t.Errorf("requests = %d, want 1", got)
+			}
+		})
+	}
+}
+
+func TestInvalidRequestDetails(t *testing.T) {
+	server := newServer(t, nil, nil)
+	c := client.New(server.URL)
+
+	_, err := c.CreatePR(context.Background(), client.CreatePRRequest{
+		PullRequestID: "pr-1",
+		AuthorID:      "u1",
+	})
+
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "INVALID_REQUEST" {
+		t.Fatalf("error = %v, want INVALID_REQUEST", err)
+	}
+	if len(apiErr.Details) == 0 {
+		t.Fatal("no details")
+	}
+	for _, detail := range apiErr.Details {
+		if detail.Field != "pull_request_name" {
+			t.Errorf("details = %+v, want only pull_request_name", apiErr.Details)
+		}
+	}
+}
+
+func TestCreatePRRejectsIDWithRepository(t *testing.T) {
+	server := newServer(t, nil, nil)
+	c := client.New(server.URL)
+
+	_, err := c.CreatePR(context.Background(), client.CreatePRRequest{
+		PullRequestID:   "PR-1",
+		PullRequestName: "Add search",
+		AuthorID:        "u1",
+		Repository:      "backend-api",
+	})
+
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "INVALID_REQUEST" {
+		t.Fatalf("error = %v, want INVALID_REQUEST", err)
+	}
+	if len(apiErr.Details) != 1 || apiErr.Details[0].Field != "pull_request_id" {
+		t.Errorf("details = %+v, want pull_request_id", apiErr.Details)
+	}
+}
+
+func TestCreatePRRejectsIDWithColon(t *testing.T) {
+	server := newServer(t, nil, nil)
+	c := client.New(server.URL)
+
+	// "backend-api:1" is the ID of PR 1 of the backend-api repository.
+	_, err := c.CreatePR(context.Background(), client.CreatePRRequest{
+		PullRequestID:   "backend-api:1",
+		PullRequestName: "Add search",
+		AuthorID:        "u1",
+	})
+
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "INVALID_REQUEST" {
+		t.Fatalf("error = %v, want INVALID_REQUEST", err)
+	}
+	if len(apiErr.Details) != 1 || apiErr.Details[0].Field != "pull_request_id" {
+		t.Errorf("details = %+v, want pull_request_id", apiErr.Details)
+	}
+}
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// UploadCodeOwners replaces the CODEOWNERS rules of a repository with the
+// given file. Unsupported lines fail the call with ErrInvalidRequest and
+// nothing is applied; the APIError details name the offending lines.
+func (c *Client) UploadCodeOwners(ctx context.Context, repository string, file []byte) (*CodeOwnersUploadResponse, error) {
+	var resp CodeOwnersUploadResponse
+	query := url.Values{"repository": {repository}}
+	err := c.doRaw(ctx, http.MethodPost, "/codeOwners/upload", query, "text/plain", file, "application/json", func(r io.Reader) error {
+		if err := json.NewDecoder(r).Decode(&resp); err != nil {
+			return fmt.Errorf("decode response: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// GetCodeOwners returns the rules of a repository in file order.
+func (c *Client) GetCodeOwners(ctx context.Context, repository string) (*CodeOwnersRules, error) {
+	var resp CodeOwnersRules
+	if err := c.do(ctx, http.MethodGet, "/codeOwners/get", url.Values{"repository": {repository}}, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Sentinel errors for the API error codes. An *APIError unwraps to the one
+// matching its code, so callers can use errors.Is(err, client.ErrNotFound).
+var (
+	ErrInvalidRequest = errors.New("invalid request")
+	ErrNotFound       = errors.New("not found")
+	ErrTeamExists     = errors.New("team already exists")
+	ErrTeamNotEmpty   = errors.New("team still has members")
+	ErrMemberConflict = errors.New("user belongs to another team")
+	ErrPRExists       = errors.New("pull request already exists")
+	ErrPRMerged       = errors.New("pull request is merged")
+	ErrNotAssigned    = errors.New("reviewer is not assigned")
+	ErrNoCandidate    = errors.New("no replacement candidate")
+	ErrTimeOffOverlap = errors.New("time off overlaps an existing period")
+	ErrRepoExists     = errors.New("repository already exists")
+	ErrRepoNotEmpty   = errors.New("repository still has pull requests")
+	ErrInternal       = errors.New("internal server error")
+	ErrNotReady       = errors.New("service is not ready")
+)
+
+var errorsByCode = map[string]error{
+	"INVALID_REQUEST":      ErrInvalidRequest,
+	"NOT_FOUND":            ErrNotFound,
+	"TEAM_EXISTS":          ErrTeamExists,
+	"TEAM_NOT_EMPTY":       ErrTeamNotEmpty,
+	"MEMBER_CONFLICT":      ErrMemberConflict,
+	"PR_EXISTS":            ErrPRExists,
+	"PR_MERGED":            ErrPRMerged,
+	"NOT_ASSIGNED":         ErrNotAssigned,
+	"NO_CANDIDATE":         ErrNoCandidate,
+	"TIME_OFF_OVERLAP":     ErrTimeOffOverlap,
+	"REPOSITORY_EXISTS":    ErrRepoExists,
+	"REPOSITORY_NOT_EMPTY": ErrRepoNotEmpty,
+	"INTERNAL_ERROR":       ErrInternal,
+	"NOT_READY":            ErrNotReady,
+}
+
+// APIError is a non-2xx response decoded from the API error body.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	RequestID  string
+	// Details lists the invalid fields of an INVALID_REQUEST error or the
+	// conflicting users of a MEMBER_CONFLICT error.
+	Details []FieldError
+}
+
+func (e *APIError) Error() string {
+	message := e.Message
+	if len(e.Details) > 0 {
+		fields := make([]string, len(e.Details))
+		for i, detail := range e.Details {
+			fields[i] = detail.Field + " " + detail.Reason
+		}
+		message += ": " + strings.Join(fields, "; ")
+	}
+
+	if e.RequestID != "" {
+		return fmt.Sprintf("%s: %s (status %d, request %s)", e.Code, message, e.StatusCode, e.RequestID)
+	}
+
+	return fmt.Sprintf("%s: %s (status %d)", e.Code, message, e.StatusCode)
+}
+
+func (e *APIError) Unwrap() error {
+	return errorsByCode[e.Code]
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ExportResource selects what ExportCSV downloads.
+type ExportResource string
+
+const (
+	ExportResourceTeams        ExportResource = "/team/export"
+	ExportResourceUsers        ExportResource = "/users/export"
+	ExportResourcePullRequests ExportResource = "/pullRequest/export"
+)
+
+// ExportCSV writes the CSV export of resource to w. The teams export uses
+// the ImportTeams columns, so it can be uploaded back.
+func (c *Client) ExportCSV(ctx context.Context, resource ExportResource, w io.Writer) error {
+	return c.doRaw(ctx, http.MethodGet, string(resource), url.Values{"format": {"csv"}}, "", nil, "text/csv", func(r io.Reader) error {
+		_, err := io.Copy(w, r)
+		return err
+	})
+}
+
+func (c *Client) ExportTeams(ctx context.Context) (*TeamExport, error) {
+	var resp TeamExport
+	if err := c.do(ctx, http.MethodGet, string(ExportResourceTeams), url.Values{"format": {"json"}}, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Client) ExportUsers(ctx context.Context) (*UserExport, error) {
+	var resp UserExport
+	if err := c.do(ctx, http.MethodGet, string(ExportResourceUsers), url.Values{"format": {"json"}}, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Client) ExportPRs(ctx context.Context) (*ListPRsResponse, error) {
+	var resp ListPRsResponse
+	if err := c.do(ctx, http.MethodGet, string(ExportResourcePullRequests), url.Values{"format": {"json"}}, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
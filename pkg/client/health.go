@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+func (c *Client) Liveness(ctx context.Context) (*Liveness, error) {
+	var resp Liveness
+	if err := c.do(ctx, http.MethodGet, "/healthz", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Readiness reports the result of every readiness check. When the service is
+// not ready it returns the checks together with an error wrapping ErrNotReady.
+// It is not retried, so the result reflects a single probe.
+func (c *Client) Readiness(ctx context.Context) (*Readiness, error) {
+	resp, err := c.send(ctx, http.MethodGet, "/readyz", nil, "", nil, "application/json")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusServiceUnavailable {
+		return nil, decodeError(resp)
+	}
+
+	var readiness Readiness
+	if err := json.NewDecoder(resp.Body).Decode(&readiness); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusServiceUnavailable {
+		return &readiness, &APIError{
+			StatusCode: resp.StatusCode,
+			Code:       "NOT_READY",
+			Message:    "service is " + readiness.Status,
+			RequestID:  resp.Header.Get("X-Request-ID"),
+		}
+	}
+
+	return &readiness, nil
+}
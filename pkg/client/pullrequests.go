@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	pullrequests "github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/prs"
+)
+
+func (c *Client) CreatePR(ctx context.Context, req CreatePRRequest) (*CreatePRResponse, error) {
+	var resp CreatePRResponse
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/create", nil, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Client) MergePR(ctx context.Context, prID string) (*MergePRResponse, error) {
+	var resp MergePRResponse
+	req := pullrequests.MergeRequest{PullRequestID: prID}
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/merge", nil, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Client) ReassignReviewer(ctx context.Context, req ReassignRequest) (*ReassignResponse, error) {
+	var resp ReassignResponse
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/reassign", nil, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Client) GetPR(ctx context.Context, prID string) (*GetPRResponse, error) {
+	var resp GetPRResponse
+	if err := c.do(ctx, http.MethodGet, "/pullRequest/get", url.Values{"pull_request_id": {prID}}, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Client) ListPRs(ctx context.Context, params ListPRsParams) (*ListPRsResponse, error) {
+	query := url.Values{}
+	if params.Status != "" {
+		query.Set("status", params.Status)
+	}
+	if params.AuthorID != "" {
+		query.Set("author_id", params.AuthorID)
+	}
+	if params.Repository != "" {
+		query.Set("repository", params.Repository)
+	}
+	if params.Understaffed {
+		query.Set("understaffed", "true")
+	}
+
+	var resp ListPRsResponse
+	if err := c.do(ctx, http.MethodGet, "/pullRequest/list", query, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// ListUnderstaffedPRs returns the open PRs with fewer reviewers than the
+// policy asks for, newest first.
+func (c *Client) ListUnderstaffedPRs(ctx context.Context) (*ListPRsResponse, error) {
+	var resp ListPRsResponse
+	if err := c.do(ctx, http.MethodGet, "/pullRequest/understaffed", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Client) GetStats(ctx context.Context) (*Stats, error) {
+	var resp Stats
+	if err := c.do(ctx, http.MethodGet, "/pullRequest/statistics", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/repositories"
+)
+
+// CreateRepository registers a repository; it fails with ErrRepoExists if the
+// name is taken.
+func (c *Client) CreateRepository(ctx context.Context, req CreateRepositoryRequest) (*Repository, error) {
+	var resp Repository
+	if err := c.do(ctx, http.MethodPost, "/repository/add", nil, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Client) GetRepository(ctx context.Context, repository string) (*Repository, error) {
+	var resp Repository
+	if err := c.do(ctx, http.MethodGet, "/repository/get", url.Values{"repository": {repository}}, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Client) ListRepositories(ctx context.Context) (*RepositoryList, error) {
+	var resp RepositoryList
+	if err := c.do(ctx, http.MethodGet, "/repository/list", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// UpdateRepository replaces the owning team and the policy of a repository;
+// fields left empty are cleared.
+func (c *Client) UpdateRepository(ctx context.Context, req UpdateRepositoryRequest) (*Repository, error) {
+	var resp Repository
+	if err := c.do(ctx, http.MethodPost, "/repository/update", nil, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// DeleteRepository deletes a repository along with its CODEOWNERS rules; it
+// fails with ErrRepoNotEmpty while the repository has pull requests.
+func (c *Client) DeleteRepository(ctx context.Context, repository string) (*DeleteRepositoryResponse, error) {
+	var resp DeleteRepositoryResponse
+	req := repositories.DeleteRequest{Repository: repository}
+	if err := c.do(ctx, http.MethodPost, "/repository/delete", nil, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
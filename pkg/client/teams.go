@@ -0,0 +1,164 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/teams"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/users"
+)
+
+// CreateTeam creates a team. It fails with ErrTeamExists or
+// ErrMemberConflict unless req.Upsert or req.AllowMove relax the checks.
+func (c *Client) CreateTeam(ctx context.Context, req CreateTeamRequest) (*CreateTeamResponse, error) {
+	var resp CreateTeamResponse
+	if err := c.do(ctx, http.MethodPost, "/team/add", nil, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Client) GetTeam(ctx context.Context, teamName string) (*Team, error) {
+	var resp Team
+	if err := c.do(ctx, http.MethodGet, "/team/get", url.Values{"team_name": {teamName}}, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Client) DeactivateTeam(ctx context.Context, teamName string) (*DeactivateTeamResponse, error) {
+	var resp DeactivateTeamResponse
+	req := teams.BulkDeactivateRequest{TeamName: teamName}
+	if err := c.do(ctx, http.MethodPost, "/team/bulk", nil, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Client) ListTeams(ctx context.Context) (*TeamList, error) {
+	var resp TeamList
+	if err := c.do(ctx, http.MethodGet, "/team/list", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// AddTeamMembers adds members to an existing team. Users that belong to
+// another team fail the whole call with ErrMemberConflict.
+func (c *Client) AddTeamMembers(ctx context.Context, req AddMembersRequest) (*Team, error) {
+	var resp Team
+	if err := c.do(ctx, http.MethodPost, "/team/addMembers", nil, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Client) RemoveTeamMembers(ctx context.Context, req RemoveMembersRequest) (*RemoveMembersResponse, error) {
+	var resp RemoveMembersResponse
+	if err := c.do(ctx, http.MethodPost, "/team/removeMembers", nil, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Client) MoveUser(ctx context.Context, userID, teamName string) (*MoveUserResponse, error) {
+	var resp MoveUserResponse
+	req := users.MoveRequest{UserID: userID, TeamName: teamName}
+	if err := c.do(ctx, http.MethodPost, "/team/moveUser", nil, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Client) RenameTeam(ctx context.Context, teamName, newTeamName string) (*Team, error) {
+	var resp Team
+	req := teams.RenameRequest{TeamName: teamName, NewTeamName: newTeamName}
+	if err := c.do(ctx, http.MethodPost, "/team/rename", nil, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// DeleteTeam deletes a team without members; otherwise it fails with
+// ErrTeamNotEmpty.
+func (c *Client) DeleteTeam(ctx context.Context, teamName string) (*DeleteTeamResponse, error) {
+	var resp DeleteTeamResponse
+	req := teams.DeleteRequest{TeamName: teamName}
+	if err := c.do(ctx, http.MethodPost, "/team/delete", nil, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// SetTeamReviewLimits replaces the team defaults; a nil field means no limit.
+func (c *Client) SetTeamReviewLimits(ctx context.Context, teamName string, limits ReviewLimits) (*TeamReviewLimits, error) {
+	var resp TeamReviewLimits
+	req := teams.ReviewLimitsRequest{TeamName: teamName, ReviewLimits: limits}
+	if err := c.do(ctx, http.MethodPost, "/team/setReviewLimits", nil, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// GetReviewLoad returns every member's open reviews against their effective
+// limits.
+func (c *Client) GetReviewLoad(ctx context.Context, teamName string) (*ReviewLoad, error) {
+	var resp ReviewLoad
+	if err := c.do(ctx, http.MethodGet, "/team/reviewLoad", url.Values{"team_name": {teamName}}, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// SyncTeams makes the server roster match req, which lists every team and
+// member; with req.DryRun it only returns the plan.
+func (c *Client) SyncTeams(ctx context.Context, req SyncRequest) (*SyncResponse, error) {
+	var resp SyncResponse
+	if err := c.do(ctx, http.MethodPost, "/team/sync", nil, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// ImportTeams uploads a CSV file with the columns team_name, user_id,
+// username and optionally is_active. In the default atomic mode a rejected
+// row fails the call with ErrInvalidRequest and nothing is applied; the
+// APIError details name the offending lines.
+func (c *Client) ImportTeams(ctx context.Context, csv []byte, params ImportParams) (*ImportResponse, error) {
+	query := url.Values{}
+	if params.Mode != "" {
+		query.Set("mode", params.Mode)
+	}
+	if params.AllowMove {
+		query.Set("allow_move", "true")
+	}
+
+	var resp ImportResponse
+	err := c.doRaw(ctx, http.MethodPost, "/team/import", query, "text/csv", csv, "application/json", func(r io.Reader) error {
+		if err := json.NewDecoder(r).Decode(&resp); err != nil {
+			return fmt.Errorf("decode response: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
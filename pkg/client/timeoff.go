@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/timeoff"
+)
+
+// CreateTimeOff schedules an absence period. A period overlapping another
+// one of the same user fails with ErrTimeOffOverlap.
+func (c *Client) CreateTimeOff(ctx context.Context, req CreateTimeOffRequest) (*CreateTimeOffResponse, error) {
+	var resp CreateTimeOffResponse
+	if err := c.do(ctx, http.MethodPost, "/users/timeOff", nil, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// ListTimeOff returns the current and upcoming periods of a user.
+func (c *Client) ListTimeOff(ctx context.Context, userID string) (*TimeOffList, error) {
+	var resp TimeOffList
+	if err := c.do(ctx, http.MethodGet, "/users/timeOff", url.Values{"user_id": {userID}}, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Client) CancelTimeOff(ctx context.Context, userID string, timeOffID int64) (*CancelTimeOffResponse, error) {
+	var resp CancelTimeOffResponse
+	req := timeoff.CancelRequest{UserID: userID, TimeOffID: timeOffID}
+	if err := c.do(ctx, http.MethodPost, "/users/timeOff/cancel", nil, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// ListAway returns the members of a team who are on time off now.
+func (c *Client) ListAway(ctx context.Context, teamName string) (*AwayList, error) {
+	var resp AwayList
+	if err := c.do(ctx, http.MethodGet, "/team/away", url.Values{"team_name": {teamName}}, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/codeowners"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/common"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/health"
+	pullrequests "github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/prs"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/repositories"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/teams"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/timeoff"
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/users"
+)
+
+// The request and response types are aliases of the DTOs the server uses,
+// so the client cannot drift from the handlers' JSON shapes.
+
+type (
+	CreateTeamRequest      = teams.CreateRequest
+	TeamMemberInput        = teams.MemberCreate
+	CreateTeamResponse     = teams.CreateResponse
+	DeactivateTeamResponse = teams.BulkDeactivateResponse
+	AddMembersRequest      = teams.AddMembersRequest
+	RemoveMembersRequest   = teams.RemoveMembersRequest
+	RemoveMembersResponse  = teams.RemoveMembersResponse
+	RenameTeamRequest      = teams.RenameRequest
+	DeleteTeamResponse     = teams.DeleteResponse
+	TeamList               = teams.ListResponse
+	TeamSummary            = teams.TeamSummary
+	SyncRequest            = teams.SyncRequest
+	SyncTeam               = teams.SyncTeam
+	SyncMember             = teams.SyncMember
+	SyncResponse           = teams.SyncResponse
+	SyncUserChange         = teams.SyncUserChange
+	ImportParams           = teams.ImportParams
+	ImportResponse         = teams.ImportResponse
+	ImportError            = teams.ImportError
+	TeamExport             = teams.ExportResponse
+	TeamReviewLimits       = teams.ReviewLimitsResponse
+	ReviewLoad             = teams.ReviewLoadResponse
+	MemberLoad             = teams.MemberLoad
+	Team                   = teams.TeamResponse
+	TeamMember             = teams.MemberResponse
+)
+
+type (
+	SetActiveResponse = users.SetActiveResponse
+	MoveUserResponse  = users.MoveResponse
+	UserExport        = users.ExportResponse
+	UserReviewLimits  = users.ReviewLimitsResponse
+	UserSkills        = users.SkillsResponse
+	User              = users.UserResponse
+	UserReviews       = users.ReviewResponse
+	PullRequestShort  = users.PullRequestShort
+)
+
+type (
+	CreateTimeOffRequest  = timeoff.CreateRequest
+	CreateTimeOffResponse = timeoff.CreateResponse
+	CancelTimeOffResponse = timeoff.CancelResponse
+	TimeOffList           = timeoff.ListResponse
+	TimeOff               = timeoff.TimeOffResponse
+	AwayList              = timeoff.AwayResponse
+	AwayMember            = timeoff.AwayMember
+)
+
+type (
+	CreatePRRequest  = pullrequests.CreateRequest
+	CreatePRResponse = pullrequests.CreateResponse
+	MergePRResponse  = pullrequests.MergeResponse
+	GetPRResponse    = pullrequests.GetResponse
+	ListPRsParams    = pullrequests.ListParams
+	ListPRsResponse  = pullrequests.ListResponse
+	ReassignRequest  = pullrequests.ReassignRequest
+	ReassignResponse = pullrequests.ReassignResponse
+	PullRequest      = pullrequests.PullRequestResponse
+	ReviewerMatch    = pullrequests.ReviewerMatch
+)
+
+type (
+	CreateRepositoryRequest  = repositories.CreateRequest
+	UpdateRepositoryRequest  = repositories.UpdateRequest
+	DeleteRepositoryResponse = repositories.DeleteResponse
+	RepositoryList           = repositories.ListResponse
+	Repository               = repositories.RepositoryResponse
+	// RepositoryPolicy overrides the service defaults; a nil field keeps
+	// the default.
+	RepositoryPolicy = repositories.Policy
+)
+
+type (
+	CodeOwnersUploadResponse = codeowners.SetResponse
+	CodeOwnersRules          = codeowners.RulesResponse
+	CodeOwnersRule           = codeowners.RuleResponse
+)
+
+type (
+	Stats            = common.StatsResponse
+	ReviewStats      = common.ReviewStats
+	PullRequestStats = common.PullRequestStats
+	FieldError       = common.FieldError
+	// ReviewLimits are capacity settings; a nil field means unset.
+	ReviewLimits = common.ReviewLimits
+	// ReviewerChange is an open review handed over or dropped because its
+	// reviewer left the author's team.
+	ReviewerChange = common.ReviewerChange
+)
+
+type (
+	Liveness    = health.LivenessResponse
+	Readiness   = health.ReadinessResponse
+	HealthCheck = health.Check
+)
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/IlyaAGL/avito_autumn_2025/internal/domain/dto/users"
+)
+
+func (c *Client) SetUserActive(ctx context.Context, userID string, isActive bool) (*SetActiveResponse, error) {
+	var resp SetActiveResponse
+	req := users.SetActiveRequest{UserID: userID, IsActive: isActive}
+	if err := c.do(ctx, http.MethodPost, "/users/setIsActive", nil, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Client) GetUserReviews(ctx context.Context, userID string) (*UserReviews, error) {
+	var resp UserReviews
+	if err := c.do(ctx, http.MethodGet, "/users/getReview", url.Values{"user_id": {userID}}, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// SetUserReviewLimits replaces the user's own limits; a nil field falls back
+// to the team default.
+func (c *Client) SetUserReviewLimits(ctx context.Context, userID string, limits ReviewLimits) (*UserReviewLimits, error) {
+	var resp UserReviewLimits
+	req := users.ReviewLimitsRequest{UserID: userID, ReviewLimits: limits}
+	if err := c.do(ctx, http.MethodPost, "/users/setReviewLimits", nil, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *Client) GetUserSkills(ctx context.Context, userID string) (*UserSkills, error) {
+	var resp UserSkills
+	if err := c.do(ctx, http.MethodGet, "/users/skills", url.Values{"user_id": {userID}}, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// SetUserSkills replaces the user's skills; PR tags are matched against them
+// when reviewers are picked.
+func (c *Client) SetUserSkills(ctx context.Context, userID string, skills []string) (*UserSkills, error) {
+	if skills == nil {
+		skills = []string{}
+	}
+
+	var resp UserSkills
+	req := users.SkillsRequest{UserID: userID, Skills: skills}
+	if err := c.do(ctx, http.MethodPost, "/users/setSkills", nil, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,239 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// clearEnv hides the config variables of the environment the tests run in.
+func clearEnv(t *testing.T) {
+	t.Helper()
+
+	t.Setenv("CONFIG_FILE", "")
+	for _, s := range settings {
+		t.Setenv(s.env, "")
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	clearEnv(t)
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	file := "server:\n  port: 8081\n  grpc_port: 9091\nlog:\n  level: debug\nreview:\n  strategy: least_loaded\n"
+	if err := os.WriteFile(path, []byte(file), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		file  bool
+		env   map[string]string
+		flags []string
+		want  func(c *Config) any
+		value any
+	}{
+		{
+			name:  "default",
+			want:  func(c *Config) any { return c.Server.Port },
+			value: 8080,
+		},
+		{
+			name:  "file over default",
+			file:  true,
+			want:  func(c *Config) any { return c.Server.Port },
+			value: 8081,
+		},
+		{
+			name:  "env over file",
+			file:  true,
+			env:   map[string]string{"SERVER_PORT": "8082"},
+			want:  func(c *Config) any { return c.Server.Port },
+			value: 8082,
+		},
+		{
+			name:  "flag over env",
+			file:  true,
+			env:   map[string]string{"SERVER_PORT": "8082"},
+			flags: []string{"-server.port=8083"},
+			want:  func(c *Config) any { return c.Server.Port },
+			value: 8083,
+		},
+		{
+			name:  "empty env is ignored",
+			file:  true,
+			env:   map[string]string{"LOG_LEVEL": ""},
+			want:  func(c *Config) any { return c.Log.Level },
+			value: "debug",
+		},
+		{
+			name:  "file keeps defaults it leaves out",
+			file:  true,
+			want:  func(c *Config) any { return c.Server.ShutdownTimeout },
+			value: 30 * time.Second,
+		},
+		{
+			name:  "flag over file",
+			file:  true,
+			flags: []string{"-review.strategy", "random"},
+			want:  func(c *Config) any { return c.Review.Strategy },
+			value: "random",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			args := tt.flags
+			if tt.file {
+				args = append([]string{"-config", path}, args...)
+			}
+
+			cfg, err := Load(flag.NewFlagSet("test", flag.ContinueOnError), args)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := tt.want(cfg); got != tt.value {
+				t.Errorf("got %v, want %v", got, tt.value)
+			}
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	clearEnv(t)
+
+	jsonFile := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(jsonFile, []byte("{}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		env   map[string]string
+		flags []string
+		want  string
+	}{
+		{name: "bad env value", env: map[string]string{"DB_MAX_CONNS": "many"}, want: "env DB_MAX_CONNS"},
+		{name: "bad flag value", flags: []string{"-server.drain-delay=soon"}, want: "flag -server.drain-delay"},
+		{name: "invalid result", flags: []string{"-review.reviewers=0"}, want: "review.reviewers must be in 1..10"},
+		{name: "unsupported file", flags: []string{"-config", jsonFile}, want: "unsupported config file format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			_, err := Load(flag.NewFlagSet("test", flag.ContinueOnError), tt.flags)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(c *Config)
+		want   string
+	}{
+		{"port out of range", func(c *Config) { c.Server.Port = 70000 }, "server.port must be in 1..65535"},
+		{"grpc port out of range", func(c *Config) { c.Server.GRPCPort = 0 }, "server.grpc_port must be in 1..65535"},
+		{"grpc port equals port", func(c *Config) { c.Server.GRPCPort = c.Server.Port }, "server.grpc_port must differ from server.port"},
+		{"read header timeout", func(c *Config) { c.Server.ReadHeaderTimeout = 0 }, "server.read_header_timeout must be positive"},
+		{"shutdown timeout", func(c *Config) { c.Server.ShutdownTimeout = 0 }, "server.shutdown_timeout must be positive"},
+		{"drain delay", func(c *Config) { c.Server.DrainDelay = -time.Second }, "server.drain_delay must not be negative"},
+		{"database url", func(c *Config) { c.Database.URL = "" }, "database.url is required"},
+		{"max conns", func(c *Config) { c.Database.MaxConns = 0 }, "database.max_conns must be positive"},
+		{"min conns above max", func(c *Config) { c.Database.MinConns = 11 }, "database.min_conns must be in 0..max_conns"},
+		{"connect timeout", func(c *Config) { c.Database.ConnectTimeout = 0 }, "database.connect_timeout must be positive"},
+		{"ping retries", func(c *Config) { c.Database.PingRetries = 0 }, "database.ping_retries must be positive"},
+		{"lock timeout", func(c *Config) { c.Migrations.LockTimeout = 0 }, "migrations.lock_timeout must be positive"},
+		{"log level", func(c *Config) { c.Log.Level = "loud" }, "log.level must be one of"},
+		{"log format", func(c *Config) { c.Log.Format = "xml" }, "log.format must be json or text"},
+		{"otlp without endpoint", func(c *Config) { c.Tracing.Exporter, c.Tracing.Endpoint = "otlp", "" }, "tracing.endpoint is required"},
+		{"tracing exporter", func(c *Config) { c.Tracing.Exporter = "jaeger" }, "tracing.exporter must be none, stdout or otlp"},
+		{"sample ratio", func(c *Config) { c.Tracing.SampleRatio = 1.5 }, "tracing.sample_ratio must be in 0..1"},
+		{"scheduler interval", func(c *Config) { c.Scheduler.Interval = 0 }, "scheduler.interval must be positive"},
+		{"reviewers", func(c *Config) { c.Review.Reviewers = 11 }, "review.reviewers must be in 1..10"},
+		{"strategy", func(c *Config) { c.Review.Strategy = "round_robin" }, "review.strategy must be random or least_loaded"},
+		{"min experts", func(c *Config) { c.Review.MinExperts = -1 }, "review.min_experts must not be negative"},
+	}
+
+	if err := Default().Validate(); err != nil {
+		t.Fatalf("default config: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			tt.change(cfg)
+
+			err := cfg.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	tests := []struct {
+		name  string
+		dsn   string
+		token string
+		want  string
+	}{
+		{
+			name: "url",
+			dsn:  "postgres://app:s3cret@db:5432/reviews?sslmode=disable",
+			want: "postgres://app:xxxxx@db:5432/reviews?sslmode=disable",
+		},
+		{
+			name: "url without password",
+			dsn:  "postgres://app@db:5432/reviews",
+			want: "postgres://app@db:5432/reviews",
+		},
+		{
+			name:  "key value",
+			dsn:   "host=db user=app password=s3cret dbname=reviews",
+			token: "scim-s3cret",
+			want:  "host=db user=app password=xxxxx dbname=reviews",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			cfg.Database.URL = tt.dsn
+			cfg.SCIM.Token = tt.token
+
+			redacted := cfg.Redacted()
+			if redacted.Database.URL != tt.want {
+				t.Errorf("database.url = %q, want %q", redacted.Database.URL, tt.want)
+			}
+			if tt.token != "" && redacted.SCIM.Token != "xxxxx" {
+				t.Errorf("scim.token = %q, want it masked", redacted.SCIM.Token)
+			}
+			if tt.token == "" && redacted.SCIM.Token != "" {
+				t.Errorf("scim.token = %q, want it left empty", redacted.SCIM.Token)
+			}
+			if cfg.Database.URL != tt.dsn || cfg.SCIM.Token != tt.token {
+				t.Error("Redacted changed the original config")
+			}
+			if out := cfg.String(); strings.Contains(out, "s3cret") {
+				t.Errorf("String() leaks a secret:\n%s", out)
+			}
+		})
+	}
+}